@@ -0,0 +1,165 @@
+// Command fastssm-import scans the SSM parameters under a path and prints the import
+// blocks and the fastssm_parameter resources bringing them under Terraform management.
+//
+//	fastssm-import -path /app > app.tf
+//
+// The values of String and StringList parameters are written to insecure_value. Those
+// of SecureString parameters never leave SSM, they're read from a sensitive variable
+// declared alongside.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fastssm-import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("path", "", "path of the parameters to import, e.g. /app")
+	recursive := flags.Bool("recursive", true, "import the parameters of the whole hierarchy under the path, not only its direct children")
+	region := flags.String("region", "", "region of the parameters, defaults to the region of the AWS configuration")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: fastssm-import -path /app [-recursive=false] [-region eu-west-1]\n\n"+
+			"Prints the import blocks and fastssm_parameter resources of the SSM parameters under the path.\n"+
+			"Credentials are read from the environment and the shared AWS configuration files.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		flags.Usage()
+		return 2
+	}
+
+	var options []func(*config.LoadOptions) error
+	if *region != "" {
+		options = append(options, config.WithRegion(*region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		fmt.Fprintf(stderr, "fastssm-import: loading the AWS configuration: %s\n", err)
+		return 1
+	}
+
+	parameters, err := scan(ctx, ssm.NewFromConfig(cfg), *path, *recursive)
+	if err != nil {
+		fmt.Fprintf(stderr, "fastssm-import: %s\n", err)
+		return 1
+	}
+	if len(parameters) == 0 {
+		fmt.Fprintf(stderr, "fastssm-import: no parameter found under %s\n", *path)
+		return 1
+	}
+
+	fmt.Fprint(stdout, generate(parameters))
+
+	return 0
+}
+
+// scan returns the parameters under path sorted by name, without decrypting the
+// SecureString ones.
+func scan(ctx context.Context, conn *ssm.Client, path string, recursive bool) ([]ssm_types.Parameter, error) {
+	var parameters []ssm_types.Parameter
+
+	pages := ssm.NewGetParametersByPathPaginator(conn, &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(recursive),
+		WithDecryption: aws.Bool(false),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading the parameters under %s: %w", path, err)
+		}
+		parameters = append(parameters, page.Parameters...)
+	}
+
+	slices.SortFunc(parameters, func(a, b ssm_types.Parameter) int {
+		return strings.Compare(aws.ToString(a.Name), aws.ToString(b.Name))
+	})
+
+	return parameters, nil
+}
+
+// invalidLabelChars matches the characters parameter names allow and resource names don't.
+var invalidLabelChars = regexache.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// resourceName derives the name of the resource of a parameter, e.g. app_db_host for /app/db/host.
+func resourceName(parameter string) string {
+	name := strings.Trim(invalidLabelChars.ReplaceAllString(parameter, "_"), "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' || name[0] == '-' {
+		name = "parameter_" + name
+	}
+
+	return name
+}
+
+// generate renders the import block and the resource of every parameter, and the
+// variables holding the values of the SecureString ones.
+func generate(parameters []ssm_types.Parameter) string {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	used := make(map[string]int)
+	for i, parameter := range parameters {
+		name := aws.ToString(parameter.Name)
+
+		// Parameter names differing only by their punctuation map to the same resource name
+		label := resourceName(name)
+		used[label]++
+		if n := used[label]; n > 1 {
+			label = fmt.Sprintf("%s_%d", label, n)
+		}
+
+		if i > 0 {
+			body.AppendNewline()
+		}
+
+		importBlock := body.AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: "fastssm_parameter"}, hcl.TraverseAttr{Name: label}})
+		importBlock.SetAttributeValue("id", cty.StringVal(name))
+		body.AppendNewline()
+
+		if parameter.Type == ssm_types.ParameterTypeSecureString {
+			variable := body.AppendNewBlock("variable", []string{label}).Body()
+			variable.SetAttributeValue("description", cty.StringVal("Value of the SecureString parameter "+name))
+			variable.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+			variable.SetAttributeValue("sensitive", cty.True)
+			body.AppendNewline()
+		}
+
+		resource := body.AppendNewBlock("resource", []string{"fastssm_parameter", label}).Body()
+		resource.SetAttributeValue("name", cty.StringVal(name))
+		resource.SetAttributeValue("type", cty.StringVal(string(parameter.Type)))
+		if dataType := aws.ToString(parameter.DataType); dataType != "" && dataType != "text" {
+			resource.SetAttributeValue("data_type", cty.StringVal(dataType))
+		}
+		if parameter.Type == ssm_types.ParameterTypeSecureString {
+			resource.SetAttributeTraversal("value", hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: label}})
+		} else {
+			resource.SetAttributeValue("insecure_value", cty.StringVal(aws.ToString(parameter.Value)))
+		}
+	}
+
+	return string(file.Bytes())
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+const expectedImport = `import {
+  to = fastssm_parameter.app_db_host
+  id = "/app/db/host"
+}
+
+resource "fastssm_parameter" "app_db_host" {
+  name           = "/app/db/host"
+  type           = "String"
+  insecure_value = "db.internal"
+}
+
+import {
+  to = fastssm_parameter.app_db_password
+  id = "/app/db/password"
+}
+
+variable "app_db_password" {
+  description = "Value of the SecureString parameter /app/db/password"
+  type        = string
+  sensitive   = true
+}
+
+resource "fastssm_parameter" "app_db_password" {
+  name  = "/app/db/password"
+  type  = "SecureString"
+  value = var.app_db_password
+}
+
+import {
+  to = fastssm_parameter.app_greeting
+  id = "/app/greeting"
+}
+
+resource "fastssm_parameter" "app_greeting" {
+  name           = "/app/greeting"
+  type           = "String"
+  insecure_value = "Hello $${name}"
+}
+
+import {
+  to = fastssm_parameter.app_hosts
+  id = "/app/hosts"
+}
+
+resource "fastssm_parameter" "app_hosts" {
+  name           = "/app/hosts"
+  type           = "StringList"
+  insecure_value = "a,b"
+}
+`
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+
+	for name, parameter := range map[string]struct {
+		Type  ssm_types.ParameterType
+		Value string
+	}{
+		"/app/db/host":     {ssm_types.ParameterTypeString, "db.internal"},
+		"/app/db/password": {ssm_types.ParameterTypeSecureString, "secret"},
+		"/app/greeting":    {ssm_types.ParameterTypeString, "Hello ${name}"},
+		"/app/hosts":       {ssm_types.ParameterTypeStringList, "a,b"},
+		"/other/host":      {ssm_types.ParameterTypeString, "other.internal"},
+	} {
+		_, err := conn.PutParameter(context.Background(), &ssm.PutParameterInput{
+			Name:  aws.String(name),
+			Type:  parameter.Type,
+			Value: aws.String(parameter.Value),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	parameters, err := scan(context.Background(), conn, "/app", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := generate(parameters); got != expectedImport {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedImport, got)
+	}
+
+	parameters, err = scan(context.Background(), conn, "/app", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(parameters) != 2 {
+		t.Errorf("expected the 2 direct children of /app, got %d", len(parameters))
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{Name: "/app/db/host", Expected: "app_db_host"},
+		{Name: "plain", Expected: "plain"},
+		{Name: "/app/db.host-primary", Expected: "app_db_host-primary"},
+		{Name: "/2024/release", Expected: "parameter_2024_release"},
+		{Name: "/app//api key", Expected: "app_api_key"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resourceName(testCase.Name); got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
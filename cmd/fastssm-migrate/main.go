@@ -0,0 +1,184 @@
+// Command fastssm-migrate reads a Terraform state and prints the moved blocks
+// migrating its aws_ssm_parameter resources to fastssm_parameter, along with the
+// required_providers entry of the provider, grouped by module.
+//
+//	terraform state pull | fastssm-migrate > migrate.tf
+//
+// With -rewrite, it rewrites the configuration of the directories instead: the
+// aws_ssm_parameter resources and the references to them turn into fastssm_parameter,
+// their unsupported attributes are commented out, their aws provider becomes the
+// fastssm provider of the same alias, and the aws_ssm_parameter data
+// sources are flagged for conversion.
+//
+//	fastssm-migrate -rewrite .
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/YakDriver/regexache"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fastssm-migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "-", "path of the state file, - reads it from stdin, e.g. piped from `terraform state pull`")
+	rewrite := flags.Bool("rewrite", false, "rewrite the .tf files of the directories given as arguments, recursively, instead of reading a state")
+	dryRun := flags.Bool("dry-run", false, "with -rewrite, report the changes without writing them")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: fastssm-migrate [-state terraform.tfstate]\n"+
+			"       fastssm-migrate -rewrite [-dry-run] [directory...]\n\n"+
+			"Prints the moved blocks migrating the aws_ssm_parameter resources of the state to fastssm_parameter,\n"+
+			"or rewrites the configuration with -rewrite.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *rewrite {
+		dirs := flags.Args()
+		if len(dirs) == 0 {
+			dirs = []string{"."}
+		}
+
+		var report strings.Builder
+		err := rewriteDirs(dirs, *dryRun, &report)
+		fmt.Fprint(stdout, report.String())
+		if err != nil {
+			fmt.Fprintf(stderr, "fastssm-migrate: %s\n", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	in := stdin
+	if *statePath != "-" {
+		f, err := os.Open(*statePath)
+		if err != nil {
+			fmt.Fprintf(stderr, "fastssm-migrate: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var st state
+	if err := json.NewDecoder(in).Decode(&st); err != nil {
+		fmt.Fprintf(stderr, "fastssm-migrate: reading the state: %s\n", err)
+		return 1
+	}
+
+	out, err := generate(st)
+	if err != nil {
+		fmt.Fprintf(stderr, "fastssm-migrate: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprint(stdout, out)
+
+	return 0
+}
+
+// state is the part of a Terraform state, format version 4, read by the migration.
+type state struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+}
+
+type stateResource struct {
+	Module   string `json:"module"`
+	Mode     string `json:"mode"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+}
+
+// instanceKeys matches the instance keys of a module address, e.g. the [0] of module.app[0].
+var instanceKeys = regexache.MustCompile(`\[[^\]]*\]`)
+
+// providerAlias matches the alias of a provider address, e.g. the west of provider["registry.terraform.io/hashicorp/aws"].west.
+var providerAlias = regexache.MustCompile(`^provider\["[^"]+"\]\.(.+)$`)
+
+const requiredProviders = `terraform {
+  required_providers {
+    fastssm = {
+      source = "rumenvasilev/fastssm"
+    }
+  }
+}
+`
+
+// generate renders the migration of the aws_ssm_parameter resources of st. The moved
+// blocks of a module go to its own source, next to the resources they move, and cover
+// every instance of the module.
+func generate(st state) (string, error) {
+	if st.Version != 4 {
+		return "", fmt.Errorf("unsupported state format version %d, expected 4", st.Version)
+	}
+
+	// The moved blocks by module, keyed by the module call path without instance keys
+	modules := make(map[string][]string)
+	for _, r := range st.Resources {
+		if r.Mode != "managed" || r.Type != "aws_ssm_parameter" {
+			continue
+		}
+
+		module := instanceKeys.ReplaceAllString(r.Module, "")
+
+		var b strings.Builder
+		// Modules receive their provider configurations through `providers`, only the
+		// root module refers to aliases by name
+		if m := providerAlias.FindStringSubmatch(r.Provider); m != nil && module == "" {
+			fmt.Fprintf(&b, "# aws_ssm_parameter.%s uses the aws.%s provider configuration, set `provider = fastssm.%s` on fastssm_parameter.%s\n", r.Name, m[1], m[1], r.Name)
+		}
+		fmt.Fprintf(&b, "moved {\n  from = aws_ssm_parameter.%s\n  to   = fastssm_parameter.%s\n}\n", r.Name, r.Name)
+
+		// Instances of a module share its source, the block is needed once
+		if !slices.Contains(modules[module], b.String()) {
+			modules[module] = append(modules[module], b.String())
+		}
+	}
+
+	if len(modules) == 0 {
+		return "", fmt.Errorf("no aws_ssm_parameter resource found in the state")
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		if name == "" {
+			b.WriteString("# Root module\n\n")
+		} else {
+			fmt.Fprintf(&b, "# Source of %s\n\n", name)
+		}
+		b.WriteString(requiredProviders)
+
+		for _, block := range modules[name] {
+			b.WriteString("\n")
+			b.WriteString(block)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_ssm_parameter",
+      "name": "db_password",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 0, "attributes": {"name": "/app/db/password"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_ssm_parameter",
+      "name": "replica",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+      "instances": [{"index_key": 0}, {"index_key": 1}]
+    },
+    {
+      "mode": "data",
+      "type": "aws_ssm_parameter",
+      "name": "ami",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{}]
+    },
+    {
+      "module": "module.app[0]",
+      "mode": "managed",
+      "type": "aws_ssm_parameter",
+      "name": "this",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+      "instances": [{}]
+    },
+    {
+      "module": "module.app[1]",
+      "mode": "managed",
+      "type": "aws_ssm_parameter",
+      "name": "this",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+      "instances": [{}]
+    }
+  ]
+}`
+
+const expectedMigration = `# Root module
+
+terraform {
+  required_providers {
+    fastssm = {
+      source = "rumenvasilev/fastssm"
+    }
+  }
+}
+
+moved {
+  from = aws_ssm_parameter.db_password
+  to   = fastssm_parameter.db_password
+}
+
+# aws_ssm_parameter.replica uses the aws.west provider configuration, set ` + "`provider = fastssm.west`" + ` on fastssm_parameter.replica
+moved {
+  from = aws_ssm_parameter.replica
+  to   = fastssm_parameter.replica
+}
+
+# Source of module.app
+
+terraform {
+  required_providers {
+    fastssm = {
+      source = "rumenvasilev/fastssm"
+    }
+  }
+}
+
+moved {
+  from = aws_ssm_parameter.this
+  to   = fastssm_parameter.this
+}
+`
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(statePath, []byte(testState), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Args     []string
+		Stdin    string
+		Code     int
+		Expected string
+		Error    string
+	}{
+		{
+			Name:     "stdin",
+			Stdin:    testState,
+			Expected: expectedMigration,
+		},
+		{
+			Name:     "state file",
+			Args:     []string{"-state", statePath},
+			Expected: expectedMigration,
+		},
+		{
+			Name:  "missing state file",
+			Args:  []string{"-state", filepath.Join(t.TempDir(), "missing.tfstate")},
+			Code:  1,
+			Error: "no such file or directory",
+		},
+		{
+			Name:  "invalid state",
+			Stdin: "{",
+			Code:  1,
+			Error: "reading the state",
+		},
+		{
+			Name:  "unsupported version",
+			Stdin: `{"version": 3, "modules": []}`,
+			Code:  1,
+			Error: "unsupported state format version 3",
+		},
+		{
+			Name:  "no parameters",
+			Stdin: `{"version": 4, "resources": []}`,
+			Code:  1,
+			Error: "no aws_ssm_parameter resource found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := run(testCase.Args, strings.NewReader(testCase.Stdin), &stdout, &stderr)
+
+			if code != testCase.Code {
+				t.Fatalf("expected exit code %d, got %d: %s", testCase.Code, code, stderr.String())
+			}
+			if testCase.Expected != "" && stdout.String() != testCase.Expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.Expected, stdout.String())
+			}
+			if testCase.Error != "" && !strings.Contains(stderr.String(), testCase.Error) {
+				t.Errorf("expected %q in the error, got %q", testCase.Error, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// unsupportedAttributes are the attributes of aws_ssm_parameter fastssm_parameter doesn't
+// support, commented out by the rewrite with the reason.
+var unsupportedAttributes = map[string]string{
+	"key_id":           "SecureString parameters are encrypted with the default key alias/aws/ssm",
+	"tags":             "tags are ignored for performance reasons",
+	"tier":             "SSM upgrades the tier by itself when the value requires it",
+	"value_wo":         "write-only values are unsupported, set value instead",
+	"value_wo_version": "write-only values are unsupported, set value instead",
+}
+
+// commentPrefix marks the comments added by the rewrite, so it doesn't add them twice.
+const commentPrefix = "# fastssm-migrate: "
+
+// rewriteDirs rewrites the configuration in every directory under roots, reporting each
+// change on report. Each directory is a module: the references to a renamed resource
+// are rewritten in all of its files. Nothing is written with dryRun.
+func rewriteDirs(roots []string, dryRun bool, report *strings.Builder) error {
+	modules := make(map[string][]string)
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && path != root && strings.HasPrefix(d.Name(), ".") {
+				// .terraform holds the downloaded modules, not the configuration
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && filepath.Ext(path) == ".tf" {
+				modules[filepath.Dir(path)] = append(modules[filepath.Dir(path)], path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	dirs := make([]string, 0, len(modules))
+	for dir := range modules {
+		dirs = append(dirs, dir)
+	}
+	slices.Sort(dirs)
+
+	for _, dir := range dirs {
+		files := make(map[string][]byte)
+		for _, path := range modules[dir] {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			files[path] = src
+		}
+
+		rewritten, err := rewriteModule(files, report)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			continue
+		}
+		for path, src := range rewritten {
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, src, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// edit replaces the bytes from start to end of a file with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// rewriteModule rewrites the files of a module, returning the files changed.
+func rewriteModule(files map[string][]byte, report *strings.Builder) (map[string][]byte, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	bodies := make(map[string]*hclsyntax.Body)
+	for _, path := range paths {
+		file, diags := hclsyntax.ParseConfig(files[path], path, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("%s: unexpected body %T", path, file.Body)
+		}
+		bodies[path] = body
+	}
+
+	edits := make(map[string][]edit)
+	renamed := make(map[string]bool)
+
+	for _, path := range paths {
+		src := files[path]
+
+		for _, block := range bodies[path].Blocks {
+			if len(block.Labels) != 2 || block.Labels[0] != "aws_ssm_parameter" {
+				continue
+			}
+
+			switch block.Type {
+			case "resource":
+				renamed[block.Labels[1]] = true
+				edits[path] = append(edits[path], edit{block.LabelRanges[0].Start.Byte, block.LabelRanges[0].End.Byte, `"fastssm_parameter"`})
+				fmt.Fprintf(report, "%s:%d: aws_ssm_parameter.%s renamed to fastssm_parameter.%s\n", path, block.TypeRange.Start.Line, block.Labels[1], block.Labels[1])
+
+				for _, attr := range sortedAttributes(block.Body) {
+					// The configuration of the AWS provider, with the same alias, is for the fastssm provider to mirror
+					if attr.Name == "provider" {
+						if traversal, diags := hcl.AbsTraversalForExpr(attr.Expr); !diags.HasErrors() && traversal.RootName() == "aws" {
+							r := traversal[0].SourceRange()
+							edits[path] = append(edits[path], edit{r.Start.Byte, r.End.Byte, "fastssm"})
+							address := "fastssm" + string(src[r.End.Byte:attr.Expr.Range().End.Byte])
+							fmt.Fprintf(report, "%s:%d: provider of aws_ssm_parameter.%s rewritten to %s, configure it like %s\n", path, attr.SrcRange.Start.Line, block.Labels[1], address, string(src[r.Start.Byte:attr.Expr.Range().End.Byte]))
+						}
+						continue
+					}
+
+					reason, ok := unsupportedAttributes[attr.Name]
+					if !ok {
+						continue
+					}
+					edits[path] = append(edits[path], commentOut(src, attr.SrcRange, attr.Name+" is unsupported by fastssm_parameter: "+reason))
+					fmt.Fprintf(report, "%s:%d: %s of aws_ssm_parameter.%s commented out: %s\n", path, attr.SrcRange.Start.Line, attr.Name, block.Labels[1], reason)
+				}
+			case "data":
+				if start := lineStart(src, block.TypeRange.Start.Byte); !bytes.HasPrefix(bytes.TrimSpace(previousLine(src, start)), []byte(commentPrefix)) {
+					edits[path] = append(edits[path], edit{start, start, indentation(src, start) + commentPrefix + `convert to data "fastssm_parameter", reading the parameter with GetParameter alone` + "\n"})
+				}
+				fmt.Fprintf(report, "%s:%d: data.aws_ssm_parameter.%s can be converted to data.fastssm_parameter\n", path, block.TypeRange.Start.Line, block.Labels[1])
+			}
+		}
+	}
+
+	// The references to the renamed resources, in every file of the module
+	for _, path := range paths {
+		for _, block := range bodies[path].Blocks {
+			// The moved blocks refer to the previous address on purpose
+			if block.Type == "moved" {
+				continue
+			}
+			walkExpressions(block.Body, block, func(expr hclsyntax.Expression) {
+				for _, traversal := range expr.Variables() {
+					if traversal.RootName() != "aws_ssm_parameter" || len(traversal) < 2 {
+						continue
+					}
+					if attr, ok := traversal[1].(hcl.TraverseAttr); !ok || !renamed[attr.Name] {
+						continue
+					}
+					r := traversal[0].SourceRange()
+					edits[path] = append(edits[path], edit{r.Start.Byte, r.End.Byte, "fastssm_parameter"})
+				}
+			})
+		}
+	}
+
+	rewritten := make(map[string][]byte)
+	for path, fileEdits := range edits {
+		rewritten[path] = applyEdits(files[path], fileEdits)
+	}
+
+	return rewritten, nil
+}
+
+// walkExpressions calls fn with the expression of every attribute of body and its nested
+// blocks, except the unsupported attributes of aws_ssm_parameter commented out.
+func walkExpressions(body *hclsyntax.Body, block *hclsyntax.Block, fn func(hclsyntax.Expression)) {
+	for _, attr := range sortedAttributes(body) {
+		if block != nil && block.Type == "resource" && len(block.Labels) == 2 && block.Labels[0] == "aws_ssm_parameter" {
+			if _, ok := unsupportedAttributes[attr.Name]; ok {
+				continue
+			}
+		}
+		fn(attr.Expr)
+	}
+
+	for _, nested := range body.Blocks {
+		walkExpressions(nested.Body, nil, fn)
+	}
+}
+
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	slices.SortFunc(attrs, func(a, b *hclsyntax.Attribute) int {
+		return a.SrcRange.Start.Byte - b.SrcRange.Start.Byte
+	})
+
+	return attrs
+}
+
+// commentOut turns the lines of r into comments, after a comment giving the reason.
+func commentOut(src []byte, r hcl.Range, reason string) edit {
+	start := lineStart(src, r.Start.Byte)
+	end := r.End.Byte
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		end += i
+	} else {
+		end = len(src)
+	}
+
+	indent := indentation(src, start)
+
+	var b strings.Builder
+	b.WriteString(indent + commentPrefix + reason + "\n")
+	for i, line := range strings.Split(string(src[start:end]), "\n") {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "# " + strings.TrimPrefix(line, indent))
+	}
+
+	return edit{start, end, b.String()}
+}
+
+func lineStart(src []byte, offset int) int {
+	return bytes.LastIndexByte(src[:offset], '\n') + 1
+}
+
+func previousLine(src []byte, start int) []byte {
+	if start == 0 {
+		return nil
+	}
+
+	return src[lineStart(src, start-1) : start-1]
+}
+
+func indentation(src []byte, start int) string {
+	line := src[start:]
+
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+func applyEdits(src []byte, edits []edit) []byte {
+	slices.SortFunc(edits, func(a, b edit) int {
+		return b.start - a.start
+	})
+
+	out := slices.Clone(src)
+	for _, e := range edits {
+		out = slices.Concat(out[:e.start], []byte(e.text), out[e.end:])
+	}
+
+	return out
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `resource "aws_ssm_parameter" "db_password" {
+  name   = "/app/db/password"
+  type   = "SecureString"
+  value  = var.db_password
+  tier   = "Advanced"
+  key_id = aws_kms_key.app.arn
+  tags = {
+    team = "platform"
+  }
+}
+
+data "aws_ssm_parameter" "ami" {
+  name = "/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64"
+}
+
+moved {
+  from = aws_ssm_parameter.legacy
+  to   = aws_ssm_parameter.db_password
+}
+`
+
+const testOutputs = `output "db_password_arn" {
+  value = aws_ssm_parameter.db_password.arn
+}
+
+output "db_password_version" {
+  value = "v${aws_ssm_parameter.db_password.version}"
+}
+
+output "unmanaged" {
+  value = aws_ssm_parameter.elsewhere.arn
+}
+`
+
+const expectedConfig = `resource "fastssm_parameter" "db_password" {
+  name   = "/app/db/password"
+  type   = "SecureString"
+  value  = var.db_password
+  # fastssm-migrate: tier is unsupported by fastssm_parameter: SSM upgrades the tier by itself when the value requires it
+  # tier   = "Advanced"
+  # fastssm-migrate: key_id is unsupported by fastssm_parameter: SecureString parameters are encrypted with the default key alias/aws/ssm
+  # key_id = aws_kms_key.app.arn
+  # fastssm-migrate: tags is unsupported by fastssm_parameter: tags are ignored for performance reasons
+  # tags = {
+  #   team = "platform"
+  # }
+}
+
+# fastssm-migrate: convert to data "fastssm_parameter", reading the parameter with GetParameter alone
+data "aws_ssm_parameter" "ami" {
+  name = "/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64"
+}
+
+moved {
+  from = aws_ssm_parameter.legacy
+  to   = aws_ssm_parameter.db_password
+}
+`
+
+const expectedOutputs = `output "db_password_arn" {
+  value = fastssm_parameter.db_password.arn
+}
+
+output "db_password_version" {
+  value = "v${fastssm_parameter.db_password.version}"
+}
+
+output "unmanaged" {
+  value = aws_ssm_parameter.elsewhere.arn
+}
+`
+
+func TestRewrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), testConfig)
+	writeFile(t, filepath.Join(dir, "outputs.tf"), testOutputs)
+	// Downloaded modules aren't rewritten
+	writeFile(t, filepath.Join(dir, ".terraform", "modules", "app", "main.tf"), testConfig)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-rewrite", "-dry-run", dir}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if got := readFile(t, filepath.Join(dir, "main.tf")); got != testConfig {
+		t.Errorf("expected no change with -dry-run, got:\n%s", got)
+	}
+
+	expectedReport := strings.Join([]string{
+		filepath.Join(dir, "main.tf") + ":1: aws_ssm_parameter.db_password renamed to fastssm_parameter.db_password",
+		filepath.Join(dir, "main.tf") + ":5: tier of aws_ssm_parameter.db_password commented out: SSM upgrades the tier by itself when the value requires it",
+		filepath.Join(dir, "main.tf") + ":6: key_id of aws_ssm_parameter.db_password commented out: SecureString parameters are encrypted with the default key alias/aws/ssm",
+		filepath.Join(dir, "main.tf") + ":7: tags of aws_ssm_parameter.db_password commented out: tags are ignored for performance reasons",
+		filepath.Join(dir, "main.tf") + ":12: data.aws_ssm_parameter.ami can be converted to data.fastssm_parameter",
+	}, "\n") + "\n"
+	if stdout.String() != expectedReport {
+		t.Errorf("expected report:\n%s\ngot:\n%s", expectedReport, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-rewrite", dir}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if got := readFile(t, filepath.Join(dir, "main.tf")); got != expectedConfig {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedConfig, got)
+	}
+	if got := readFile(t, filepath.Join(dir, "outputs.tf")); got != expectedOutputs {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedOutputs, got)
+	}
+	if got := readFile(t, filepath.Join(dir, ".terraform", "modules", "app", "main.tf")); got != testConfig {
+		t.Errorf("expected the downloaded module unchanged, got:\n%s", got)
+	}
+
+	// Running again only reports the data source, already flagged
+	stdout.Reset()
+	if code := run([]string{"-rewrite", dir}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if got := readFile(t, filepath.Join(dir, "main.tf")); got != expectedConfig {
+		t.Errorf("expected no change on the second run, got:\n%s", got)
+	}
+	if expected := filepath.Join(dir, "main.tf") + ":16: data.aws_ssm_parameter.ami can be converted to data.fastssm_parameter\n"; stdout.String() != expected {
+		t.Errorf("expected report %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRewriteResourceArguments(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		Config         string
+		ExpectedConfig string
+		ExpectedReport []string
+	}{
+		{
+			Name: "provider alias",
+			Config: `resource "aws_ssm_parameter" "replica" {
+  provider = aws.us_east_1
+  name     = "/app/replica"
+  value    = "replica"
+}
+`,
+			ExpectedConfig: `resource "fastssm_parameter" "replica" {
+  provider = fastssm.us_east_1
+  name     = "/app/replica"
+  value    = "replica"
+}
+`,
+			ExpectedReport: []string{
+				":1: aws_ssm_parameter.replica renamed to fastssm_parameter.replica",
+				":2: provider of aws_ssm_parameter.replica rewritten to fastssm.us_east_1, configure it like aws.us_east_1",
+			},
+		},
+		{
+			Name: "default provider",
+			Config: `resource "aws_ssm_parameter" "config" {
+  provider = aws
+  name     = "/app/config"
+  value    = "config"
+}
+`,
+			ExpectedConfig: `resource "fastssm_parameter" "config" {
+  provider = fastssm
+  name     = "/app/config"
+  value    = "config"
+}
+`,
+			ExpectedReport: []string{
+				":1: aws_ssm_parameter.config renamed to fastssm_parameter.config",
+				":2: provider of aws_ssm_parameter.config rewritten to fastssm, configure it like aws",
+			},
+		},
+		{
+			Name: "write-only value",
+			Config: `resource "aws_ssm_parameter" "secret" {
+  name             = "/app/secret"
+  type             = "SecureString"
+  value_wo         = ephemeral.random_password.secret.result
+  value_wo_version = 1
+}
+`,
+			ExpectedConfig: `resource "fastssm_parameter" "secret" {
+  name             = "/app/secret"
+  type             = "SecureString"
+  # fastssm-migrate: value_wo is unsupported by fastssm_parameter: write-only values are unsupported, set value instead
+  # value_wo         = ephemeral.random_password.secret.result
+  # fastssm-migrate: value_wo_version is unsupported by fastssm_parameter: write-only values are unsupported, set value instead
+  # value_wo_version = 1
+}
+`,
+			ExpectedReport: []string{
+				":1: aws_ssm_parameter.secret renamed to fastssm_parameter.secret",
+				":4: value_wo of aws_ssm_parameter.secret commented out: write-only values are unsupported, set value instead",
+				":5: value_wo_version of aws_ssm_parameter.secret commented out: write-only values are unsupported, set value instead",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			file := filepath.Join(dir, "main.tf")
+			writeFile(t, file, testCase.Config)
+
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{"-rewrite", dir}, nil, &stdout, &stderr); code != 0 {
+				t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+			}
+			if got := readFile(t, file); got != testCase.ExpectedConfig {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.ExpectedConfig, got)
+			}
+
+			var expectedReport strings.Builder
+			for _, line := range testCase.ExpectedReport {
+				expectedReport.WriteString(file + line + "\n")
+			}
+			if stdout.String() != expectedReport.String() {
+				t.Errorf("expected report:\n%s\ngot:\n%s", expectedReport.String(), stdout.String())
+			}
+		})
+	}
+}
+
+func TestRewriteInvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `resource "aws_ssm_parameter" "broken" {`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-rewrite", dir}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "main.tf") {
+		t.Errorf("expected the file in the error, got %q", stderr.String())
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return string(content)
+}
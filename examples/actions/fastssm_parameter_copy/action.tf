@@ -0,0 +1,8 @@
+# terraform apply -invoke=action.fastssm_parameter_copy.database_url
+action "fastssm_parameter_copy" "database_url" {
+  config {
+    source        = "/app/prod/db_url"
+    destination   = "/app/prod/database/url"
+    delete_source = true
+  }
+}
@@ -0,0 +1,8 @@
+# terraform apply -invoke=action.fastssm_parameter_label.promote
+action "fastssm_parameter_label" "promote" {
+  config {
+    name    = "/app/prod/image"
+    version = 42
+    labels  = ["production"]
+  }
+}
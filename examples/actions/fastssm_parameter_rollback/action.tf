@@ -0,0 +1,7 @@
+# terraform apply -invoke=action.fastssm_parameter_rollback.image
+action "fastssm_parameter_rollback" "image" {
+  config {
+    name  = "/app/prod/image"
+    label = "last-known-good"
+  }
+}
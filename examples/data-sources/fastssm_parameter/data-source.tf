@@ -1,4 +1,58 @@
 
 data "fastssm_parameter" "example" {
   name = "some-value"
-}
\ No newline at end of file
+}
+
+# Pin a historical version
+data "fastssm_parameter" "pinned" {
+  name    = "some-value"
+  version = 3
+}
+
+# Follow a release label
+data "fastssm_parameter" "current" {
+  name  = "some-value"
+  label = "prod-current"
+}
+
+# Use the parameter only if it's present
+data "fastssm_parameter" "feature_flag" {
+  name     = "/app/prod/feature-flag"
+  optional = true
+}
+
+# Fall back to a default until the parameter is seeded
+data "fastssm_parameter" "log_level" {
+  name          = "/app/prod/log-level"
+  optional      = true
+  default_value = "info"
+}
+
+# Parameters shared through AWS RAM can only be addressed by ARN
+data "fastssm_parameter" "shared" {
+  name = "arn:aws:ssm:eu-west-1:123456789012:parameter/shared/config"
+}
+
+# Read a parameter of another region without a provider alias
+data "fastssm_parameter" "dr_endpoint" {
+  name   = "/app/prod/endpoint"
+  region = "eu-central-1"
+}
+
+# Grant consumers kms:Decrypt on the key protecting a SecureString
+data "fastssm_parameter" "db_password" {
+  name             = "/app/prod/db-password"
+  include_metadata = true
+}
+
+data "aws_iam_policy_document" "consumer" {
+  statement {
+    actions   = ["ssm:GetParameter"]
+    resources = [data.fastssm_parameter.db_password.arn]
+  }
+
+  statement {
+    actions   = ["kms:Decrypt"]
+    resources = [data.fastssm_parameter.db_password.key_id]
+  }
+}
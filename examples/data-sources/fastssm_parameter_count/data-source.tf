@@ -0,0 +1,17 @@
+data "fastssm_parameter_count" "all" {
+  path = "/"
+}
+
+# Fail the plan before hitting the quota of 10,000 standard parameters
+resource "fastssm_parameter" "example" {
+  name  = "/app/feature-flag"
+  type  = "String"
+  value = "on"
+
+  lifecycle {
+    precondition {
+      condition     = data.fastssm_parameter_count.all.total < 9500
+      error_message = "The region is close to the quota of standard parameters."
+    }
+  }
+}
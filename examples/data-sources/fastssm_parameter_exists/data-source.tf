@@ -0,0 +1,12 @@
+data "fastssm_parameter_exists" "example" {
+  name = "/some/parameter"
+}
+
+# Seed a default value only when the parameter is absent
+resource "fastssm_parameter" "default" {
+  count = data.fastssm_parameter_exists.example.exists ? 0 : 1
+
+  name  = "/some/parameter"
+  type  = "String"
+  value = "default"
+}
@@ -0,0 +1,8 @@
+data "fastssm_parameter_tree" "app" {
+  path = "/app/prod"
+}
+
+# /app/prod/db/host and /app/prod/db/port
+locals {
+  database_url = "postgres://${data.fastssm_parameter_tree.app.tree.db.host}:${data.fastssm_parameter_tree.app.tree.db.port}"
+}
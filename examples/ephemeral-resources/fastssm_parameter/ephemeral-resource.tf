@@ -0,0 +1,14 @@
+ephemeral "fastssm_parameter" "database_password" {
+  name = "/app/prod/database/password"
+}
+
+provider "postgresql" {
+  username = "app"
+  password = ephemeral.fastssm_parameter.database_password.value
+}
+
+# Keep the consumers on the vetted version while a rotation rolls out
+ephemeral "fastssm_parameter" "api_key" {
+  name  = "/app/prod/api-key"
+  label = "vetted"
+}
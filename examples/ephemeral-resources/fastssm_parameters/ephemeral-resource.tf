@@ -0,0 +1,11 @@
+ephemeral "fastssm_parameters" "database" {
+  names = [
+    "/app/prod/database/username",
+    "/app/prod/database/password",
+  ]
+}
+
+provider "postgresql" {
+  username = ephemeral.fastssm_parameters.database.values["/app/prod/database/username"]
+  password = ephemeral.fastssm_parameters.database.values["/app/prod/database/password"]
+}
@@ -0,0 +1,9 @@
+ephemeral "fastssm_parameters_by_path" "database" {
+  path      = "/app/prod/database"
+  recursive = true
+}
+
+provider "postgresql" {
+  username = ephemeral.fastssm_parameters_by_path.database.values["/app/prod/database/username"]
+  password = ephemeral.fastssm_parameters_by_path.database.values["/app/prod/database/password"]
+}
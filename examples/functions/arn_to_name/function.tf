@@ -0,0 +1,3 @@
+data "fastssm_parameter" "from_arn" {
+  name = provider::fastssm::arn_to_name("arn:aws:ssm:eu-west-1:123456789012:parameter/app/prod/db")
+}
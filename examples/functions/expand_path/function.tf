@@ -0,0 +1,5 @@
+resource "fastssm_parameter" "db_password" {
+  name  = provider::fastssm::expand_path(var.org, var.team, var.environment, "db/password")
+  type  = "SecureString"
+  value = random_password.db.result
+}
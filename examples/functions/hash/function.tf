@@ -0,0 +1,8 @@
+data "fastssm_parameter" "db_password" {
+  name = "/app/prod/db/password"
+}
+
+# Changes when the password is rotated, without revealing it
+output "db_password_fingerprint" {
+  value = nonsensitive(provider::fastssm::hash(data.fastssm_parameter.db_password.value, var.fingerprint_salt))
+}
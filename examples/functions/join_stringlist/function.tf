@@ -0,0 +1,5 @@
+resource "fastssm_parameter" "subnets" {
+  name           = "/network/private-subnets"
+  type           = "StringList"
+  insecure_value = provider::fastssm::join_stringlist(aws_subnet.private[*].id)
+}
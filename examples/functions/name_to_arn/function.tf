@@ -0,0 +1,17 @@
+data "aws_caller_identity" "current" {}
+data "aws_partition" "current" {}
+data "aws_region" "current" {}
+
+data "aws_iam_policy_document" "read_config" {
+  statement {
+    actions = ["ssm:GetParameter"]
+    resources = [
+      provider::fastssm::name_to_arn(
+        data.aws_partition.current.partition,
+        data.aws_region.current.name,
+        data.aws_caller_identity.current.account_id,
+        "/app/prod/config",
+      ),
+    ]
+  }
+}
@@ -0,0 +1,5 @@
+resource "fastssm_parameter" "settings" {
+  name  = "/app/prod/settings"
+  type  = "String"
+  value = provider::fastssm::normalize_json(file("${path.module}/settings.json"))
+}
@@ -0,0 +1,13 @@
+locals {
+  config = provider::fastssm::parse_arn(var.config_parameter_arn)
+}
+
+provider "fastssm" {
+  alias  = "config"
+  region = local.config.region
+}
+
+data "fastssm_parameter" "config" {
+  provider = fastssm.config
+  name     = local.config.name
+}
@@ -0,0 +1,7 @@
+data "fastssm_parameter" "subnets" {
+  name = "/network/private-subnets"
+}
+
+output "subnet_ids" {
+  value = provider::fastssm::split_stringlist(data.fastssm_parameter.subnets.insecure_value)
+}
@@ -0,0 +1,17 @@
+data "fastssm_parameter" "db_host" {
+  name = "/app/prod/db/host"
+}
+
+data "fastssm_parameter" "db_port" {
+  name = "/app/prod/db/port"
+}
+
+# DB_HOST=db.example.com
+# DB_PORT=5432
+resource "local_sensitive_file" "env" {
+  filename = "${path.module}/app.env"
+  content = provider::fastssm::to_dotenv({
+    (data.fastssm_parameter.db_host.name) = data.fastssm_parameter.db_host.value
+    (data.fastssm_parameter.db_port.name) = data.fastssm_parameter.db_port.value
+  }, "/app/prod")
+}
@@ -0,0 +1,8 @@
+variable "parameter_name" {
+  type = string
+
+  validation {
+    condition     = can(provider::fastssm::validate_name(var.parameter_name))
+    error_message = "The parameter name doesn't satisfy the AWS SSM naming constraints."
+  }
+}
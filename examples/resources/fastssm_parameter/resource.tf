@@ -14,4 +14,32 @@ resource "fastssm_parameter" "secure" {
   type        = "SecureString"
   value       = "some-secure-value"
   description = "An example description"
-}
\ No newline at end of file
+}
+
+### Unique name for CI test parameters
+
+resource "fastssm_parameter" "ci" {
+  name_prefix    = "/ci/test-"
+  type           = "String"
+  insecure_value = "some-insecure-value"
+}
+
+### Rendered configuration file
+
+resource "fastssm_parameter" "config" {
+  name       = "/app/config"
+  type       = "String"
+  value_file = "${path.module}/rendered/config.json"
+}
+
+### Keys of a shared JSON document
+
+resource "fastssm_parameter" "feature_flags" {
+  name                   = "/app/shared/settings"
+  type                   = "String"
+  value_json_merge_patch = jsonencode({
+    features = {
+      checkout_v2 = true
+    }
+  })
+}
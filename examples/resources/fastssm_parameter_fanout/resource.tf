@@ -0,0 +1,12 @@
+resource "fastssm_parameter_fanout" "example" {
+  name        = "/org/config/log-bucket"
+  type        = "String"
+  value       = "org-central-logs"
+  description = "Distributed to every member account"
+
+  role_arns = [
+    "arn:aws:iam::111111111111:role/ssm-distribution",
+    "arn:aws:iam::222222222222:role/ssm-distribution",
+  ]
+  external_id = "org-config"
+}
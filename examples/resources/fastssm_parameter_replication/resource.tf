@@ -0,0 +1,12 @@
+resource "fastssm_parameter_replication" "example" {
+  name        = "/shared/config/endpoint"
+  type        = "String"
+  value       = "https://api.example.com"
+  description = "Replicated to every region we deploy in"
+
+  regions = [
+    "eu-west-1",
+    "eu-central-1",
+    "us-east-1",
+  ]
+}
@@ -0,0 +1,10 @@
+# Raise the throughput limit of Parameter Store for the account in this region
+resource "fastssm_service_setting" "high_throughput" {
+  setting_id    = "/ssm/parameter-store/high-throughput-enabled"
+  setting_value = "true"
+}
+
+resource "fastssm_service_setting" "default_tier" {
+  setting_id    = "/ssm/parameter-store/default-parameter-tier"
+  setting_value = "Intelligent-Tiering"
+}
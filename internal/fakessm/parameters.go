@@ -0,0 +1,819 @@
+package fakessm
+
+import (
+	"encoding/base64"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YakDriver/regexache"
+)
+
+const (
+	// maxVersions is the number of versions SSM keeps for a parameter
+	maxVersions = 100
+	// maxLabelsPerVersion is the number of labels a version can carry
+	maxLabelsPerVersion = 10
+	// maxHierarchyLevels is the depth limit of parameter names
+	maxHierarchyLevels = 15
+	// maxStandardValueSize is the value limit of the Standard tier, Advanced allows twice that
+	maxStandardValueSize = 4096
+)
+
+var nameRegexp = regexache.MustCompile(`^[a-zA-Z0-9_.\-/]+$`)
+
+// parameter is a parameter with its versions, oldest first.
+type parameter struct {
+	name     string
+	versions []*parameterVersion
+	// lastVersion keeps counting after the oldest versions are dropped
+	lastVersion int64
+	tags        map[string]string
+	// owner is the account sharing the parameter through RAM, empty for the parameters of AccountID
+	owner string
+}
+
+type parameterVersion struct {
+	version          int64
+	value            string
+	typ              string
+	dataType         string
+	keyID            string
+	description      string
+	allowedPattern   string
+	tier             string
+	labels           []string
+	lastModifiedDate time.Time
+}
+
+func (p *parameter) latest() *parameterVersion {
+	return p.versions[len(p.versions)-1]
+}
+
+// regionStore is the view of the operations on the parameters of a region, the lock being held.
+type regionStore struct {
+	parameters map[string]*parameter
+	// shared are the parameters shared with AccountID, by name
+	shared map[string]*parameter
+	// settings are the customized service settings, by ID
+	settings map[string]string
+	region   string
+	now      time.Time
+	// stale answers the reads of the latest version with the version before
+	stale bool
+}
+
+// wireParameter is the Parameter shape of the SSM API.
+type wireParameter struct {
+	ARN              string  `json:",omitempty"`
+	DataType         string  `json:",omitempty"`
+	LastModifiedDate float64 `json:",omitempty"`
+	Name             string
+	Selector         string `json:",omitempty"`
+	Type             string
+	Value            string
+	Version          int64
+}
+
+// wireParameterHistory is the ParameterHistory shape of the SSM API.
+type wireParameterHistory struct {
+	AllowedPattern   string `json:",omitempty"`
+	DataType         string `json:",omitempty"`
+	Description      string `json:",omitempty"`
+	KeyID            string `json:"KeyId,omitempty"`
+	Labels           []string
+	LastModifiedDate float64 `json:",omitempty"`
+	Name             string
+	Tier             string `json:",omitempty"`
+	Type             string
+	Value            string
+	Version          int64
+}
+
+// wireParameterMetadata is the ParameterMetadata shape of the SSM API.
+type wireParameterMetadata struct {
+	ARN              string  `json:",omitempty"`
+	AllowedPattern   string  `json:",omitempty"`
+	DataType         string  `json:",omitempty"`
+	Description      string  `json:",omitempty"`
+	KeyID            string  `json:"KeyId,omitempty"`
+	LastModifiedDate float64 `json:",omitempty"`
+	Name             string
+	Tier             string `json:",omitempty"`
+	Type             string
+	Version          int64
+}
+
+func (s *regionStore) arn(name string) string {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+
+	return "arn:aws:ssm:" + s.region + ":" + AccountID + ":parameter" + name
+}
+
+// arnOf is the ARN of a parameter, of its owner account for a shared one.
+func (s *regionStore) arnOf(p *parameter) string {
+	if p.owner == "" {
+		return s.arn(p.name)
+	}
+
+	return strings.Replace(s.arn(p.name), ":"+AccountID+":", ":"+p.owner+":", 1)
+}
+
+// nameOf is the name SSM answers for a parameter, the full ARN for a shared one.
+func (s *regionStore) nameOf(p *parameter) string {
+	if p.owner == "" {
+		return p.name
+	}
+
+	return s.arnOf(p)
+}
+
+func (s *regionStore) wire(p *parameter, v *parameterVersion, selector string, withDecryption bool) wireParameter {
+	return wireParameter{
+		ARN:              s.arnOf(p),
+		DataType:         v.dataType,
+		LastModifiedDate: epochSeconds(v.lastModifiedDate),
+		Name:             s.nameOf(p),
+		Selector:         selector,
+		Type:             v.typ,
+		Value:            visibleValue(v, withDecryption),
+		Version:          v.version,
+	}
+}
+
+// visibleValue stands in for the ciphertext of a SecureString read without decryption.
+func visibleValue(v *parameterVersion, withDecryption bool) string {
+	if v.typ == "SecureString" && !withDecryption {
+		return base64.StdEncoding.EncodeToString([]byte(v.keyID + ":" + v.value))
+	}
+
+	return v.value
+}
+
+func epochSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
+
+// resolve finds the version a name refers to. The name can be an ARN and carry
+// a version or label selector.
+func (s *regionStore) resolve(name string) (*parameter, *parameterVersion, string, error) {
+	parameters := s.parameters
+	if strings.HasPrefix(name, "arn:") {
+		prefix, rest, ok := strings.Cut(name, ":parameter")
+		if !ok {
+			return nil, nil, "", newAPIError("ValidationException", "invalid parameter ARN %s", name)
+		}
+		if fields := strings.Split(prefix, ":"); len(fields) == 5 && fields[4] == SharingAccountID {
+			parameters = s.shared
+		}
+		name = rest
+		// Names at the root have no leading slash but their ARN does
+		if base, _, _ := strings.Cut(name, ":"); strings.Count(base, "/") == 1 {
+			if _, ok := parameters[strings.TrimPrefix(base, "/")]; ok {
+				name = strings.TrimPrefix(name, "/")
+			}
+		}
+	}
+
+	base, selector, hasSelector := strings.Cut(name, ":")
+
+	p, ok := parameters[base]
+	if !ok {
+		return nil, nil, "", newAPIError("ParameterNotFound", "parameter %s not found", base)
+	}
+
+	if !hasSelector {
+		return p, p.latest(), "", nil
+	}
+
+	if version, err := strconv.ParseInt(selector, 10, 64); err == nil {
+		for _, v := range p.versions {
+			if v.version == version {
+				return p, v, ":" + selector, nil
+			}
+		}
+		return nil, nil, "", newAPIError("ParameterVersionNotFound", "version %d of parameter %s not found", version, base)
+	}
+
+	for _, v := range p.versions {
+		if slices.Contains(v.labels, selector) {
+			return p, v, ":" + selector, nil
+		}
+	}
+
+	return nil, nil, "", newAPIError("ParameterNotFound", "label %s of parameter %s not found", selector, base)
+}
+
+func validateName(name string) error {
+	if name == "" || !nameRegexp.MatchString(name) {
+		return newAPIError("ValidationException", "parameter name %q: can only include a-zA-Z0-9_.-/", name)
+	}
+	if strings.Contains(name, "/") && !strings.HasPrefix(name, "/") {
+		return newAPIError("ValidationException", "parameter name %q: must be fully qualified", name)
+	}
+	if strings.Count(name, "/") > maxHierarchyLevels {
+		return newAPIError("HierarchyLevelLimitExceededException", "parameter name %q: exceeds %d hierarchy levels", name, maxHierarchyLevels)
+	}
+
+	return nil
+}
+
+type putParameterInput struct {
+	Name           string
+	Value          *string
+	Type           string
+	DataType       string
+	Description    *string
+	AllowedPattern *string
+	KeyID          string `json:"KeyId"`
+	Overwrite      bool
+	Tier           string
+	Tags           []tag
+}
+
+type putParameterOutput struct {
+	Tier    string
+	Version int64
+}
+
+func (s *regionStore) putParameter(input *putParameterInput) (*putParameterOutput, error) {
+	if err := validateName(input.Name); err != nil {
+		return nil, err
+	}
+	if reserved := strings.ToLower(strings.TrimPrefix(input.Name, "/")); strings.HasPrefix(reserved, "aws") || strings.HasPrefix(reserved, "ssm") {
+		return nil, newAPIError("ValidationException", "parameter name %q: can't be prefixed with \"aws\" or \"ssm\" (case-insensitive)", input.Name)
+	}
+	if input.Value == nil {
+		return nil, newAPIError("ValidationException", "value is required")
+	}
+
+	p, exists := s.parameters[input.Name]
+	if exists && !input.Overwrite {
+		return nil, newAPIError("ParameterAlreadyExists", "the parameter %s already exists", input.Name)
+	}
+
+	v := &parameterVersion{
+		value:            *input.Value,
+		typ:              input.Type,
+		dataType:         input.DataType,
+		keyID:            input.KeyID,
+		tier:             input.Tier,
+		lastModifiedDate: s.now,
+	}
+	if input.Description != nil {
+		v.description = *input.Description
+	}
+	if input.AllowedPattern != nil {
+		v.allowedPattern = *input.AllowedPattern
+	}
+
+	// An overwrite keeps the attributes it doesn't set, except the key: like in AWS,
+	// a SecureString written without KeyId is encrypted with the default key
+	if exists {
+		previous := p.latest()
+		if v.typ == "" {
+			v.typ = previous.typ
+		}
+		if v.dataType == "" {
+			v.dataType = previous.dataType
+		}
+		if input.Description == nil {
+			v.description = previous.description
+		}
+		if input.AllowedPattern == nil {
+			v.allowedPattern = previous.allowedPattern
+		}
+		if v.tier == "" {
+			v.tier = previous.tier
+		}
+	}
+
+	switch v.typ {
+	case "String", "StringList":
+	case "SecureString":
+		if v.keyID == "" {
+			v.keyID = "alias/aws/ssm"
+		}
+	case "":
+		return nil, newAPIError("ValidationException", "type is required when creating a parameter")
+	default:
+		return nil, newAPIError("ValidationException", "unsupported parameter type %s", v.typ)
+	}
+	if v.typ != "SecureString" {
+		v.keyID = ""
+	}
+	if v.dataType == "" {
+		v.dataType = "text"
+	}
+	if v.tier == "" || v.tier == "Intelligent-Tiering" {
+		v.tier = "Standard"
+		if len(v.value) > maxStandardValueSize {
+			v.tier = "Advanced"
+		}
+	}
+	if v.tier == "Standard" && len(v.value) > maxStandardValueSize {
+		return nil, newAPIError("ValidationException", "standard tier parameters support a value of up to %d characters", maxStandardValueSize)
+	}
+
+	if v.allowedPattern != "" {
+		re, err := regexp.Compile(v.allowedPattern)
+		if err != nil {
+			return nil, newAPIError("ValidationException", "invalid allowed pattern %s", v.allowedPattern)
+		}
+		if !re.MatchString(v.value) {
+			return nil, newAPIError("ParameterPatternMismatchException", "parameter value, cannot be validated against allowedPattern: %s", v.allowedPattern)
+		}
+	}
+
+	if !exists {
+		p = &parameter{name: input.Name, tags: make(map[string]string)}
+		s.parameters[input.Name] = p
+	}
+	for _, tag := range input.Tags {
+		p.tags[tag.Key] = tag.Value
+	}
+
+	if len(p.versions) == maxVersions {
+		if len(p.versions[0].labels) > 0 {
+			return nil, newAPIError("ParameterMaxVersionLimitExceeded", "you attempted to create a new version of %s by calling the PutParameter API with the overwrite flag. Version %d, the oldest version, can't be deleted because it has a label associated with it", p.name, p.versions[0].version)
+		}
+		p.versions = p.versions[1:]
+	}
+
+	p.lastVersion++
+	v.version = p.lastVersion
+	p.versions = append(p.versions, v)
+
+	return &putParameterOutput{Tier: v.tier, Version: v.version}, nil
+}
+
+type getParameterInput struct {
+	Name           string
+	WithDecryption bool
+}
+
+type getParameterOutput struct {
+	Parameter wireParameter
+}
+
+func (s *regionStore) getParameter(input *getParameterInput) (*getParameterOutput, error) {
+	p, v, selector, err := s.resolve(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if s.stale && selector == "" {
+		// A parameter just created isn't found yet
+		if len(p.versions) == 1 {
+			return nil, newAPIError("ParameterNotFound", "parameter %s not found", input.Name)
+		}
+		v = p.versions[len(p.versions)-2]
+	}
+
+	return &getParameterOutput{Parameter: s.wire(p, v, selector, input.WithDecryption)}, nil
+}
+
+type getParametersInput struct {
+	Names          []string
+	WithDecryption bool
+}
+
+type getParametersOutput struct {
+	InvalidParameters []string
+	Parameters        []wireParameter
+}
+
+func (s *regionStore) getParameters(input *getParametersInput) (*getParametersOutput, error) {
+	if len(input.Names) == 0 || len(input.Names) > 10 {
+		return nil, newAPIError("ValidationException", "1 to 10 names are allowed, got %d", len(input.Names))
+	}
+
+	output := &getParametersOutput{
+		InvalidParameters: []string{},
+		Parameters:        []wireParameter{},
+	}
+	for _, name := range input.Names {
+		p, v, selector, err := s.resolve(name)
+		if err != nil {
+			output.InvalidParameters = append(output.InvalidParameters, name)
+			continue
+		}
+		output.Parameters = append(output.Parameters, s.wire(p, v, selector, input.WithDecryption))
+	}
+
+	return output, nil
+}
+
+type getParametersByPathInput struct {
+	Path           string
+	Recursive      bool
+	WithDecryption bool
+	MaxResults     int
+	NextToken      string
+}
+
+type getParametersByPathOutput struct {
+	NextToken  string `json:",omitempty"`
+	Parameters []wireParameter
+}
+
+func (s *regionStore) getParametersByPath(input *getParametersByPathInput) (*getParametersByPathOutput, error) {
+	if !strings.HasPrefix(input.Path, "/") {
+		return nil, newAPIError("ValidationException", "the path must start with /")
+	}
+
+	prefix := strings.TrimSuffix(input.Path, "/") + "/"
+
+	var names []string
+	for name := range s.parameters {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || (!input.Recursive && strings.Contains(rest, "/")) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	page, nextToken, err := paginate(sortedParameters(s.parameters, names), input.NextToken, input.MaxResults, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &getParametersByPathOutput{
+		NextToken:  nextToken,
+		Parameters: []wireParameter{},
+	}
+	for _, p := range page {
+		output.Parameters = append(output.Parameters, s.wire(p, p.latest(), "", input.WithDecryption))
+	}
+
+	return output, nil
+}
+
+type getParameterHistoryInput struct {
+	Name           string
+	WithDecryption bool
+	MaxResults     int
+	NextToken      string
+}
+
+type getParameterHistoryOutput struct {
+	NextToken  string `json:",omitempty"`
+	Parameters []wireParameterHistory
+}
+
+func (s *regionStore) getParameterHistory(input *getParameterHistoryInput) (*getParameterHistoryOutput, error) {
+	p, ok := s.parameters[input.Name]
+	if !ok {
+		return nil, newAPIError("ParameterNotFound", "parameter %s not found", input.Name)
+	}
+
+	page, nextToken, err := paginate(p.versions, input.NextToken, input.MaxResults, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &getParameterHistoryOutput{
+		NextToken:  nextToken,
+		Parameters: []wireParameterHistory{},
+	}
+	for _, v := range page {
+		output.Parameters = append(output.Parameters, wireParameterHistory{
+			AllowedPattern:   v.allowedPattern,
+			DataType:         v.dataType,
+			Description:      v.description,
+			KeyID:            v.keyID,
+			Labels:           slices.Clone(v.labels),
+			LastModifiedDate: epochSeconds(v.lastModifiedDate),
+			Name:             s.nameOf(p),
+			Tier:             v.tier,
+			Type:             v.typ,
+			Value:            visibleValue(v, input.WithDecryption),
+			Version:          v.version,
+		})
+	}
+
+	return output, nil
+}
+
+type deleteParameterInput struct {
+	Name string
+}
+
+type deleteParameterOutput struct{}
+
+func (s *regionStore) deleteParameter(input *deleteParameterInput) (*deleteParameterOutput, error) {
+	if _, ok := s.parameters[input.Name]; !ok {
+		return nil, newAPIError("ParameterNotFound", "parameter %s not found", input.Name)
+	}
+	delete(s.parameters, input.Name)
+
+	return &deleteParameterOutput{}, nil
+}
+
+type deleteParametersInput struct {
+	Names []string
+}
+
+type deleteParametersOutput struct {
+	DeletedParameters []string
+	InvalidParameters []string
+}
+
+func (s *regionStore) deleteParameters(input *deleteParametersInput) (*deleteParametersOutput, error) {
+	if len(input.Names) == 0 || len(input.Names) > 10 {
+		return nil, newAPIError("ValidationException", "1 to 10 names are allowed, got %d", len(input.Names))
+	}
+
+	output := &deleteParametersOutput{
+		DeletedParameters: []string{},
+		InvalidParameters: []string{},
+	}
+	for _, name := range input.Names {
+		if _, ok := s.parameters[name]; !ok {
+			output.InvalidParameters = append(output.InvalidParameters, name)
+			continue
+		}
+		delete(s.parameters, name)
+		output.DeletedParameters = append(output.DeletedParameters, name)
+	}
+
+	return output, nil
+}
+
+type parameterFilter struct {
+	Key    string
+	Option string
+	Values []string
+}
+
+type describeParametersInput struct {
+	// Filters is the legacy form of ParameterFilters
+	Filters          []parameterFilter
+	ParameterFilters []parameterFilter
+	MaxResults       int
+	NextToken        string
+	// Shared lists the parameters shared with the account instead of its own
+	Shared bool
+}
+
+type describeParametersOutput struct {
+	NextToken  string `json:",omitempty"`
+	Parameters []wireParameterMetadata
+}
+
+func (s *regionStore) describeParameters(input *describeParametersInput) (*describeParametersOutput, error) {
+	filters := append(slices.Clone(input.Filters), input.ParameterFilters...)
+
+	parameters := s.parameters
+	if input.Shared {
+		parameters = s.shared
+	}
+
+	var names []string
+	for name, p := range parameters {
+		ok, err := matchFilters(s.nameOf(p), p, filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			names = append(names, name)
+		}
+	}
+
+	page, nextToken, err := paginate(sortedParameters(parameters, names), input.NextToken, input.MaxResults, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &describeParametersOutput{
+		NextToken:  nextToken,
+		Parameters: []wireParameterMetadata{},
+	}
+	for _, p := range page {
+		v := p.latest()
+		output.Parameters = append(output.Parameters, wireParameterMetadata{
+			ARN:              s.arnOf(p),
+			AllowedPattern:   v.allowedPattern,
+			DataType:         v.dataType,
+			Description:      v.description,
+			KeyID:            v.keyID,
+			LastModifiedDate: epochSeconds(v.lastModifiedDate),
+			Name:             p.name,
+			Tier:             v.tier,
+			Type:             v.typ,
+			Version:          v.version,
+		})
+	}
+
+	return output, nil
+}
+
+// matchFilters supports the Name, Type and Path filters, Name matching the name SSM answers.
+func matchFilters(name string, p *parameter, filters []parameterFilter) (bool, error) {
+	for _, filter := range filters {
+		var match func(value string) bool
+
+		switch filter.Key {
+		case "Name":
+			match = func(value string) bool { return name == value }
+			if filter.Option == "BeginsWith" {
+				match = func(value string) bool { return strings.HasPrefix(name, value) }
+			}
+		case "Type":
+			match = func(value string) bool { return p.latest().typ == value }
+		case "Path":
+			match = func(value string) bool {
+				rest, ok := strings.CutPrefix(p.name, strings.TrimSuffix(value, "/")+"/")
+				return ok && (filter.Option == "Recursive" || !strings.Contains(rest, "/"))
+			}
+		default:
+			return false, newAPIError("InvalidFilterKey", "filter key %s isn't supported by the fake", filter.Key)
+		}
+
+		if !slices.ContainsFunc(filter.Values, match) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+type labelParameterVersionInput struct {
+	Name             string
+	Labels           []string
+	ParameterVersion *int64
+}
+
+type labelParameterVersionOutput struct {
+	InvalidLabels    []string
+	ParameterVersion int64
+}
+
+var numericLabelRegexp = regexache.MustCompile(`^\d+$`)
+
+func (s *regionStore) labelParameterVersion(input *labelParameterVersionInput) (*labelParameterVersionOutput, error) {
+	p, ok := s.parameters[input.Name]
+	if !ok {
+		return nil, newAPIError("ParameterNotFound", "parameter %s not found", input.Name)
+	}
+
+	target := p.latest()
+	if input.ParameterVersion != nil {
+		target = nil
+		for _, v := range p.versions {
+			if v.version == *input.ParameterVersion {
+				target = v
+			}
+		}
+		if target == nil {
+			return nil, newAPIError("ParameterVersionNotFound", "version %d of parameter %s not found", *input.ParameterVersion, input.Name)
+		}
+	}
+
+	output := &labelParameterVersionOutput{
+		InvalidLabels:    []string{},
+		ParameterVersion: target.version,
+	}
+
+	var labels []string
+	for _, label := range input.Labels {
+		lower := strings.ToLower(label)
+		if strings.HasPrefix(lower, "aws") || strings.HasPrefix(lower, "ssm") || numericLabelRegexp.MatchString(label) || strings.Contains(label, ":") {
+			output.InvalidLabels = append(output.InvalidLabels, label)
+			continue
+		}
+		if !slices.Contains(target.labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	if len(target.labels)+len(labels) > maxLabelsPerVersion {
+		return nil, newAPIError("ParameterVersionLabelLimitExceeded", "a parameter version can have maximum %d labels", maxLabelsPerVersion)
+	}
+
+	// A label moves from the version carrying it
+	for _, v := range p.versions {
+		v.labels = slices.DeleteFunc(v.labels, func(label string) bool { return slices.Contains(labels, label) })
+	}
+	target.labels = append(target.labels, labels...)
+
+	return output, nil
+}
+
+type tag struct {
+	Key   string
+	Value string
+}
+
+type addTagsToResourceInput struct {
+	ResourceID   string `json:"ResourceId"`
+	ResourceType string
+	Tags         []tag
+}
+
+type removeTagsFromResourceInput struct {
+	ResourceID   string `json:"ResourceId"`
+	ResourceType string
+	TagKeys      []string
+}
+
+type listTagsForResourceInput struct {
+	ResourceID   string `json:"ResourceId"`
+	ResourceType string
+}
+
+type tagsOutput struct {
+	TagList []tag `json:",omitempty"`
+}
+
+// taggedParameter finds the parameter of a tagging call, the only resource type of the fake.
+func (s *regionStore) taggedParameter(resourceType, resourceID string) (*parameter, error) {
+	if resourceType != "Parameter" {
+		return nil, newAPIError("InvalidResourceType", "resource type %s isn't supported by the fake", resourceType)
+	}
+
+	p, ok := s.parameters[resourceID]
+	if !ok {
+		return nil, newAPIError("InvalidResourceId", "parameter %s not found", resourceID)
+	}
+
+	return p, nil
+}
+
+func (s *regionStore) addTagsToResource(input *addTagsToResourceInput) (*tagsOutput, error) {
+	p, err := s.taggedParameter(input.ResourceType, input.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range input.Tags {
+		p.tags[tag.Key] = tag.Value
+	}
+
+	return &tagsOutput{}, nil
+}
+
+func (s *regionStore) removeTagsFromResource(input *removeTagsFromResourceInput) (*tagsOutput, error) {
+	p, err := s.taggedParameter(input.ResourceType, input.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range input.TagKeys {
+		delete(p.tags, key)
+	}
+
+	return &tagsOutput{}, nil
+}
+
+func (s *regionStore) listTagsForResource(input *listTagsForResourceInput) (*tagsOutput, error) {
+	p, err := s.taggedParameter(input.ResourceType, input.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &tagsOutput{TagList: []tag{}}
+	for _, key := range slices.Sorted(maps.Keys(p.tags)) {
+		output.TagList = append(output.TagList, tag{Key: key, Value: p.tags[key]})
+	}
+
+	return output, nil
+}
+
+// sortedParameters returns the parameters of the names, in name order.
+func sortedParameters(parameters map[string]*parameter, names []string) []*parameter {
+	slices.Sort(names)
+
+	sorted := make([]*parameter, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, parameters[name])
+	}
+
+	return sorted
+}
+
+// paginate returns a page of items, the token being the offset of the next page.
+func paginate[T any](items []T, token string, maxResults, defaultMaxResults int) ([]T, string, error) {
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+
+	var offset int
+	if token != "" {
+		var err error
+		if offset, err = strconv.Atoi(token); err != nil || offset < 0 || offset > len(items) {
+			return nil, "", newAPIError("InvalidNextToken", "the next token %q is invalid", token)
+		}
+	}
+
+	end := min(offset+maxResults, len(items))
+	if end == len(items) {
+		return items[offset:end], "", nil
+	}
+
+	return items[offset:end], strconv.Itoa(end), nil
+}
@@ -0,0 +1,434 @@
+// Package fakessm is an in-memory SSM Parameter Store served over HTTP, for
+// running the acceptance tests offline. It speaks the JSON protocol of SSM and
+// answers STS GetCallerIdentity and the AssumeRole calls, so the provider can be
+// pointed at it with AWS_ENDPOINT_URL. Parameters are kept per region, the one
+// of the request signature.
+package fakessm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/YakDriver/regexache"
+)
+
+const (
+	// AccountID is the account of the caller identity and of the parameter ARNs
+	AccountID = "123456789012"
+	// CallerARN is the identity answered by GetCallerIdentity
+	CallerARN = "arn:aws:iam::" + AccountID + ":user/fastssm"
+	// SharingAccountID is the account of the parameters shared with AccountID by Share
+	SharingAccountID = "210987654321"
+
+	defaultRegion = "us-east-1"
+)
+
+// credentialScopeRegexp extracts the access key ID and the region from the SigV4 Authorization header.
+var credentialScopeRegexp = regexache.MustCompile(`Credential=([^/]+)/\d{8}/([^/]+)/`)
+
+// Server is the fake backend, its URL goes to AWS_ENDPOINT_URL.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// regions holds the parameters of each region, by name
+	regions map[string]map[string]*parameter
+	// shared holds the parameters shared with AccountID in each region, by name
+	shared map[string]map[string]*parameter
+	// settings holds the customized service settings of each region, by ID
+	settings map[string]map[string]string
+
+	requests atomic.Int64
+	// throttleEvery answers every nth SSM request with a ThrottlingException, none when 0
+	throttleEvery atomic.Int64
+	ssmRequests   atomic.Int64
+	throttled     atomic.Int64
+	// calls counts the requests of each operation, guarded by mu
+	calls map[string]int64
+	// sessions are the ARNs of the assumed roles, by access key ID, guarded by mu
+	sessions     map[string]string
+	assumedRoles []AssumedRole
+	// tokenCodes are the MFA codes used already, by device, guarded by mu
+	tokenCodes map[string]bool
+	// expired are the access key IDs of the sessions expired by ExpireSessions, guarded by mu
+	expired map[string]bool
+	// denied are the SSM operations answered with AccessDeniedException, guarded by mu
+	denied map[string]bool
+	// staleReads is the number of GetParameter calls still answered with the previous version, guarded by mu
+	staleReads int64
+
+	// Now is the clock of the modification dates, replaceable for deterministic results
+	Now func() time.Time
+}
+
+// NewServer starts a fake backend without any parameter. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		regions:    make(map[string]map[string]*parameter),
+		shared:     make(map[string]map[string]*parameter),
+		settings:   make(map[string]map[string]string),
+		calls:      make(map[string]int64),
+		sessions:   make(map[string]string),
+		tokenCodes: make(map[string]bool),
+		expired:    make(map[string]bool),
+		denied:     make(map[string]bool),
+		Now:        time.Now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Requests returns the number of API calls answered, errors included.
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+// Calls returns the number of requests answered for each operation, e.g. GetParameters.
+func (s *Server) Calls() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.calls)
+}
+
+// SetThrottling answers every nth SSM request with a ThrottlingException, as SSM
+// does past its throughput limit. 0 turns it off.
+func (s *Server) SetThrottling(every int64) {
+	s.throttleEvery.Store(every)
+}
+
+// Throttled returns the number of requests answered with a ThrottlingException.
+func (s *Server) Throttled() int64 {
+	return s.throttled.Load()
+}
+
+// AssumedRole is a role assumed through the fake, with the identity that assumed
+// it, or the token for AssumeRoleWithWebIdentity.
+type AssumedRole struct {
+	RoleARN          string
+	CallerARN        string
+	WebIdentityToken string
+	// SerialNumber and TokenCode are the MFA device and code of an AssumeRole call
+	SerialNumber string
+	TokenCode    string
+}
+
+// AssumedRoles returns the roles assumed so far, in order.
+func (s *Server) AssumedRoles() []AssumedRole {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.assumedRoles)
+}
+
+// ExpireSessions expires the sessions of the roles assumed so far, their requests
+// are answered with ExpiredTokenException, as AWS does once a session expired.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.sessions {
+		s.expired[key] = true
+	}
+}
+
+// Share adds an Advanced String parameter of SharingAccountID shared with AccountID
+// through RAM. It's read with its full ARN, and listed by DescribeParameters with Shared.
+func (s *Server) Share(region, name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shared[region] == nil {
+		s.shared[region] = make(map[string]*parameter)
+	}
+	s.shared[region][name] = &parameter{
+		name:        name,
+		lastVersion: 1,
+		owner:       SharingAccountID,
+		versions: []*parameterVersion{
+			{version: 1, value: value, typ: "String", dataType: "text", tier: "Advanced", lastModifiedDate: s.Now()},
+		},
+	}
+}
+
+// SetStaleReads answers the next n GetParameter calls of the latest version of a
+// parameter with the version before, or ParameterNotFound for a parameter with a
+// single version, as SSM may right after a write, being eventually consistent.
+func (s *Server) SetStaleReads(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.staleReads = n
+}
+
+// Deny answers the SSM operations, e.g. PutParameter, with AccessDeniedException,
+// as AWS does when no IAM policy allows them.
+func (s *Server) Deny(operations ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, operation := range operations {
+		s.denied[operation] = true
+	}
+}
+
+func (s *Server) isDenied(operation string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.denied[operation]
+}
+
+func (s *Server) isExpired(accessKeyID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expired[accessKeyID]
+}
+
+func (s *Server) countCall(operation string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[operation]++
+}
+
+// apiError is an error answered to the client, Code being the exception name.
+type apiError struct {
+	Code    string
+	Message string
+	Status  int
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newAPIError(code, format string, a ...any) *apiError {
+	return &apiError{Code: code, Message: fmt.Sprintf(format, a...), Status: http.StatusBadRequest}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	w.Header().Set("X-Amzn-Requestid", strconv.FormatInt(s.requests.Load(), 10))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	accessKeyID, region := "", defaultRegion
+	if match := credentialScopeRegexp.FindStringSubmatch(r.Header.Get("Authorization")); match != nil {
+		accessKeyID, region = match[1], match[2]
+	}
+
+	target := r.Header.Get("X-Amz-Target")
+	if target == "" {
+		s.serveSTS(w, body, accessKeyID)
+		return
+	}
+	operation := strings.TrimPrefix(target, "AmazonSSM.")
+	s.countCall(operation)
+
+	if every := s.throttleEvery.Load(); every > 0 && s.ssmRequests.Add(1)%every == 0 {
+		s.throttled.Add(1)
+		writeJSON(w, http.StatusBadRequest, map[string]string{"__type": "ThrottlingException", "message": "Rate exceeded"})
+		return
+	}
+
+	if s.isExpired(accessKeyID) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"__type": "ExpiredTokenException", "message": "The security token included in the request is expired"})
+		return
+	}
+
+	if s.isDenied(operation) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"__type":  "AccessDeniedException",
+			"message": fmt.Sprintf("User: %s is not authorized to perform: ssm:%s because no identity-based policy allows the ssm:%s action", CallerARN, operation, operation),
+		})
+		return
+	}
+
+	output, err := s.dispatch(region, operation, body)
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = &apiError{Code: "InternalServerError", Message: err.Error(), Status: http.StatusInternalServerError}
+		}
+		writeJSON(w, apiErr.Status, map[string]string{"__type": apiErr.Code, "message": apiErr.Message})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (s *Server) dispatch(region, operation string, body []byte) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, ok := s.regions[region]
+	if !ok {
+		store = make(map[string]*parameter)
+		s.regions[region] = store
+	}
+	settings, ok := s.settings[region]
+	if !ok {
+		settings = make(map[string]string)
+		s.settings[region] = settings
+	}
+	st := &regionStore{parameters: store, shared: s.shared[region], settings: settings, region: region, now: s.Now()}
+
+	switch operation {
+	case "PutParameter":
+		return decodeAndCall(body, st.putParameter)
+	case "GetParameter":
+		if s.staleReads > 0 {
+			s.staleReads--
+			st.stale = true
+		}
+		return decodeAndCall(body, st.getParameter)
+	case "GetParameters":
+		return decodeAndCall(body, st.getParameters)
+	case "GetParametersByPath":
+		return decodeAndCall(body, st.getParametersByPath)
+	case "GetParameterHistory":
+		return decodeAndCall(body, st.getParameterHistory)
+	case "DeleteParameter":
+		return decodeAndCall(body, st.deleteParameter)
+	case "DeleteParameters":
+		return decodeAndCall(body, st.deleteParameters)
+	case "DescribeParameters":
+		return decodeAndCall(body, st.describeParameters)
+	case "LabelParameterVersion":
+		return decodeAndCall(body, st.labelParameterVersion)
+	case "AddTagsToResource":
+		return decodeAndCall(body, st.addTagsToResource)
+	case "RemoveTagsFromResource":
+		return decodeAndCall(body, st.removeTagsFromResource)
+	case "ListTagsForResource":
+		return decodeAndCall(body, st.listTagsForResource)
+	case "GetServiceSetting":
+		return decodeAndCall(body, st.getServiceSetting)
+	case "UpdateServiceSetting":
+		return decodeAndCall(body, st.updateServiceSetting)
+	case "ResetServiceSetting":
+		return decodeAndCall(body, st.resetServiceSetting)
+	}
+
+	return nil, newAPIError("UnknownOperationException", "operation %s isn't supported by the fake", operation)
+}
+
+func decodeAndCall[I, O any](body []byte, fn func(*I) (*O, error)) (any, error) {
+	input := new(I)
+	if err := json.Unmarshal(body, input); err != nil {
+		return nil, newAPIError("SerializationException", "%s", err)
+	}
+
+	return fn(input)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// serveSTS answers GetCallerIdentity, AssumeRole and AssumeRoleWithWebIdentity, the STS calls of the
+// provider. The identity is the assumed role of the session of accessKeyID,
+// CallerARN for any other key.
+func (s *Server) serveSTS(w http.ResponseWriter, body []byte, accessKeyID string) {
+	w.Header().Set("Content-Type", "text/xml")
+
+	form, _ := url.ParseQuery(string(body))
+	action := form.Get("Action")
+	s.countCall(action)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, ok := s.sessions[accessKeyID]
+	if !ok {
+		caller = CallerARN
+	}
+
+	if s.expired[accessKeyID] {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>ExpiredToken</Code><Message>The security token included in the request is expired</Message></Error></ErrorResponse>`)
+		return
+	}
+
+	switch action {
+	case "GetCallerIdentity":
+		fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>%s</Arn>
+    <UserId>AIDAFASTSSMFAKE</UserId>
+    <Account>%s</Account>
+  </GetCallerIdentityResult>
+  <ResponseMetadata>
+    <RequestId>%d</RequestId>
+  </ResponseMetadata>
+</GetCallerIdentityResponse>`, caller, AccountID, s.requests.Load())
+	case "AssumeRole", "AssumeRoleWithWebIdentity":
+		roleARN, sessionName := form.Get("RoleArn"), form.Get("RoleSessionName")
+		duration, err := strconv.Atoi(form.Get("DurationSeconds"))
+		if err != nil {
+			duration = 3600
+		}
+		roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+
+		// AWS accepts a code of an MFA device once
+		if serialNumber := form.Get("SerialNumber"); serialNumber != "" {
+			if s.tokenCodes[serialNumber+"/"+form.Get("TokenCode")] {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>MultiFactorAuthentication failed with invalid MFA one time pass code.</Message></Error></ErrorResponse>`)
+				return
+			}
+			s.tokenCodes[serialNumber+"/"+form.Get("TokenCode")] = true
+		}
+
+		arn := fmt.Sprintf("arn:aws:sts::%s:assumed-role/%s/%s", AccountID, roleName, sessionName)
+		key := fmt.Sprintf("ASIAFAKE%012d", len(s.assumedRoles)+1)
+		s.sessions[key] = arn
+		assumed := AssumedRole{RoleARN: roleARN, CallerARN: caller, SerialNumber: form.Get("SerialNumber"), TokenCode: form.Get("TokenCode")}
+		if action == "AssumeRoleWithWebIdentity" {
+			// Unsigned, authenticated by the token
+			assumed = AssumedRole{RoleARN: roleARN, WebIdentityToken: form.Get("WebIdentityToken")}
+		}
+		s.assumedRoles = append(s.assumedRoles, assumed)
+
+		fmt.Fprintf(w, `<%[1]sResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <%[1]sResult>
+    <Credentials>
+      <AccessKeyId>%[2]s</AccessKeyId>
+      <SecretAccessKey>fake</SecretAccessKey>
+      <SessionToken>fake</SessionToken>
+      <Expiration>%[3]s</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>%[4]s</Arn>
+      <AssumedRoleId>AROAFASTSSMFAKE:%[5]s</AssumedRoleId>
+    </AssumedRoleUser>
+  </%[1]sResult>
+  <ResponseMetadata>
+    <RequestId>%[6]d</RequestId>
+  </ResponseMetadata>
+</%[1]sResponse>`, action, key, s.Now().Add(time.Duration(duration)*time.Second).UTC().Format(time.RFC3339), arn, sessionName, s.requests.Load())
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>InvalidAction</Code><Message>only GetCallerIdentity, AssumeRole and AssumeRoleWithWebIdentity are supported by the fake</Message></Error></ErrorResponse>`)
+	}
+}
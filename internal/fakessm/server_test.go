@@ -0,0 +1,465 @@
+package fakessm_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+func newTestClient(t *testing.T, server *fakessm.Server, region string) *ssm.Client {
+	t.Helper()
+
+	return ssm.New(ssm.Options{
+		Region:       region,
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.NewCredentialsCache(staticCredentials{}),
+	})
+}
+
+type staticCredentials struct{}
+
+func (staticCredentials) Retrieve(context.Context) (aws.Credentials, error) {
+	return aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}, nil
+}
+
+func TestPutAndGetParameter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	put, err := client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:  aws.String("/app/secret"),
+		Value: aws.String("one"),
+		Type:  ssm_types.ParameterTypeSecureString,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if put.Version != 1 || put.Tier != ssm_types.ParameterTierStandard {
+		t.Errorf("unexpected put output: version %d, tier %s", put.Version, put.Tier)
+	}
+
+	_, err = client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:  aws.String("/app/secret"),
+		Value: aws.String("two"),
+	})
+	var alreadyExists *ssm_types.ParameterAlreadyExists
+	if !errors.As(err, &alreadyExists) {
+		t.Fatalf("expected ParameterAlreadyExists, got %v", err)
+	}
+
+	put, err = client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String("/app/secret"),
+		Value:     aws.String("two"),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if put.Version != 2 {
+		t.Errorf("expected version 2, got %d", put.Version)
+	}
+
+	testCases := []struct {
+		Name           string
+		WithDecryption bool
+		ExpectedValue  string
+		ExpectedVer    int64
+	}{
+		{Name: "/app/secret", WithDecryption: true, ExpectedValue: "two", ExpectedVer: 2},
+		{Name: "/app/secret:1", WithDecryption: true, ExpectedValue: "one", ExpectedVer: 1},
+		{Name: "arn:aws:ssm:eu-west-1:123456789012:parameter/app/secret", WithDecryption: true, ExpectedValue: "two", ExpectedVer: 2},
+		{Name: "/app/secret", ExpectedValue: base64.StdEncoding.EncodeToString([]byte("alias/aws/ssm:two")), ExpectedVer: 2},
+	}
+
+	for _, testCase := range testCases {
+		got, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(testCase.Name),
+			WithDecryption: aws.Bool(testCase.WithDecryption),
+		})
+		if err != nil {
+			t.Fatalf("%s: %s", testCase.Name, err)
+		}
+		if aws.ToString(got.Parameter.Value) != testCase.ExpectedValue || got.Parameter.Version != testCase.ExpectedVer {
+			t.Errorf("%s: expected %q at version %d, got %q at version %d", testCase.Name, testCase.ExpectedValue, testCase.ExpectedVer, aws.ToString(got.Parameter.Value), got.Parameter.Version)
+		}
+		if got.Parameter.Type != ssm_types.ParameterTypeSecureString {
+			t.Errorf("%s: expected the type to be kept on overwrite, got %s", testCase.Name, got.Parameter.Type)
+		}
+	}
+
+	// Parameters are per region
+	_, err = newTestClient(t, server, "us-east-1").GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/app/secret")})
+	var notFound *ssm_types.ParameterNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected ParameterNotFound in another region, got %v", err)
+	}
+}
+
+func TestGetParametersAndByPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	for _, name := range []string{"/app/a", "/app/b", "/app/nested/c", "/other/d"} {
+		if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String(name), Type: ssm_types.ParameterTypeString}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := client.GetParameters(ctx, &ssm.GetParametersInput{Names: []string{"/app/a", "/missing", "/app/b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Parameters) != 2 || len(got.InvalidParameters) != 1 || got.InvalidParameters[0] != "/missing" {
+		t.Errorf("unexpected GetParameters output: %d parameters, invalid %v", len(got.Parameters), got.InvalidParameters)
+	}
+
+	testCases := []struct {
+		Recursive bool
+		Expected  int
+	}{
+		{Recursive: false, Expected: 2},
+		{Recursive: true, Expected: 3},
+	}
+
+	for _, testCase := range testCases {
+		var names []string
+		pages := ssm.NewGetParametersByPathPaginator(client, &ssm.GetParametersByPathInput{
+			Path:       aws.String("/app"),
+			Recursive:  aws.Bool(testCase.Recursive),
+			MaxResults: aws.Int32(1),
+		})
+		for pages.HasMorePages() {
+			page, err := pages.NextPage(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range page.Parameters {
+				names = append(names, aws.ToString(p.Name))
+			}
+		}
+		if len(names) != testCase.Expected {
+			t.Errorf("recursive %t: expected %d parameters, got %v", testCase.Recursive, testCase.Expected, names)
+		}
+	}
+}
+
+func TestDescribeLabelAndDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	for _, value := range []string{"one", "two"} {
+		if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/a"), Value: aws.String(value), Type: ssm_types.ParameterTypeString, Description: aws.String("test"), Overwrite: aws.Bool(true)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	described, err := client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+		ParameterFilters: []ssm_types.ParameterStringFilter{{Key: aws.String("Name"), Option: aws.String("Equals"), Values: []string{"/app/a"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(described.Parameters) != 1 || aws.ToString(described.Parameters[0].Description) != "test" || described.Parameters[0].Version != 2 {
+		t.Errorf("unexpected DescribeParameters output: %+v", described.Parameters)
+	}
+
+	labeled, err := client.LabelParameterVersion(ctx, &ssm.LabelParameterVersionInput{
+		Name:             aws.String("/app/a"),
+		Labels:           []string{"stable", "awsInvalid"},
+		ParameterVersion: aws.Int64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labeled.ParameterVersion != 1 || len(labeled.InvalidLabels) != 1 {
+		t.Errorf("unexpected LabelParameterVersion output: version %d, invalid %v", labeled.ParameterVersion, labeled.InvalidLabels)
+	}
+
+	got, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/app/a:stable")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(got.Parameter.Value) != "one" || aws.ToString(got.Parameter.Selector) != ":stable" {
+		t.Errorf("expected the labeled version, got %q with selector %q", aws.ToString(got.Parameter.Value), aws.ToString(got.Parameter.Selector))
+	}
+
+	if _, err := client.AddTagsToResource(ctx, &ssm.AddTagsToResourceInput{
+		ResourceId:   aws.String("/app/a"),
+		ResourceType: ssm_types.ResourceTypeForTaggingParameter,
+		Tags:         []ssm_types.Tag{{Key: aws.String("team"), Value: aws.String("platform")}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := client.ListTagsForResource(ctx, &ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String("/app/a"),
+		ResourceType: ssm_types.ResourceTypeForTaggingParameter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags.TagList) != 1 || aws.ToString(tags.TagList[0].Value) != "platform" {
+		t.Errorf("unexpected tags %+v", tags.TagList)
+	}
+
+	if _, err := client.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String("/app/a")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String("/app/a")})
+	var notFound *ssm_types.ParameterNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected ParameterNotFound, got %v", err)
+	}
+}
+
+func TestOverwriteDefaultKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	// The overwrite without KeyId uses the default key, as in AWS
+	for _, keyID := range []*string{aws.String("alias/app"), nil} {
+		if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/secret"), Value: aws.String("secret"), Type: ssm_types.ParameterTypeSecureString, KeyId: keyID, Overwrite: aws.Bool(true)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	described, err := client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+		ParameterFilters: []ssm_types.ParameterStringFilter{{Key: aws.String("Name"), Option: aws.String("Equals"), Values: []string{"/app/secret"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(described.Parameters) != 1 || aws.ToString(described.Parameters[0].KeyId) != "alias/aws/ssm" {
+		t.Errorf("expected the default key, got %+v", described.Parameters)
+	}
+}
+
+func TestSharedParameter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	server.Share("eu-west-1", "/shared/endpoint", "https://hub.example.com")
+	sharedARN := "arn:aws:ssm:eu-west-1:" + fakessm.SharingAccountID + ":parameter/shared/endpoint"
+
+	got, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(sharedARN)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(got.Parameter.Name) != sharedARN || aws.ToString(got.Parameter.Value) != "https://hub.example.com" {
+		t.Errorf("unexpected shared parameter %s = %s", aws.ToString(got.Parameter.Name), aws.ToString(got.Parameter.Value))
+	}
+
+	// Not among the parameters of the account
+	if _, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/shared/endpoint")}); err == nil {
+		t.Errorf("expected the shared parameter to be read by ARN only")
+	}
+
+	described, err := client.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+		Shared: aws.Bool(true),
+		ParameterFilters: []ssm_types.ParameterStringFilter{
+			{Key: aws.String("Name"), Option: aws.String("Equals"), Values: []string{sharedARN}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(described.Parameters) != 1 || described.Parameters[0].Tier != ssm_types.ParameterTierAdvanced {
+		t.Errorf("expected the Advanced shared parameter to be described, got %+v", described.Parameters)
+	}
+}
+
+func TestGetCallerIdentity(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	client := sts.New(sts.Options{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.NewCredentialsCache(staticCredentials{}),
+	})
+
+	got, err := client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(got.Arn) != fakessm.CallerARN || aws.ToString(got.Account) != fakessm.AccountID {
+		t.Errorf("unexpected identity %s in %s", aws.ToString(got.Arn), aws.ToString(got.Account))
+	}
+	if calls := server.Calls()["GetCallerIdentity"]; calls != 1 {
+		t.Errorf("expected 1 GetCallerIdentity call, got %d", calls)
+	}
+}
+
+func TestThrottling(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	server.SetThrottling(2)
+
+	// Without retries, to see every answer
+	client := ssm.New(ssm.Options{
+		Region:           "eu-west-1",
+		BaseEndpoint:     aws.String(server.URL),
+		Credentials:      aws.NewCredentialsCache(staticCredentials{}),
+		RetryMaxAttempts: 1,
+	})
+
+	var throttled int
+	for range 4 {
+		_, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/missing")})
+
+		var apiErr interface{ ErrorCode() string }
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ThrottlingException" {
+			throttled++
+		}
+	}
+
+	if throttled != 2 || server.Throttled() != 2 {
+		t.Errorf("expected 2 throttled requests, got %d, %d counted by the server", throttled, server.Throttled())
+	}
+}
+
+func TestStaleReads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	put := func(value string) {
+		_, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/config"), Value: aws.String(value), Type: ssm_types.ParameterTypeString, Overwrite: aws.Bool(true)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put("one")
+	server.SetStaleReads(1)
+	_, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/app/config")})
+	var notFound *ssm_types.ParameterNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected ParameterNotFound right after the creation, got %v", err)
+	}
+
+	put("two")
+	server.SetStaleReads(1)
+	for _, expected := range []int64{1, 2} {
+		output, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/app/config")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output.Parameter.Version != expected {
+			t.Errorf("expected version %d, got %d", expected, output.Parameter.Version)
+		}
+	}
+
+	// Reads of a version aren't stale
+	server.SetStaleReads(1)
+	output, err := client.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/app/config:2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Parameter.Version != 2 {
+		t.Errorf("expected version 2, got %d", output.Parameter.Version)
+	}
+}
+
+func TestServiceSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+	client := newTestClient(t, server, "eu-west-1")
+
+	get := func(id string) *ssm_types.ServiceSetting {
+		t.Helper()
+
+		output, err := client.GetServiceSetting(ctx, &ssm.GetServiceSettingInput{SettingId: aws.String(id)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return output.ServiceSetting
+	}
+
+	const id = "/ssm/parameter-store/high-throughput-enabled"
+	if setting := get(id); aws.ToString(setting.SettingValue) != "false" || aws.ToString(setting.Status) != "Default" {
+		t.Errorf("expected the default value false, got %s (%s)", aws.ToString(setting.SettingValue), aws.ToString(setting.Status))
+	}
+
+	if _, err := client.UpdateServiceSetting(ctx, &ssm.UpdateServiceSettingInput{SettingId: aws.String(id), SettingValue: aws.String("true")}); err != nil {
+		t.Fatal(err)
+	}
+	setting := get(id)
+	if aws.ToString(setting.SettingValue) != "true" || aws.ToString(setting.Status) != "Customized" {
+		t.Errorf("expected the customized value true, got %s (%s)", aws.ToString(setting.SettingValue), aws.ToString(setting.Status))
+	}
+
+	// The ARN identifies the setting too
+	if arnSetting := get(aws.ToString(setting.ARN)); aws.ToString(arnSetting.SettingValue) != "true" {
+		t.Errorf("expected the setting read by ARN to be true, got %s", aws.ToString(arnSetting.SettingValue))
+	}
+
+	// Settings are regional
+	output, err := newTestClient(t, server, "eu-central-1").GetServiceSetting(ctx, &ssm.GetServiceSettingInput{SettingId: aws.String(id)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(output.ServiceSetting.Status) != "Default" {
+		t.Errorf("expected the setting of another region to be the default, got %s", aws.ToString(output.ServiceSetting.Status))
+	}
+
+	_, err = client.UpdateServiceSetting(ctx, &ssm.UpdateServiceSettingInput{SettingId: aws.String(id), SettingValue: aws.String("yes")})
+	var apiErr interface{ ErrorCode() string }
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ValidationException" {
+		t.Errorf("expected ValidationException for an invalid value, got %v", err)
+	}
+
+	if _, err := client.ResetServiceSetting(ctx, &ssm.ResetServiceSettingInput{SettingId: aws.String(id)}); err != nil {
+		t.Fatal(err)
+	}
+	if setting := get(id); aws.ToString(setting.Status) != "Default" {
+		t.Errorf("expected the reset setting to be the default, got %s", aws.ToString(setting.Status))
+	}
+
+	_, err = client.GetServiceSetting(ctx, &ssm.GetServiceSettingInput{SettingId: aws.String("/ssm/unknown")})
+	var notFound *ssm_types.ServiceSettingNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected ServiceSettingNotFound, got %v", err)
+	}
+}
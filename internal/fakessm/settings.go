@@ -0,0 +1,99 @@
+package fakessm
+
+import (
+	"slices"
+	"strings"
+)
+
+// serviceSettings are the Parameter Store settings of the fake, with their values,
+// the first one being the default.
+var serviceSettings = map[string][]string{
+	"/ssm/parameter-store/default-parameter-tier":  {"Standard", "Advanced", "Intelligent-Tiering"},
+	"/ssm/parameter-store/high-throughput-enabled": {"false", "true"},
+}
+
+// wireServiceSetting is the ServiceSetting shape of the SSM API.
+type wireServiceSetting struct {
+	ARN          string
+	SettingID    string `json:"SettingId"`
+	SettingValue string
+	Status       string
+}
+
+// settingID resolves the ID of a setting, which may be given by its ARN.
+func (s *regionStore) settingID(id string) (string, error) {
+	if strings.HasPrefix(id, "arn:") {
+		_, rest, ok := strings.Cut(id, ":servicesetting")
+		if !ok {
+			return "", newAPIError("ValidationException", "invalid service setting ARN %s", id)
+		}
+		id = rest
+	}
+	if _, ok := serviceSettings[id]; !ok {
+		return "", newAPIError("ServiceSettingNotFound", "service setting %s not found", id)
+	}
+
+	return id, nil
+}
+
+func (s *regionStore) wireSetting(id string) wireServiceSetting {
+	setting := wireServiceSetting{
+		ARN:          "arn:aws:ssm:" + s.region + ":" + AccountID + ":servicesetting" + id,
+		SettingID:    id,
+		SettingValue: serviceSettings[id][0],
+		Status:       "Default",
+	}
+	if value, ok := s.settings[id]; ok {
+		setting.SettingValue = value
+		setting.Status = "Customized"
+	}
+
+	return setting
+}
+
+type serviceSettingInput struct {
+	SettingID string `json:"SettingId"`
+}
+
+type updateServiceSettingInput struct {
+	SettingID    string `json:"SettingId"`
+	SettingValue string
+}
+
+type serviceSettingOutput struct {
+	ServiceSetting *wireServiceSetting `json:",omitempty"`
+}
+
+func (s *regionStore) getServiceSetting(input *serviceSettingInput) (*serviceSettingOutput, error) {
+	id, err := s.settingID(input.SettingID)
+	if err != nil {
+		return nil, err
+	}
+	setting := s.wireSetting(id)
+
+	return &serviceSettingOutput{ServiceSetting: &setting}, nil
+}
+
+func (s *regionStore) updateServiceSetting(input *updateServiceSettingInput) (*serviceSettingOutput, error) {
+	id, err := s.settingID(input.SettingID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(serviceSettings[id], input.SettingValue) {
+		return nil, newAPIError("ValidationException", "invalid value %q of service setting %s, valid values: %s", input.SettingValue, id, strings.Join(serviceSettings[id], ", "))
+	}
+	s.settings[id] = input.SettingValue
+
+	return &serviceSettingOutput{}, nil
+}
+
+func (s *regionStore) resetServiceSetting(input *serviceSettingInput) (*serviceSettingOutput, error) {
+	id, err := s.settingID(input.SettingID)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.settings, id)
+	setting := s.wireSetting(id)
+
+	return &serviceSettingOutput{ServiceSetting: &setting}, nil
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// apiCallBudget aborts the plan or the apply once the AWS API calls of the provider
+// configuration exceed `max_api_calls`, so a misconfigured workspace can't exhaust the
+// rate limits of a shared account. The calls are counted by the plugin process, one per
+// configuration and walk.
+type apiCallBudget struct {
+	limit int64
+	calls atomic.Int64
+}
+
+// apiCallBudgetExceededError is returned in place of every call over the budget.
+type apiCallBudgetExceededError struct {
+	limit int64
+}
+
+func (e *apiCallBudgetExceededError) Error() string {
+	return fmt.Sprintf("the budget of %d AWS API calls set by max_api_calls is exhausted, aborting. "+
+		"Raise max_api_calls, or reduce the calls with prefetch_paths or read_cache", e.limit)
+}
+
+func newAPICallBudget(limit int64) *apiCallBudget {
+	return &apiCallBudget{limit: limit}
+}
+
+// addMiddleware registers the budget on a client stack, for use in aws.Config.APIOptions.
+// Every attempt counts, retries included.
+func (b *apiCallBudget) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("FastSSMCallBudget", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if b.calls.Add(1) > b.limit {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, &apiCallBudgetExceededError{limit: b.limit}
+		}
+
+		return next.HandleFinalize(ctx, in)
+	}), "Retry", middleware.After)
+}
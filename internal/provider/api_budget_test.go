@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestAPICallBudget(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	conn := ssm.New(ssm.Options{
+		Region:      "eu-west-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  transport,
+		APIOptions:  []func(*middleware.Stack) error{newAPICallBudget(2).addMiddleware},
+	})
+
+	for range 2 {
+		if _, err := findParameterByName(context.Background(), conn, "/test", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	_, err := findParameterByName(context.Background(), conn, "/test", true)
+
+	var budgetErr *apiCallBudgetExceededError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected the budget to be exceeded, got %v", err)
+	}
+	if isRetryableError(context.Background(), err) {
+		t.Errorf("unexpected retryable budget error")
+	}
+
+	if got := transport.calls.Load(); got != 2 {
+		t.Errorf("expected 2 API calls, got %d", got)
+	}
+}
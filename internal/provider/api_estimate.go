@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiCallEstimate sums the SSM API calls the changes planned for the fastssm_parameter
+// resources of a provider configuration are expected to make at apply time, and how
+// long they take at the configured rate. Terraform has no end of plan the provider
+// could report at, the estimate so far is logged with every planned change, the last
+// log of a plan having the whole of it, and, once it reaches
+// `api_call_estimate_warning_threshold`, reported as a warning.
+type apiCallEstimate struct {
+	region string
+	// tps is the rate the calls are expected to be made at, see newAPICallEstimate
+	tps int64
+	// batchSize is the number of reads of the refresh coalesced in a GetParameters call
+	batchSize int
+	// threshold is `api_call_estimate_warning_threshold`, 0 when unset
+	threshold int64
+
+	mu         sync.Mutex
+	operations map[string]int64
+	// refreshed and deleted are the resources read and deleted in batches
+	refreshed int64
+	deleted   int64
+	warned    bool
+}
+
+// newAPICallEstimate returns the estimate of a provider configuration. The calls are
+// expected at `shared_rate_limit_tps` when the rate is shared, and at the standard read
+// throughput of Parameter Store otherwise.
+func newAPICallEstimate(region string, tps int64, batchSize int, threshold int64) *apiCallEstimate {
+	if tps <= 0 {
+		tps = standardThroughputTPS
+	}
+
+	return &apiCallEstimate{
+		region:     region,
+		tps:        tps,
+		batchSize:  batchSize,
+		threshold:  threshold,
+		operations: make(map[string]int64),
+	}
+}
+
+// plannedParameterCalls are the calls planned for a single fastssm_parameter resource.
+type plannedParameterCalls struct {
+	operations map[string]int64
+	// refreshed is read again by the refresh of `terraform apply`, batched with the others
+	refreshed bool
+	// deleted is deleted, batched with the others in DeleteParameters calls
+	deleted bool
+}
+
+// parameterAPICalls estimates the calls of the apply of a fastssm_parameter from its
+// state, null on create, and its plan, null on destroy. write is whether the plan
+// writes the parameter, with an unknown `version`.
+func parameterAPICalls(plan, state ParameterResourceModel, exists, destroy, write bool) plannedParameterCalls {
+	calls := plannedParameterCalls{
+		operations: make(map[string]int64),
+		refreshed:  exists,
+	}
+
+	if destroy {
+		if !state.ValueJSONMergePatch.IsNull() {
+			// The patch is removed from the document, which is only deleted once empty
+			calls.operations["SSM.GetParameter"]++
+			calls.operations["SSM.PutParameter"]++
+			return calls
+		}
+		calls.deleted = true
+		return calls
+	}
+
+	if !write {
+		return calls
+	}
+
+	// Every write is read back
+	calls.operations["SSM.PutParameter"]++
+	calls.operations["SSM.GetParameter"]++
+
+	if !plan.ValueJSONMergePatch.IsNull() {
+		calls.operations["SSM.GetParameter"]++
+	}
+	if exists && plan.ExpectedVersion.ValueBool() {
+		calls.operations["SSM.GetParameter"]++
+	}
+	if exists && keepsCurrentValue(plan, state) {
+		calls.operations["SSM.GetParameter"]++
+	}
+
+	return calls
+}
+
+// plan adds the calls of a resource and logs the estimate so far at INFO level, warning
+// once when it reaches the threshold.
+func (e *apiCallEstimate) plan(ctx context.Context, calls plannedParameterCalls) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if e == nil {
+		return diags
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for operation, count := range calls.operations {
+		e.operations[operation] += count
+	}
+	if calls.refreshed {
+		e.refreshed++
+	}
+	if calls.deleted {
+		e.deleted++
+	}
+
+	total := e.total()
+	if total > 0 {
+		tflog.Info(ctx, "SSM API calls estimated for the changes planned so far", e.fields())
+	}
+
+	if e.threshold == 0 || e.warned || total < e.threshold {
+		return diags
+	}
+	e.warned = true
+
+	diags.AddWarning(
+		"Large apply ahead",
+		fmt.Sprintf("The changes planned so far for the fastssm_parameter resources of this provider configuration are estimated at %d SSM API calls "+
+			"at apply time, reaching api_call_estimate_warning_threshold. More resources may still be planned, "+
+			"the estimate of the whole plan is logged with the last of them, at the INFO level.\n\n%s", total, e.summary()),
+	)
+
+	return diags
+}
+
+// counts returns the calls per operation, the batched ones included.
+func (e *apiCallEstimate) counts() map[string]int64 {
+	counts := make(map[string]int64, len(e.operations)+2)
+	for operation, count := range e.operations {
+		if count > 0 {
+			counts[operation] = count
+		}
+	}
+	if e.refreshed > 0 {
+		counts["SSM.GetParameters"] += ceilDiv(e.refreshed, int64(max(e.batchSize, 1)))
+	}
+	if e.deleted > 0 {
+		counts["SSM.DeleteParameters"] += ceilDiv(e.deleted, deleteParametersMaxNames)
+	}
+
+	return counts
+}
+
+// fields returns the estimate as the fields of a structured log.
+func (e *apiCallEstimate) fields() map[string]any {
+	counts := e.counts()
+	operations := make(map[string]any, len(counts))
+	var total int64
+	for name, count := range counts {
+		operations[name] = count
+		total += count
+	}
+
+	return map[string]any{
+		"region":        e.region,
+		"calls":         total,
+		"operations":    operations,
+		"tps":           e.tps,
+		"duration_secs": ceilDiv(total, e.tps),
+	}
+}
+
+func (e *apiCallEstimate) total() int64 {
+	var total int64
+	for _, count := range e.counts() {
+		total += count
+	}
+
+	return total
+}
+
+// summary renders the estimate, one operation per line sorted by name, followed by
+// the total and the time it takes at the expected rate. Retries aren't counted.
+func (e *apiCallEstimate) summary() string {
+	counts := e.counts()
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	var total int64
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: calls=%d\n", name, counts[name])
+		total += counts[name]
+	}
+	eta := time.Duration(ceilDiv(total, e.tps)) * time.Second
+	fmt.Fprintf(&b, "total: calls=%d, at least %s at %d calls per second, the refresh of `terraform apply` included and retries excluded", total, eta, e.tps)
+
+	return b.String()
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
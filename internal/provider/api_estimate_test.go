@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParameterAPICalls(t *testing.T) {
+	t.Parallel()
+
+	state := ParameterResourceModel{
+		Value:               types.StringValue("a"),
+		ValueJSONMergePatch: types.StringNull(),
+	}
+
+	testCases := []struct {
+		Name       string
+		Plan       ParameterResourceModel
+		State      ParameterResourceModel
+		Exists     bool
+		Destroy    bool
+		Write      bool
+		Operations map[string]int64
+		Refreshed  bool
+		Deleted    bool
+	}{
+		{
+			Name:       "create",
+			Plan:       ParameterResourceModel{Value: types.StringValue("a")},
+			Write:      true,
+			Operations: map[string]int64{"SSM.PutParameter": 1, "SSM.GetParameter": 1},
+		},
+		{
+			Name:       "create with merge patch",
+			Plan:       ParameterResourceModel{ValueJSONMergePatch: types.StringValue(`{"a":1}`)},
+			Write:      true,
+			Operations: map[string]int64{"SSM.PutParameter": 1, "SSM.GetParameter": 2},
+		},
+		{
+			Name:       "no change",
+			Plan:       state,
+			State:      state,
+			Exists:     true,
+			Operations: map[string]int64{},
+			Refreshed:  true,
+		},
+		{
+			Name:       "update with expected version",
+			Plan:       ParameterResourceModel{Value: types.StringValue("b"), ExpectedVersion: types.BoolValue(true)},
+			State:      state,
+			Exists:     true,
+			Write:      true,
+			Operations: map[string]int64{"SSM.PutParameter": 1, "SSM.GetParameter": 2},
+			Refreshed:  true,
+		},
+		{
+			Name:       "destroy",
+			State:      state,
+			Exists:     true,
+			Destroy:    true,
+			Operations: map[string]int64{},
+			Refreshed:  true,
+			Deleted:    true,
+		},
+		{
+			Name:       "destroy with merge patch",
+			State:      ParameterResourceModel{ValueJSONMergePatch: types.StringValue(`{"a":1}`)},
+			Exists:     true,
+			Destroy:    true,
+			Operations: map[string]int64{"SSM.PutParameter": 1, "SSM.GetParameter": 1},
+			Refreshed:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := parameterAPICalls(testCase.Plan, testCase.State, testCase.Exists, testCase.Destroy, testCase.Write)
+
+			if len(calls.operations) != len(testCase.Operations) {
+				t.Errorf("expected %v, got %v", testCase.Operations, calls.operations)
+			}
+			for operation, count := range testCase.Operations {
+				if calls.operations[operation] != count {
+					t.Errorf("expected %v, got %v", testCase.Operations, calls.operations)
+				}
+			}
+			if calls.refreshed != testCase.Refreshed {
+				t.Errorf("expected refreshed %t, got %t", testCase.Refreshed, calls.refreshed)
+			}
+			if calls.deleted != testCase.Deleted {
+				t.Errorf("expected deleted %t, got %t", testCase.Deleted, calls.deleted)
+			}
+		})
+	}
+}
+
+func TestAPICallEstimate(t *testing.T) {
+	t.Parallel()
+
+	estimate := newAPICallEstimate("eu-west-1", 0, 10, 45)
+
+	warnings := 0
+	for range 20 {
+		// An update, refreshed and written
+		diags := estimate.plan(context.Background(), plannedParameterCalls{
+			operations: map[string]int64{"SSM.PutParameter": 1, "SSM.GetParameter": 1},
+			refreshed:  true,
+		})
+		warnings += diags.WarningsCount()
+	}
+	for range 11 {
+		diags := estimate.plan(context.Background(), plannedParameterCalls{operations: map[string]int64{}, refreshed: true, deleted: true})
+		warnings += diags.WarningsCount()
+	}
+
+	if warnings != 1 {
+		t.Errorf("expected a single warning, got %d", warnings)
+	}
+
+	// 31 refreshed and 11 deleted resources, batched by 10
+	expected := "SSM.DeleteParameters: calls=2\n" +
+		"SSM.GetParameter: calls=20\n" +
+		"SSM.GetParameters: calls=4\n" +
+		"SSM.PutParameter: calls=20\n" +
+		"total: calls=46, at least 2s at 40 calls per second"
+	if summary := estimate.summary(); !strings.HasPrefix(summary, expected) {
+		t.Errorf("expected a summary starting with %q, got %q", expected, summary)
+	}
+
+	expectedFields := map[string]any{
+		"region":        "eu-west-1",
+		"calls":         int64(46),
+		"tps":           int64(40),
+		"duration_secs": int64(2),
+		"operations": map[string]any{
+			"SSM.DeleteParameters": int64(2),
+			"SSM.GetParameter":     int64(20),
+			"SSM.GetParameters":    int64(4),
+			"SSM.PutParameter":     int64(20),
+		},
+	}
+	if diff := cmp.Diff(estimate.fields(), expectedFields); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestAPICallEstimateNil(t *testing.T) {
+	t.Parallel()
+
+	var estimate *apiCallEstimate
+	if diags := estimate.plan(context.Background(), plannedParameterCalls{refreshed: true}); diags.HasError() || diags.WarningsCount() != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
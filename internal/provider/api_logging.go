@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// awsLogSubsystem is the tflog subsystem of the AWS API calls
+	awsLogSubsystem = "aws"
+	// awsLogLevelEnvVar sets the level of the subsystem, e.g. TF_LOG_PROVIDER_FASTSSM_AWS=debug
+	awsLogLevelEnvVar = "TF_LOG_PROVIDER_FASTSSM_AWS"
+)
+
+// attemptCountKey holds the attempt counter of a call in the middleware stack values.
+type attemptCountKey struct{}
+
+// addAPILoggingMiddleware logs every attempt of an AWS API call to the aws subsystem,
+// for use in aws.Config.APIOptions.
+func addAPILoggingMiddleware(stack *middleware.Stack) error {
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("FastSSMCallLogging", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		ctx = tflog.NewSubsystem(ctx, awsLogSubsystem, tflog.WithLevelFromEnv(awsLogLevelEnvVar))
+		ctx = middleware.WithStackValue(ctx, attemptCountKey{}, new(int))
+
+		return next.HandleInitialize(ctx, in)
+	}), middleware.After)
+	if err != nil {
+		return err
+	}
+
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("FastSSMAttemptLogging", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		attempt, _ := middleware.GetStackValue(ctx, attemptCountKey{}).(*int)
+		if attempt == nil {
+			return next.HandleFinalize(ctx, in)
+		}
+		*attempt++
+
+		start := time.Now()
+		out, metadata, err := next.HandleFinalize(ctx, in)
+
+		fields := map[string]any{
+			"operation":   operationName(ctx),
+			"attempt":     *attempt,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+		if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+			fields["request_id"] = requestID
+		}
+
+		if err == nil {
+			tflog.SubsystemDebug(ctx, awsLogSubsystem, "AWS API call", fields)
+			return out, metadata, err
+		}
+
+		fields["error"] = err.Error()
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			fields["error_code"] = apiErr.ErrorCode()
+		}
+
+		// A missing parameter is an expected answer, throttling is worth a look
+		if isThrottlingError(err) {
+			tflog.SubsystemWarn(ctx, awsLogSubsystem, "AWS API call throttled", fields)
+		} else {
+			tflog.SubsystemDebug(ctx, awsLogSubsystem, "AWS API call failed", fields)
+		}
+
+		return out, metadata, err
+	}), "Retry", middleware.After)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestAPILoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	conn := ssm.New(ssm.Options{
+		Region:      "eu-west-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  &countingTransport{},
+		APIOptions:  []func(*middleware.Stack) error{addAPILoggingMiddleware},
+	})
+
+	if _, err := findParameterByName(ctx, conn, "/test", true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var found bool
+	for _, entry := range entries {
+		if entry["@message"] != "AWS API call" {
+			continue
+		}
+		found = true
+
+		for field, expected := range map[string]any{
+			"@module":    "provider." + awsLogSubsystem,
+			"operation":  "SSM.GetParameter",
+			"attempt":    float64(1),
+			"request_id": "test-request-id",
+		} {
+			if entry[field] != expected {
+				t.Errorf("expected %s to be %v, got %v", field, expected, entry[field])
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("expected an AWS API call entry, got %v", entries)
+	}
+}
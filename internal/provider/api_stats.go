@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiCalls counts the AWS API calls of the provider process, i.e. of the run.
+var apiCalls = newAPICallStats()
+
+// throttleWarningThreshold is the number of throttled attempts of a run from which
+// they're reported. A few are absorbed by the retries unnoticed.
+const throttleWarningThreshold = 10
+
+// apiCallStats records the calls made through the clients of the provider.
+type apiCallStats struct {
+	mu         sync.Mutex
+	operations map[string]*apiOperationStats
+	// throttlesWarned is set once the throttles were reported, see throttleWarning
+	throttlesWarned bool
+}
+
+// apiOperationStats are the figures of a single operation, e.g. SSM.GetParameter.
+type apiOperationStats struct {
+	// Calls made by the provider, each made of one or more attempts
+	Calls    int
+	Attempts int
+	// Throttles is the number of attempts rejected with ThrottlingException, or throttled by KMS
+	Throttles int
+	Duration  time.Duration
+}
+
+func newAPICallStats() *apiCallStats {
+	return &apiCallStats{
+		operations: make(map[string]*apiOperationStats),
+	}
+}
+
+// apiCallStatsKey is the context key of the apiCallStats of a Terraform operation, see trackAPICalls.
+type apiCallStatsKey struct{}
+
+// addMiddleware registers the counting middlewares on a client stack, for use in aws.Config.APIOptions.
+// The calls are counted in s, and in the stats of the Terraform operation making them, if any.
+func (s *apiCallStats) addMiddleware(stack *middleware.Stack) error {
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("FastSSMCallStats", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		start := time.Now()
+		out, metadata, err := next.HandleInitialize(ctx, in)
+
+		s.recordCall(ctx, func(o *apiOperationStats) {
+			o.Calls++
+			o.Duration += time.Since(start)
+		})
+
+		return out, metadata, err
+	}), middleware.After)
+	if err != nil {
+		return err
+	}
+
+	// After the retry middleware, every attempt goes through it
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("FastSSMAttemptStats", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		out, metadata, err := next.HandleFinalize(ctx, in)
+
+		s.recordCall(ctx, func(o *apiOperationStats) {
+			o.Attempts++
+			if isThrottlingError(err) {
+				o.Throttles++
+			}
+		})
+
+		return out, metadata, err
+	}), "Retry", middleware.After)
+}
+
+// recordCall records a call made with ctx in s and in the stats of its Terraform operation.
+func (s *apiCallStats) recordCall(ctx context.Context, fn func(*apiOperationStats)) {
+	s.record(operationName(ctx), fn)
+	if operation, ok := ctx.Value(apiCallStatsKey{}).(*apiCallStats); ok {
+		operation.record(operationName(ctx), fn)
+	}
+}
+
+func (s *apiCallStats) record(operation string, fn func(*apiOperationStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.operations[operation]
+	if !ok {
+		o = &apiOperationStats{}
+		s.operations[operation] = o
+	}
+	fn(o)
+}
+
+// total sums the figures of every operation.
+func (s *apiCallStats) total() apiOperationStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total apiOperationStats
+	for _, o := range s.operations {
+		total.Calls += o.Calls
+		total.Attempts += o.Attempts
+		total.Throttles += o.Throttles
+		total.Duration += o.Duration
+	}
+
+	return total
+}
+
+// fields renders the figures as the fields of a structured log: the totals, and the
+// calls per operation.
+func (s *apiCallStats) fields() map[string]any {
+	total := s.total()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make(map[string]any, len(s.operations))
+	for name, o := range s.operations {
+		calls[name] = o.Calls
+	}
+
+	return map[string]any{
+		"calls":       total.Calls,
+		"retries":     total.Attempts - total.Calls,
+		"throttles":   total.Throttles,
+		"duration_ms": total.Duration.Milliseconds(),
+		"operations":  calls,
+	}
+}
+
+// throttleWarning aggregates the throttled attempts of the run so far in a single
+// message, once they reach throttleWarningThreshold. It's empty before, and after it
+// was returned once.
+func (s *apiCallStats) throttleWarning() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.throttlesWarned {
+		return ""
+	}
+
+	var total int
+	var operations []string
+	for name, o := range s.operations {
+		if o.Throttles == 0 {
+			continue
+		}
+		total += o.Throttles
+		operations = append(operations, fmt.Sprintf("%s=%d", name, o.Throttles))
+	}
+	if total < throttleWarningThreshold {
+		return ""
+	}
+	slices.Sort(operations)
+	s.throttlesWarned = true
+
+	return fmt.Sprintf("%d throttled AWS API calls so far during the run (%s), retried with back-off at the cost of a slower run. "+
+		"Consider prefetch_paths or read_cache to reduce the SSM API calls. This warning is reported once per run, "+
+		"the throttles of each operation are logged.", total, strings.Join(operations, ", "))
+}
+
+// trackAPICalls returns the context of a Terraform operation, e.g. the read of a
+// resource, counting the AWS API calls made with it. The returned function, deferred
+// by the operation, logs them at INFO level, with the totals of the run so far. Terraform
+// runs a provider process per command, the last log of a run has its totals. An operation
+// throttled itself adds the throttle warning of the run to diags, see throttleWarning.
+func trackAPICalls(ctx context.Context) (context.Context, func(*diag.Diagnostics)) {
+	return apiCalls.track(ctx)
+}
+
+// track is trackAPICalls with s as the stats of the run.
+func (s *apiCallStats) track(ctx context.Context) (context.Context, func(*diag.Diagnostics)) {
+	operation := newAPICallStats()
+	ctx = context.WithValue(ctx, apiCallStatsKey{}, operation)
+
+	return ctx, func(diags *diag.Diagnostics) {
+		total := operation.total()
+		if total.Calls == 0 {
+			return
+		}
+
+		fields := operation.fields()
+		for name, value := range s.fields() {
+			if name != "operations" {
+				fields["run_"+name] = value
+			}
+		}
+		tflog.Info(ctx, "AWS API calls", fields)
+
+		// Reported by an operation that was throttled itself
+		if total.Throttles == 0 {
+			return
+		}
+		if warning := s.throttleWarning(); warning != "" {
+			diags.AddWarning("AWS API calls throttled", warning)
+		}
+	}
+}
+
+func operationName(ctx context.Context) string {
+	return awsmiddleware.GetServiceID(ctx) + "." + awsmiddleware.GetOperationName(ctx)
+}
+
+// isThrottlingError reports whether SSM, or KMS on SecureString operations, throttled the call.
+func isThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "ThrottlingException" || isKMSThrottlingError(err)
+}
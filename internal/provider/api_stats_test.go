@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestAPICallStats(t *testing.T) {
+	t.Parallel()
+
+	stats := newAPICallStats()
+	conn := ssm.New(ssm.Options{
+		Region:      "eu-west-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  &countingTransport{},
+		APIOptions:  []func(*middleware.Stack) error{stats.addMiddleware},
+	})
+
+	// Counted in the stats of the operation as well
+	ctx, report := stats.track(context.Background())
+	for _, ctx := range []context.Context{ctx, ctx, context.Background()} {
+		if _, err := findParameterByName(ctx, conn, "/test", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	operation := ctx.Value(apiCallStatsKey{}).(*apiCallStats)
+	for _, testCase := range []struct {
+		Stats    *apiCallStats
+		Expected map[string]any
+	}{
+		{
+			Stats:    operation,
+			Expected: map[string]any{"calls": 2, "retries": 0, "throttles": 0, "operations": map[string]any{"SSM.GetParameter": 2}},
+		},
+		{
+			Stats:    stats,
+			Expected: map[string]any{"calls": 3, "retries": 0, "throttles": 0, "operations": map[string]any{"SSM.GetParameter": 3}},
+		},
+	} {
+		fields := testCase.Stats.fields()
+		delete(fields, "duration_ms")
+		if diff := cmp.Diff(fields, testCase.Expected); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+	}
+
+	var diags diag.Diagnostics
+	report(&diags)
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	// The throttle warning of the run, from an operation throttled itself, once
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) { o.Throttles += throttleWarningThreshold })
+	for _, step := range []struct {
+		Throttles int
+		Expected  int
+	}{
+		{Throttles: 0, Expected: 0},
+		{Throttles: 1, Expected: 1},
+		{Throttles: 1, Expected: 0},
+	} {
+		ctx, report := stats.track(context.Background())
+		operation := ctx.Value(apiCallStatsKey{}).(*apiCallStats)
+		operation.record("SSM.GetParameter", func(o *apiOperationStats) { o.Calls++; o.Throttles += step.Throttles })
+
+		diags = nil
+		report(&diags)
+		if diags.WarningsCount() != step.Expected {
+			t.Errorf("expected %d warnings, got %v", step.Expected, diags)
+		}
+	}
+}
+
+func TestAPICallStatsThrottleWarning(t *testing.T) {
+	t.Parallel()
+
+	stats := newAPICallStats()
+	if got := stats.throttleWarning(); got != "" {
+		t.Errorf("expected no warning, got %q", got)
+	}
+
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) { o.Throttles += 3 })
+	stats.record("STS.GetCallerIdentity", func(o *apiOperationStats) { o.Calls++ })
+
+	// Below throttleWarningThreshold
+	if got := stats.throttleWarning(); got != "" {
+		t.Errorf("expected no warning, got %q", got)
+	}
+
+	stats.record("SSM.GetParameters", func(o *apiOperationStats) { o.Throttles += 7 })
+
+	expected := "10 throttled AWS API calls so far during the run (SSM.GetParameter=3, SSM.GetParameters=7), retried with back-off at the cost of a slower run. " +
+		"Consider prefetch_paths or read_cache to reduce the SSM API calls. This warning is reported once per run, the throttles of each operation are logged."
+	if got := stats.throttleWarning(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	// Reported once
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) { o.Throttles++ })
+	if got := stats.throttleWarning(); got != "" {
+		t.Errorf("expected no warning, got %q", got)
+	}
+}
+
+func TestIsThrottlingError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Err      error
+		Expected bool
+	}{
+		{Name: "ssm", Err: &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}, Expected: true},
+		{Name: "kms code", Err: &smithy.GenericAPIError{Code: "KMSThrottlingException"}, Expected: true},
+		{Name: "kms relayed", Err: &smithy.GenericAPIError{Code: "InternalServerError", Message: "Rate exceeded (Service: AWSKMS; Status Code: 400; Error Code: ThrottlingException)"}, Expected: true},
+		{Name: "kms access denied", Err: &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: kms:Decrypt"}},
+		{Name: "other", Err: &smithy.GenericAPIError{Code: "InternalServerError"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isThrottlingError(testCase.Err); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
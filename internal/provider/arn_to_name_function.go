@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ARNToNameFunction{}
+
+func NewARNToNameFunction() function.Function {
+	return &ARNToNameFunction{}
+}
+
+// ARNToNameFunction extracts the parameter name out of an SSM parameter ARN.
+type ARNToNameFunction struct{}
+
+func (f *ARNToNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "arn_to_name"
+}
+
+func (f *ARNToNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Extract the parameter name from an SSM parameter ARN",
+		Description: "Given the ARN of an SSM parameter in any partition, returns the parameter name. " +
+			"Hierarchical names are returned with their leading forward slash (`/`).",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "arn",
+				Description: "ARN of the SSM parameter.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ARNToNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	name, err := parameterNameFromARN(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
+
+// parameterNameFromARN reverses the ARN format of SSM parameters, where the leading
+// slash of a hierarchical name is folded into the `parameter/` resource prefix.
+func parameterNameFromARN(value string) (string, error) {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("%q is an invalid ARN: %s", value, err)
+	}
+
+	if parsed.Service != "ssm" {
+		return "", fmt.Errorf("%q is not an SSM ARN, got service %q", value, parsed.Service)
+	}
+
+	name, ok := strings.CutPrefix(parsed.Resource, "parameter/")
+	if !ok || name == "" {
+		return "", fmt.Errorf("%q is not an SSM parameter ARN", value)
+	}
+
+	if strings.Contains(name, "/") {
+		return "/" + name, nil
+	}
+
+	return name, nil
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestARNToNameFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		ARN         string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "hierarchical name",
+			ARN:      "arn:aws:ssm:eu-west-1:123456789012:parameter/app/prod/db",
+			Expected: "/app/prod/db",
+		},
+		{
+			Name:     "flat name",
+			ARN:      "arn:aws:ssm:eu-west-1:123456789012:parameter/db",
+			Expected: "db",
+		},
+		{
+			Name:     "other partition",
+			ARN:      "arn:aws-cn:ssm:cn-north-1:123456789012:parameter/app/db",
+			Expected: "/app/db",
+		},
+		{
+			Name:        "not an ARN",
+			ARN:         "/app/db",
+			ExpectError: true,
+		},
+		{
+			Name:        "other service",
+			ARN:         "arn:aws:s3:::bucket/app/db",
+			ExpectError: true,
+		},
+		{
+			Name:        "other SSM resource",
+			ARN:         "arn:aws:ssm:eu-west-1:123456789012:document/app",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.ARN)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewARNToNameFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.StringValue(testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	sts_types "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mfaTokenCodeEnvVar holds the MFA code of the assume_role blocks with a `serial_number`
+// and no `token_code`.
+const mfaTokenCodeEnvVar = "FASTSSM_MFA_TOKEN_CODE"
+
+// maxChainedRoleDuration is the longest session AWS grants to a role assumed with role credentials.
+const maxChainedRoleDuration = time.Hour
+
+// assumeRoles replaces the credentials of cfg by those of the roles, assumed in
+// order: the first with the credentials of cfg, every next one with those of the
+// previous role. The roles are assumed lazily, on the first API call.
+func assumeRoles(ctx context.Context, cfg *aws.Config, roles []assumeRoleModel, endpoints endpoints) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var assumed int
+
+	for i, role := range roles {
+		// For historical reasons, an empty block is allowed
+		if role.RoleARN.ValueString() == "" {
+			continue
+		}
+		attributePath := path.Root("assume_role").AtListIndex(i)
+
+		var duration time.Duration
+		if !role.Duration.IsNull() {
+			// Already validated
+			duration, _ = time.ParseDuration(role.Duration.ValueString())
+		}
+		if assumed > 0 && duration > maxChainedRoleDuration {
+			diags.AddAttributeError(
+				attributePath.AtName("duration"),
+				"invalid duration of a chained role",
+				fmt.Sprintf("%s is assumed with the credentials of a role, AWS limits its session to %s, got %s.", role.RoleARN.ValueString(), maxChainedRoleDuration, duration),
+			)
+			continue
+		}
+
+		var code string
+		var tokenProvider func() (string, error)
+		if !role.SerialNumber.IsNull() {
+			code = role.TokenCode.ValueString()
+			if code == "" {
+				code = os.Getenv(mfaTokenCodeEnvVar)
+			}
+			if !mfaTokenCodeRegexp.MatchString(code) {
+				diags.AddAttributeError(
+					attributePath.AtName("token_code"),
+					"MFA token code required",
+					fmt.Sprintf("%s requires the code of the MFA device %s: set token_code, or the %s environment variable to the 6 digits of the code.", role.RoleARN.ValueString(), role.SerialNumber.ValueString(), mfaTokenCodeEnvVar),
+				)
+				continue
+			}
+			tokenProvider = mfaTokenProvider(role.RoleARN.ValueString(), code)
+		}
+
+		var policyARNs, transitiveTagKeys []string
+		var tags map[string]string
+		diags.Append(role.PolicyARNs.ElementsAs(ctx, &policyARNs, false)...)
+		diags.Append(role.TransitiveTagKeys.ElementsAs(ctx, &transitiveTagKeys, false)...)
+		diags.Append(role.Tags.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		// The client signs with the credentials of the previous role
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(*cfg, endpoints.stsOptions), role.RoleARN.ValueString(), func(o *stscreds.AssumeRoleOptions) {
+			o.Duration = duration
+			o.ExternalID = role.ExternalID.ValueStringPointer()
+			o.Policy = role.Policy.ValueStringPointer()
+			o.SourceIdentity = role.SourceIdentity.ValueStringPointer()
+			o.SerialNumber = role.SerialNumber.ValueStringPointer()
+			o.TokenProvider = tokenProvider
+			o.TransitiveTagKeys = transitiveTagKeys
+			if !role.SessionName.IsNull() {
+				o.RoleSessionName = role.SessionName.ValueString()
+			}
+			for _, arn := range policyARNs {
+				o.PolicyARNs = append(o.PolicyARNs, sts_types.PolicyDescriptorType{Arn: aws.String(arn)})
+			}
+			for key, value := range tags {
+				o.Tags = append(o.Tags, sts_types.Tag{Key: aws.String(key), Value: aws.String(value)})
+			}
+		})
+		var credentials aws.CredentialsProvider = provider
+		if tokenProvider != nil {
+			session, err := newMFASessionCredentials(provider, role.RoleARN.ValueString(), role.SerialNumber.ValueString(), code)
+			if err != nil {
+				diags.AddAttributeWarning(
+					attributePath.AtName("serial_number"),
+					"MFA session not shared",
+					fmt.Sprintf("The session of %s can't be shared with the other Terraform commands, each needs a new code: %s", role.RoleARN.ValueString(), err),
+				)
+			} else {
+				credentials = session
+			}
+		}
+		cfg.Credentials = aws.NewCredentialsCache(credentials)
+		assumed++
+	}
+
+	return diags
+}
+
+// mfaTokenProvider returns code on the first call only. A code is accepted once, the
+// renewal of an expired session fails with an explanation instead of AccessDenied. The
+// other processes share the session through mfaSessionCredentials.
+func mfaTokenProvider(roleARN, code string) func() (string, error) {
+	var used atomic.Bool
+
+	return func() (string, error) {
+		if used.Swap(true) {
+			return "", fmt.Errorf("the session of %s expired and can't be renewed with the MFA token code already used, set a duration covering the run", roleARN)
+		}
+
+		return code, nil
+	}
+}
+
+// webIdentityToken is the web_identity_token of the provider block, the same for the whole run.
+type webIdentityToken string
+
+func (t webIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// emptyAssumeRoleWithWebIdentity is an assume_role_with_web_identity block without
+// attributes, every one of them read from the environment.
+var emptyAssumeRoleWithWebIdentity = assumeRoleWithWebIdentityModel{
+	Duration:             types.StringNull(),
+	Policy:               types.StringNull(),
+	PolicyARNs:           types.SetNull(types.StringType),
+	RoleARN:              types.StringNull(),
+	SessionName:          types.StringNull(),
+	WebIdentityToken:     types.StringNull(),
+	WebIdentityTokenFile: types.StringNull(),
+}
+
+// defaultsToWebIdentity reports whether the credentials come from the web identity of
+// AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE, as set up in the pods by EKS IAM roles
+// for service accounts (IRSA). Static credentials and profiles take precedence, as
+// in the default credential chain of the SDK.
+func defaultsToWebIdentity(data FastSSMProviderModel) bool {
+	if !data.AccessKey.IsNull() || !data.Profile.IsNull() {
+		return false
+	}
+	for _, env := range []string{"AWS_ACCESS_KEY_ID", "AWS_PROFILE"} {
+		if os.Getenv(env) != "" {
+			return false
+		}
+	}
+
+	return os.Getenv("AWS_ROLE_ARN") != "" && os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != ""
+}
+
+// hcpTerraformWebIdentity returns the web identity of the dynamic provider credentials
+// of HCP Terraform, enabled by TFC_AWS_PROVIDER_AUTH on the workspace: the role of
+// TFC_AWS_RUN_ROLE_ARN, assumed with the workload identity token of the run. Static
+// credentials, profiles and shared config files of the provider block take precedence,
+// the latter carrying the dynamic credentials of aliases.
+func hcpTerraformWebIdentity(data FastSSMProviderModel) (assumeRoleWithWebIdentityModel, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	role := emptyAssumeRoleWithWebIdentity
+
+	if enabled, _ := strconv.ParseBool(os.Getenv("TFC_AWS_PROVIDER_AUTH")); !enabled || !data.AccessKey.IsNull() || !data.Profile.IsNull() || !data.SharedConfigFiles.IsNull() {
+		return role, false, diags
+	}
+
+	roleARN := os.Getenv("TFC_AWS_RUN_ROLE_ARN")
+	if roleARN == "" {
+		diags.AddError(
+			"missing HCP Terraform run role",
+			"TFC_AWS_PROVIDER_AUTH enables the dynamic provider credentials of HCP Terraform, but TFC_AWS_RUN_ROLE_ARN is not set. "+
+				"Set it on the workspace to the ARN of the role trusting the HCP Terraform OIDC provider.",
+		)
+		return role, true, diags
+	}
+	role.RoleARN = types.StringValue(roleARN)
+
+	// The token file written by the agent, the token itself otherwise
+	if file := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); file != "" {
+		role.WebIdentityTokenFile = types.StringValue(file)
+	} else if token := os.Getenv("TFC_WORKLOAD_IDENTITY_TOKEN"); token != "" {
+		role.WebIdentityToken = types.StringValue(token)
+	} else {
+		diags.AddError(
+			"missing HCP Terraform workload identity token",
+			"TFC_AWS_PROVIDER_AUTH enables the dynamic provider credentials of HCP Terraform, but the run has no workload identity token, "+
+				"neither AWS_WEB_IDENTITY_TOKEN_FILE nor TFC_WORKLOAD_IDENTITY_TOKEN is set. Dynamic credentials need HCP Terraform, "+
+				"or Terraform Enterprise v202207-1 and later, and agents v1.7.0 and later.",
+		)
+		return role, true, diags
+	}
+
+	// Traces the calls of a run in CloudTrail
+	if runID := os.Getenv("TFC_RUN_ID"); runID != "" {
+		role.SessionName = types.StringValue(runID)
+	}
+
+	return role, true, diags
+}
+
+// assumeRoleWithWebIdentity replaces the credentials of cfg by those of the role,
+// assumed with an OpenID Connect token. A token file is read on every refresh of
+// the credentials, the platforms rotating it well within a long apply.
+func assumeRoleWithWebIdentity(ctx context.Context, cfg *aws.Config, role assumeRoleWithWebIdentityModel, endpoints endpoints) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	valueOrEnv := func(value types.String, env string) string {
+		if v := value.ValueString(); v != "" {
+			return v
+		}
+		return os.Getenv(env)
+	}
+
+	roleARN := valueOrEnv(role.RoleARN, "AWS_ROLE_ARN")
+	// For historical reasons, an empty block is allowed
+	if roleARN == "" {
+		return diags
+	}
+
+	var token stscreds.IdentityTokenRetriever
+	if !role.WebIdentityToken.IsNull() {
+		token = webIdentityToken(role.WebIdentityToken.ValueString())
+	} else if file := valueOrEnv(role.WebIdentityTokenFile, "AWS_WEB_IDENTITY_TOKEN_FILE"); file != "" {
+		token = stscreds.IdentityTokenFile(file)
+	} else {
+		diags.AddAttributeError(
+			path.Root("assume_role_with_web_identity").AtListIndex(0),
+			"missing web identity token",
+			"Set `web_identity_token`, `web_identity_token_file` or the AWS_WEB_IDENTITY_TOKEN_FILE environment variable.",
+		)
+		return diags
+	}
+
+	var duration time.Duration
+	if !role.Duration.IsNull() {
+		// Already validated
+		duration, _ = time.ParseDuration(role.Duration.ValueString())
+	}
+
+	var policyARNs []string
+	diags.Append(role.PolicyARNs.ElementsAs(ctx, &policyARNs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// AssumeRoleWithWebIdentity is authenticated by the token, cfg.Credentials aren't used
+	provider := stscreds.NewWebIdentityRoleProvider(sts.NewFromConfig(*cfg, endpoints.stsOptions), roleARN, token, func(o *stscreds.WebIdentityRoleOptions) {
+		o.Duration = duration
+		o.Policy = role.Policy.ValueStringPointer()
+		o.RoleSessionName = valueOrEnv(role.SessionName, "AWS_ROLE_SESSION_NAME")
+		for _, arn := range policyARNs {
+			o.PolicyARNs = append(o.PolicyARNs, sts_types.PolicyDescriptorType{Arn: aws.String(arn)})
+		}
+	})
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+
+	return diags
+}
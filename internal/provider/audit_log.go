@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// auditRecord is a line of the `audit_log`, one change made by the provider.
+type auditRecord struct {
+	Timestamp string `json:"timestamp"`
+	Operation string `json:"operation"`
+	Name      string `json:"name"`
+	Region    string `json:"region"`
+	// OldVersion is omitted when the parameter is created, or deleted at an unknown version
+	OldVersion int64 `json:"old_version,omitempty"`
+	// NewVersion is omitted when the parameter is deleted
+	NewVersion int64  `json:"new_version,omitempty"`
+	CallerARN  string `json:"caller_arn"`
+	// RoleARN is the role the change was made with, omitted for the provider's own identity
+	RoleARN string `json:"role_arn,omitempty"`
+}
+
+// auditLog appends a JSON record of every parameter written or deleted by the
+// provider to a local file, evidence of the changes for auditors without access to
+// CloudTrail. A nil auditLog records nothing.
+type auditLog struct {
+	path      string
+	callerARN string
+	roleARN   string
+
+	now func() time.Time
+}
+
+// auditLogMu serializes the appends, the aliases of the provider may share the file.
+var auditLogMu sync.Mutex
+
+func newAuditLog(path, callerARN string) (*auditLog, error) {
+	// Fail at configure time rather than after the first change
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("opening the audit log: %w", err)
+	}
+
+	return &auditLog{
+		path:      path,
+		callerARN: callerARN,
+		now:       time.Now,
+	}, nil
+}
+
+// withRole returns the log recording the changes made with the assumed role.
+func (l *auditLog) withRole(roleARN string) *auditLog {
+	if l == nil {
+		return nil
+	}
+
+	role := *l
+	role.roleARN = roleARN
+
+	return &role
+}
+
+// putParameter records a PutParameter call that wrote version, which follows the
+// previous version unless it's the first.
+func (l *auditLog) putParameter(region, name string, version int64) diag.Diagnostics {
+	return l.record(auditRecord{
+		Operation:  "PutParameter",
+		Name:       name,
+		Region:     region,
+		OldVersion: version - 1,
+		NewVersion: version,
+	})
+}
+
+// deleteParameter records the deletion of a parameter last known at version, 0 when unknown.
+func (l *auditLog) deleteParameter(region, name string, version int64) diag.Diagnostics {
+	return l.record(auditRecord{
+		Operation:  "DeleteParameter",
+		Name:       name,
+		Region:     region,
+		OldVersion: version,
+	})
+}
+
+// record appends the record, a failure is a warning as the change is made already.
+func (l *auditLog) record(record auditRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if l == nil {
+		return diags
+	}
+
+	record.Timestamp = l.now().UTC().Format(time.RFC3339Nano)
+	record.CallerARN = l.callerARN
+	record.RoleARN = l.roleARN
+
+	if err := l.append(record); err != nil {
+		diags.AddWarning(
+			"Audit log not written",
+			fmt.Sprintf("The %s of %s in %s succeeded but couldn't be recorded in %s: %s", record.Operation, record.Name, record.Region, l.path, err),
+		)
+	}
+
+	return diags
+}
+
+func (l *auditLog) append(record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
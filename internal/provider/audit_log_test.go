@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := newAuditLog(path, "arn:aws:iam::123456789012:user/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	log.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	diags := log.putParameter("eu-west-1", "/test", 1)
+	diags.Append(log.putParameter("eu-west-1", "/test", 2)...)
+	diags.Append(log.deleteParameter("eu-west-1", "/test", 2)...)
+	diags.Append(log.withRole("arn:aws:iam::210987654321:role/fanout").putParameter("eu-west-1", "/test", 1)...)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		`{"timestamp":"2024-05-01T12:00:00Z","operation":"PutParameter","name":"/test","region":"eu-west-1","new_version":1,"caller_arn":"arn:aws:iam::123456789012:user/test"}`,
+		`{"timestamp":"2024-05-01T12:00:00Z","operation":"PutParameter","name":"/test","region":"eu-west-1","old_version":1,"new_version":2,"caller_arn":"arn:aws:iam::123456789012:user/test"}`,
+		`{"timestamp":"2024-05-01T12:00:00Z","operation":"DeleteParameter","name":"/test","region":"eu-west-1","old_version":2,"caller_arn":"arn:aws:iam::123456789012:user/test"}`,
+		`{"timestamp":"2024-05-01T12:00:00Z","operation":"PutParameter","name":"/test","region":"eu-west-1","new_version":1,"caller_arn":"arn:aws:iam::123456789012:user/test","role_arn":"arn:aws:iam::210987654321:role/fanout"}`,
+	}
+	if want := strings.Join(expected, "\n") + "\n"; string(content) != want {
+		t.Errorf("expected records:\n%s\ngot:\n%s", want, content)
+	}
+}
+
+func TestAuditLogFailure(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := newAuditLog(path, "arn:aws:iam::123456789012:user/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The change is made already, a record that can't be written only warns
+	log.path = filepath.Join(path, "missing", "audit.jsonl")
+	diags := log.putParameter("eu-west-1", "/test", 1)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+
+	var disabled *auditLog
+	if diags := disabled.deleteParameter("eu-west-1", "/test", 1); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics without audit log: %v", diags)
+	}
+
+	if _, err := newAuditLog(filepath.Join(path, "missing", "audit.jsonl"), ""); err == nil {
+		t.Errorf("expected an error opening an audit log in a missing directory")
+	}
+}
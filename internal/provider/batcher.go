@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// parameterBatchWindow is how long a batch waits for more reads before it is sent,
+// unless `read_batch_window_ms` is set.
+const parameterBatchWindow = 10 * time.Millisecond
+
+// parameterBatchOptions are `read_batch_window_ms` and `read_batch_size`.
+type parameterBatchOptions struct {
+	window   time.Duration
+	maxNames int
+}
+
+var defaultParameterBatchOptions = parameterBatchOptions{
+	window:   parameterBatchWindow,
+	maxNames: getParametersMaxNames,
+}
+
+// parameterBatcher coalesces concurrent reads into GetParameters calls of up to
+// maxNames names, getParametersMaxNames by default. Terraform refreshes many
+// resources and data sources at once, so most reads of a large configuration are
+// sent 10 at a time.
+type parameterBatcher struct {
+	conn     *ssm.Client
+	window   time.Duration
+	maxNames int
+
+	mu sync.Mutex
+	// pending holds the batch being filled, per value of WithDecryption
+	pending map[bool]*parameterBatch
+}
+
+// parameterBatch is a single GetParameters call shared by its callers.
+type parameterBatch struct {
+	names          []string
+	withDecryption bool
+
+	// done is closed once parameters and err are set
+	done       chan struct{}
+	parameters map[string]*ssm_types.Parameter
+	err        error
+}
+
+func newParameterBatcher(conn *ssm.Client, options parameterBatchOptions) *parameterBatcher {
+	return &parameterBatcher{
+		conn:     conn,
+		window:   options.window,
+		maxNames: options.maxNames,
+		pending:  make(map[bool]*parameterBatch),
+	}
+}
+
+// get reads a parameter through the next batch. Only plain names are batched, ARNs
+// and version or label selectors can't be matched reliably to the GetParameters
+// response, they are read one by one.
+func (b *parameterBatcher) get(ctx context.Context, name string, withDecryption bool) (*ssm_types.Parameter, error) {
+	if strings.Contains(name, ":") {
+		return findParameterByName(ctx, b.conn, name, withDecryption)
+	}
+
+	batch := b.add(ctx, name, withDecryption)
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	parameter, ok := batch.parameters[name]
+	if !ok {
+		return nil, &retry.NotFoundError{
+			Message: fmt.Sprintf("SSM Parameter %s not found", name),
+		}
+	}
+
+	return parameter, nil
+}
+
+// add queues the name on the pending batch, sending the batch once it's full.
+func (b *parameterBatcher) add(ctx context.Context, name string, withDecryption bool) *parameterBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch, ok := b.pending[withDecryption]
+	if !ok {
+		batch = &parameterBatch{
+			withDecryption: withDecryption,
+			done:           make(chan struct{}),
+		}
+		b.pending[withDecryption] = batch
+
+		// The batch outlives the read that opened it
+		flushCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(b.window, func() {
+			if b.detach(batch) {
+				b.flush(flushCtx, batch)
+			}
+		})
+	}
+
+	for _, n := range batch.names {
+		if n == name {
+			return batch
+		}
+	}
+	batch.names = append(batch.names, name)
+
+	if len(batch.names) == b.maxNames {
+		delete(b.pending, withDecryption)
+		go b.flush(context.WithoutCancel(ctx), batch)
+	}
+
+	return batch
+}
+
+// detach removes the batch from pending, reporting false when it's already sent.
+func (b *parameterBatcher) detach(batch *parameterBatch) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending[batch.withDecryption] != batch {
+		return false
+	}
+	delete(b.pending, batch.withDecryption)
+
+	return true
+}
+
+func (b *parameterBatcher) flush(ctx context.Context, batch *parameterBatch) {
+	defer close(batch.done)
+
+	tflog.Debug(ctx, "reading batched SSM parameters", map[string]any{"count": len(batch.names)})
+
+	output, err := b.conn.GetParameters(ctx, &ssm.GetParametersInput{
+		Names:          batch.names,
+		WithDecryption: &batch.withDecryption,
+	})
+	if err != nil {
+		batch.err = err
+		return
+	}
+
+	batch.parameters = make(map[string]*ssm_types.Parameter, len(output.Parameters))
+	for i := range output.Parameters {
+		batch.parameters[*output.Parameters[i].Name] = &output.Parameters[i]
+	}
+}
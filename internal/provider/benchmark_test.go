@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const (
+	// benchmarkEnvVar is the number of parameters provisioned by TestAccBenchmark, it's skipped when unset
+	benchmarkEnvVar = "FASTSSM_BENCHMARK"
+	// benchmarkOutputEnvVar is a file receiving the results as JSON
+	benchmarkOutputEnvVar = "FASTSSM_BENCHMARK_OUTPUT"
+	// benchmarkBaselineEnvVar is a file with earlier results, the run fails when fastssm makes more calls than it
+	benchmarkBaselineEnvVar = "FASTSSM_BENCHMARK_BASELINE"
+)
+
+// benchmarkResult is the cost of a phase of the benchmark.
+type benchmarkResult struct {
+	Provider   string           `json:"provider"`
+	Phase      string           `json:"phase"`
+	Parameters int              `json:"parameters"`
+	Calls      map[string]int64 `json:"calls"`
+	TotalCalls int64            `json:"total_calls"`
+	WallTime   time.Duration    `json:"wall_time_ns"`
+}
+
+// benchmarkRecorder splits the calls answered by the fake backend in phases.
+type benchmarkRecorder struct {
+	provider   string
+	parameters int
+
+	phase   string
+	start   time.Time
+	calls   map[string]int64
+	results []benchmarkResult
+}
+
+// mark closes the running phase and starts the next one, none when phase is empty.
+func (r *benchmarkRecorder) mark(phase string) {
+	calls := testAccFakeBackend.Calls()
+
+	if r.phase != "" {
+		result := benchmarkResult{
+			Provider:   r.provider,
+			Phase:      r.phase,
+			Parameters: r.parameters,
+			Calls:      make(map[string]int64),
+			WallTime:   time.Since(r.start),
+		}
+		for operation, n := range calls {
+			if n -= r.calls[operation]; n > 0 {
+				result.Calls[operation] = n
+				result.TotalCalls += n
+			}
+		}
+		r.results = append(r.results, result)
+	}
+
+	r.phase, r.start, r.calls = phase, time.Now(), calls
+}
+
+// TestAccBenchmark provisions FASTSSM_BENCHMARK parameters with fastssm and with the
+// AWS provider against the fake backend, and records the SSM API calls and the wall
+// time of apply, plan, refresh and destroy for each. The apply phase includes the
+// plan the test framework runs after it.
+func TestAccBenchmark(t *testing.T) {
+	parameters, _ := strconv.Atoi(os.Getenv(benchmarkEnvVar))
+	if parameters <= 0 {
+		t.Skipf("set %s to the number of parameters to run the benchmark", benchmarkEnvVar)
+	}
+	if testAccFakeBackend == nil {
+		t.Skipf("the benchmark counts the calls answered by the fake backend, set %s", fakeBackendEnvVar)
+	}
+
+	var results []benchmarkResult
+	for _, provider := range []string{"fastssm", "aws"} {
+		results = append(results, runBenchmark(t, provider, parameters)...)
+	}
+
+	for _, result := range results {
+		operations := slices.Sorted(maps.Keys(result.Calls))
+		var calls []string
+		for _, operation := range operations {
+			calls = append(calls, fmt.Sprintf("%s=%d", operation, result.Calls[operation]))
+		}
+		t.Logf("%-8s %-8s parameters=%d calls=%d time=%s (%s)", result.Provider, result.Phase, result.Parameters, result.TotalCalls, result.WallTime.Round(time.Millisecond), strings.Join(calls, ", "))
+	}
+
+	// The claim of the provider, refreshes are cheaper than with the AWS provider
+	for _, phase := range []string{"plan", "refresh"} {
+		fastssm, aws := findBenchmarkResult(results, "fastssm", phase), findBenchmarkResult(results, "aws", phase)
+		if fastssm != nil && aws != nil && fastssm.TotalCalls >= aws.TotalCalls {
+			t.Errorf("%s: fastssm made %d calls, not fewer than the %d of the AWS provider", phase, fastssm.TotalCalls, aws.TotalCalls)
+		}
+	}
+
+	if path := os.Getenv(benchmarkBaselineEnvVar); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var baseline []benchmarkResult
+		if err := json.Unmarshal(b, &baseline); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, result := range results {
+			previous := findBenchmarkResult(baseline, result.Provider, result.Phase)
+			if result.Provider != "fastssm" || previous == nil || previous.Parameters != result.Parameters {
+				continue
+			}
+			if result.TotalCalls > previous.TotalCalls {
+				t.Errorf("%s: regression, %d calls instead of %d in the baseline", result.Phase, result.TotalCalls, previous.TotalCalls)
+			}
+		}
+	}
+
+	if path := os.Getenv(benchmarkOutputEnvVar); path != "" {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func runBenchmark(t *testing.T, provider string, parameters int) []benchmarkResult {
+	recorder := &benchmarkRecorder{provider: provider, parameters: parameters}
+	config := testAccBenchmarkConfig(provider, parameters)
+
+	testCase := resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			recorder.mark("")
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { recorder.mark("apply") },
+				Config:    config,
+			},
+			{
+				PreConfig: func() { recorder.mark("plan") },
+				Config:    config,
+				PlanOnly:  true,
+			},
+			{
+				PreConfig:    func() { recorder.mark("refresh") },
+				RefreshState: true,
+			},
+			{
+				PreConfig: func() { recorder.mark("destroy") },
+				Config:    config,
+				Destroy:   true,
+			},
+		},
+	}
+	if provider == "aws" {
+		testCase.ExternalProviders = map[string]resource.ExternalProvider{
+			"aws": {Source: "hashicorp/aws", VersionConstraint: "~> 6.0"},
+		}
+	}
+
+	resource.Test(t, testCase)
+
+	return recorder.results
+}
+
+func findBenchmarkResult(results []benchmarkResult, provider, phase string) *benchmarkResult {
+	for i := range results {
+		if results[i].Provider == provider && results[i].Phase == phase {
+			return &results[i]
+		}
+	}
+
+	return nil
+}
+
+func testAccBenchmarkConfig(provider string, parameters int) string {
+	if provider == "fastssm" {
+		return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  count = %[1]d
+
+  name  = "/fastssm/acctest/benchmark/fastssm/${count.index}"
+  value = "value-${count.index}"
+  type  = "String"
+}
+`, parameters)
+	}
+
+	// The AWS provider doesn't need any other API with these settings
+	return fmt.Sprintf(`
+provider "aws" {
+  region                      = %[2]q
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  skip_region_validation      = true
+
+  endpoints {
+    ssm = %[3]q
+    sts = %[3]q
+  }
+}
+
+resource "aws_ssm_parameter" "test" {
+  count = %[1]d
+
+  name  = "/fastssm/acctest/benchmark/aws/${count.index}"
+  value = "value-${count.index}"
+  type  = "String"
+}
+`, parameters, os.Getenv("AWS_REGION"), testAccFakeBackend.URL)
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// FastSSMClient is the provider data handed to resources, data sources, ephemeral
+// resources and actions. It embeds the SSM client, so the API can be called on it directly.
+type FastSSMClient struct {
+	*ssm.Client
+
+	// reads is the in-memory read state of the provider process
+	reads *parameterReads
+	// prefetch serves the reads under `prefetch_paths`, nil without any
+	prefetch *parameterPrefetch
+	// diskCache persists reads between runs, nil unless `read_cache` is set
+	diskCache *parameterDiskCache
+	// deletes coalesces the deletions of the fastssm_parameter resources into DeleteParameters calls
+	deletes *parameterDeleteBatcher
+	// normalizeNames is `normalize_names`, see parameterName
+	normalizeNames bool
+	// plannedNames are the parameter names planned by the fastssm_parameter resources
+	plannedNames *parameterNameClaims
+	// highThroughput warns about large applies at the default throughput, nil unless `high_throughput_warning_threshold` is set
+	highThroughput *highThroughputCheck
+	// versionLimitWarning is `version_limit_warning_threshold`, 0 when unset
+	versionLimitWarning int64
+	// assumedRoles are the roles of the fastssm_parameter_fanout resources
+	assumedRoles *assumedRoles
+	// regionalClients are the clients of the regions read by the data sources setting `region`, and of the replicas
+	regionalClients *regionalClients
+	// plannedCalls estimates the API calls of the planned changes, nil in tests
+	plannedCalls *apiCallEstimate
+	// audit records the changes to parameters, nil unless `audit_log` is set
+	audit *auditLog
+	// retryableErrorCodes are the `retryable_error_codes` retried on top of the default ones
+	retryableErrorCodes []string
+	// disableRetries is `disable_retries`, no error is retried
+	disableRetries bool
+	// timeouts bound the retries of each SSM operation, see `operation_timeouts`
+	timeouts operationTimeouts
+}
+
+// operationTimeouts is how long the calls of an SSM operation are retried.
+type operationTimeouts struct {
+	deleteParameter    time.Duration
+	describeParameters time.Duration
+	getParameter       time.Duration
+	putParameter       time.Duration
+}
+
+// defaultOperationTimeouts apply to the operations `operation_timeouts` leaves unset.
+var defaultOperationTimeouts = operationTimeouts{
+	deleteParameter:    10 * time.Minute,
+	describeParameters: 5 * time.Minute,
+	getParameter:       2 * time.Minute,
+	putParameter:       10 * time.Minute,
+}
+
+// isRetryableError retries the throttled calls like the package-level isRetryableError,
+// and the transient failures as well, the error codes of `retryable_error_codes`
+// included. Nothing is retried with `disable_retries`.
+func (c *FastSSMClient) isRetryableError(ctx context.Context, err error) bool {
+	if c.disableRetries {
+		return false
+	}
+	if err != nil && isTransientError(err, c.retryableErrorCodes) {
+		tflog.Debug(ctx, "SSM API transient failure, retrying", map[string]any{"error": err.Error()})
+		return sleepContext(ctx, transientBackoff)
+	}
+
+	return isRetryableError(ctx, err)
+}
+
+// parameterName returns the name sent to SSM for a configured parameter name,
+// normalized when `normalize_names` is set.
+func (c *FastSSMClient) parameterName(name string) string {
+	if !c.normalizeNames {
+		return name
+	}
+
+	return normalizeParameterName(name)
+}
+
+// normalizeParameterName prefixes hierarchical names with / and collapses the
+// repeated slashes, app//db/password becomes /app/db/password. Names without
+// any /, and ARNs, are left alone.
+func normalizeParameterName(name string) string {
+	if !strings.Contains(name, "/") || strings.HasPrefix(name, "arn:") {
+		return name
+	}
+
+	var b strings.Builder
+	for _, segment := range strings.Split(name, "/") {
+		if segment != "" {
+			b.WriteString("/" + segment)
+		}
+	}
+
+	return b.String()
+}
+
+// parameterNameClaims records the parameter names the fastssm_parameter resources of
+// a provider configuration are planned to manage, catching two of them resolving to
+// the same parameter before they overwrite each other at apply time.
+type parameterNameClaims struct {
+	mu sync.Mutex
+	// names holds the owner of each claimed name, see claim
+	names map[string]string
+}
+
+func newParameterNameClaims() *parameterNameClaims {
+	return &parameterNameClaims{names: make(map[string]string)}
+}
+
+// claim records name for owner, the fingerprint of the configuration of the resource,
+// and reports false when another owner claimed it already. Terraform plans a replaced
+// resource twice, with its prior state and then without, the same configuration
+// claiming the name again. Resources configured identically can't be told apart, they
+// write the same parameter anyway.
+func (c *parameterNameClaims) claim(name, owner string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if claimed, ok := c.names[name]; ok {
+		return claimed == owner
+	}
+	c.names[name] = owner
+
+	return true
+}
+
+// parameterReads is the in-memory read state of a run.
+type parameterReads struct {
+	// group deduplicates concurrent GetParameter calls for the same parameter
+	group singleflight.Group
+	// batcher coalesces the remaining concurrent reads into GetParameters calls
+	batcher *parameterBatcher
+
+	// parameters memoizes reads for the rest of the run, including the not-found ones
+	parametersMu sync.Mutex
+	parameters   map[parameterReadKey]parameterRead
+}
+
+// parameterRead is the outcome of a memoized read, err is only ever a not-found error.
+type parameterRead struct {
+	parameter *ssm_types.Parameter
+	err       error
+}
+
+// parameterReadKey identifies a read, name may carry a version or label selector.
+type parameterReadKey struct {
+	name           string
+	withDecryption bool
+}
+
+func newFastSSMClient(client *ssm.Client, batching parameterBatchOptions) *FastSSMClient {
+	return &FastSSMClient{
+		Client: client,
+		reads: &parameterReads{
+			batcher:    newParameterBatcher(client, batching),
+			parameters: make(map[parameterReadKey]parameterRead),
+		},
+		deletes:         newParameterDeleteBatcher(client, parameterBatchWindow),
+		plannedNames:    newParameterNameClaims(),
+		regionalClients: newRegionalClients(),
+		timeouts:        defaultOperationTimeouts,
+	}
+}
+
+// readParameter is findParameterByName shared by every caller in the run. Identical
+// reads, in flight or done, result in a single API call, and concurrent reads of
+// different parameters are batched together.
+func (c *FastSSMClient) readParameter(ctx context.Context, name string, withDecryption bool) (*ssm_types.Parameter, error) {
+	key := parameterReadKey{name: name, withDecryption: withDecryption}
+
+	if read, ok := c.reads.get(key); ok {
+		return read.parameter, read.err
+	}
+
+	if parameter, ok, err := c.prefetch.get(ctx, name, withDecryption); ok {
+		return parameter, err
+	}
+
+	flightKey := name
+	if withDecryption {
+		flightKey += "\x00decrypted"
+	}
+
+	v, err, _ := c.reads.group.Do(flightKey, func() (any, error) {
+		parameter, ok := c.diskCache.get(name, withDecryption)
+		if ok {
+			c.reads.put(key, parameterRead{parameter: parameter})
+
+			return parameter, nil
+		}
+
+		// The context of the first caller is used for everyone waiting on the call
+		parameter, err := c.reads.batcher.get(ctx, name, withDecryption)
+		if err == nil {
+			if err := c.diskCache.put(name, withDecryption, parameter); err != nil {
+				tflog.Warn(ctx, "writing the read cache failed", map[string]any{"error": err.Error()})
+			}
+		}
+		// Optional lookups of missing parameters are common, they are remembered as well
+		if err != nil && !tfresource.NotFound(err) {
+			return nil, err
+		}
+
+		c.reads.put(key, parameterRead{parameter: parameter, err: err})
+
+		return parameter, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*ssm_types.Parameter), nil
+}
+
+// forgetParameter drops the memoized reads of a parameter after it has been written or deleted.
+func (c *FastSSMClient) forgetParameter(name string) {
+	c.prefetch.forget(name)
+	c.diskCache.forget(name)
+	c.reads.forget(name)
+}
+
+func (r *parameterReads) get(key parameterReadKey) (parameterRead, bool) {
+	r.parametersMu.Lock()
+	defer r.parametersMu.Unlock()
+
+	read, ok := r.parameters[key]
+
+	return read, ok
+}
+
+func (r *parameterReads) put(key parameterReadKey, read parameterRead) {
+	r.parametersMu.Lock()
+	defer r.parametersMu.Unlock()
+
+	r.parameters[key] = read
+}
+
+func (r *parameterReads) forget(name string) {
+	r.parametersMu.Lock()
+	defer r.parametersMu.Unlock()
+
+	for key := range r.parameters {
+		if key.name == name || strings.HasPrefix(key.name, name+":") {
+			delete(r.parameters, key)
+		}
+	}
+}
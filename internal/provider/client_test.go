@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// countingTransport answers GetParameter, GetParameters, GetParametersByPath and
+// DeleteParameters calls and counts them. Names starting with /missing don't exist.
+type countingTransport struct {
+	calls atomic.Int32
+}
+
+func (t *countingTransport) Do(req *http.Request) (*http.Response, error) {
+	t.calls.Add(1)
+
+	body := `{"Parameter":{"Name":"/test","Type":"String","Value":"v","Version":1}}`
+	if req.Header.Get("X-Amz-Target") == "AmazonSSM.DeleteParameters" {
+		var input struct{ Names []string }
+		if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+			return nil, err
+		}
+
+		output := struct {
+			DeletedParameters []string
+			InvalidParameters []string
+		}{}
+		for _, name := range input.Names {
+			if strings.HasPrefix(name, "/missing") {
+				output.InvalidParameters = append(output.InvalidParameters, name)
+				continue
+			}
+			output.DeletedParameters = append(output.DeletedParameters, name)
+		}
+
+		b, err := json.Marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+
+	if req.Header.Get("X-Amz-Target") == "AmazonSSM.GetParameters" {
+		var input struct{ Names []string }
+		if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+			return nil, err
+		}
+
+		output := struct {
+			Parameters        []map[string]any
+			InvalidParameters []string
+		}{}
+		for _, name := range input.Names {
+			if strings.HasPrefix(name, "/missing") {
+				output.InvalidParameters = append(output.InvalidParameters, name)
+				continue
+			}
+			output.Parameters = append(output.Parameters, map[string]any{"Name": name, "Type": "String", "Value": "v", "Version": 1})
+		}
+
+		b, err := json.Marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+
+	// Every path holds the parameters a and b
+	if req.Header.Get("X-Amz-Target") == "AmazonSSM.GetParametersByPath" {
+		var input struct{ Path string }
+		if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+			return nil, err
+		}
+
+		body = fmt.Sprintf(`{"Parameters":[{"Name":"%[1]s/a","Type":"String","Value":"a"},{"Name":"%[1]s/b","Type":"SecureString","Value":"b"}]}`, input.Path)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}, "X-Amzn-Requestid": []string{"test-request-id"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func newTestFastSSMClient(transport *countingTransport) *FastSSMClient {
+	return newFastSSMClient(ssm.New(ssm.Options{
+		Region:      "eu-west-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  transport,
+	}), defaultParameterBatchOptions)
+}
+
+func TestFastSSMClientReadParameter(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.readParameter(context.Background(), "/test", true); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := transport.calls.Load(); got != 1 {
+		t.Errorf("expected 1 API call, got %d", got)
+	}
+
+	// Without decryption is a different read
+	if _, err := client.readParameter(context.Background(), "/test", false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := transport.calls.Load(); got != 2 {
+		t.Errorf("expected 2 API calls, got %d", got)
+	}
+
+	client.forgetParameter("/test")
+	if _, err := client.readParameter(context.Background(), "/test", true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := transport.calls.Load(); got != 3 {
+		t.Errorf("expected 3 API calls after forgetting the parameter, got %d", got)
+	}
+}
+
+func TestParameterBatcher(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	// A long window, the batches are sent once full
+	batcher := newParameterBatcher(client.Client, parameterBatchOptions{window: time.Minute, maxNames: getParametersMaxNames})
+
+	var wg sync.WaitGroup
+	for i := range 2 * getParametersMaxNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			name := fmt.Sprintf("/test/%d", i)
+			parameter, err := batcher.get(context.Background(), name, true)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if *parameter.Name != name {
+				t.Errorf("expected parameter %s, got %s", name, *parameter.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := transport.calls.Load(); got != 2 {
+		t.Errorf("expected 2 GetParameters calls, got %d", got)
+	}
+}
+
+func TestParameterBatcherMaxNames(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	batcher := newParameterBatcher(client.Client, parameterBatchOptions{window: time.Minute, maxNames: 1})
+
+	for i := range 3 {
+		if _, err := batcher.get(context.Background(), fmt.Sprintf("/test/%d", i), true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// A batch of one is sent right away, whatever the window
+	if got := transport.calls.Load(); got != 3 {
+		t.Errorf("expected 3 GetParameters calls, got %d", got)
+	}
+}
+
+func TestParameterBatcherNotFound(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	batcher := newParameterBatcher(client.Client, parameterBatchOptions{window: time.Millisecond, maxNames: getParametersMaxNames})
+
+	_, err := batcher.get(context.Background(), "/missing", true)
+	if !tfresource.NotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestParameterDeleteBatcher(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	// A long window, the batches are sent once full
+	batcher := newParameterDeleteBatcher(client.Client, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := range 2 * deleteParametersMaxNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := batcher.delete(context.Background(), fmt.Sprintf("/test/%d", i)); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := transport.calls.Load(); got != 2 {
+		t.Errorf("expected 2 DeleteParameters calls, got %d", got)
+	}
+}
+
+func TestParameterDeleteBatcherNotFound(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	batcher := newParameterDeleteBatcher(client.Client, time.Millisecond)
+
+	var notFound *ssm_types.ParameterNotFound
+	if err := batcher.delete(context.Background(), "/missing"); !errors.As(err, &notFound) {
+		t.Errorf("expected a ParameterNotFound error, got %v", err)
+	}
+}
+
+func TestFastSSMClientReadParameterNotFound(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+
+	for range 3 {
+		if _, err := client.readParameter(context.Background(), "/missing", true); !tfresource.NotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	}
+
+	if got := transport.calls.Load(); got != 1 {
+		t.Errorf("expected 1 API call, got %d", got)
+	}
+
+	client.forgetParameter("/missing")
+	if _, err := client.readParameter(context.Background(), "/missing", true); !tfresource.NotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if got := transport.calls.Load(); got != 2 {
+		t.Errorf("expected 2 API calls after forgetting the parameter, got %d", got)
+	}
+}
+
+func TestFastSSMClientReadParameterPrefetch(t *testing.T) {
+	t.Parallel()
+
+	transport := &countingTransport{}
+	client := newTestFastSSMClient(transport)
+	client.prefetch = newParameterPrefetch(client.Client, []string{"/app/prod/"}, 1, 0)
+
+	testCases := []struct {
+		Name           string
+		WithDecryption bool
+		ExpectedCalls  int32
+		ExpectNotFound bool
+	}{
+		{Name: "/app/prod/a", WithDecryption: true, ExpectedCalls: 1},
+		{Name: "/app/prod/b", WithDecryption: true, ExpectedCalls: 1},
+		{Name: "/app/prod/c", WithDecryption: true, ExpectedCalls: 1, ExpectNotFound: true},
+		// The ciphertext isn't prefetched
+		{Name: "/app/prod/b", WithDecryption: false, ExpectedCalls: 2},
+		// Not under the path
+		{Name: "/app/dev/a", WithDecryption: true, ExpectedCalls: 3},
+	}
+
+	for _, testCase := range testCases {
+		_, err := client.readParameter(context.Background(), testCase.Name, testCase.WithDecryption)
+		if testCase.ExpectNotFound && !tfresource.NotFound(err) {
+			t.Errorf("%s: expected a not found error, got %v", testCase.Name, err)
+		}
+		if !testCase.ExpectNotFound && err != nil {
+			t.Errorf("%s: unexpected error: %s", testCase.Name, err)
+		}
+
+		if got := transport.calls.Load(); got != testCase.ExpectedCalls {
+			t.Errorf("%s: expected %d API calls, got %d", testCase.Name, testCase.ExpectedCalls, got)
+		}
+	}
+}
+
+func TestNormalizeParameterName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{Name: "normalized", Input: "/app/db/password", Expected: "/app/db/password"},
+		{Name: "leading slash", Input: "app/db/password", Expected: "/app/db/password"},
+		{Name: "repeated slashes", Input: "//app//db///password", Expected: "/app/db/password"},
+		{Name: "trailing slash", Input: "/app/db/", Expected: "/app/db"},
+		{Name: "flat", Input: "password", Expected: "password"},
+		{Name: "ARN", Input: "arn:aws:ssm:eu-west-1:123456789012:parameter/app//db", Expected: "arn:aws:ssm:eu-west-1:123456789012:parameter/app//db"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := normalizeParameterName(testCase.Input); got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestOperationTimeoutsApply(t *testing.T) {
+	t.Parallel()
+
+	model := operationTimeoutsModel{
+		DeleteParameter:    types.StringNull(),
+		DescribeParameters: types.StringValue("30s"),
+		GetParameter:       types.StringValue("10s"),
+		PutParameter:       types.StringNull(),
+	}
+
+	got := model.apply(defaultOperationTimeouts)
+	expected := operationTimeouts{
+		deleteParameter:    10 * time.Minute,
+		describeParameters: 30 * time.Second,
+		getParameter:       10 * time.Second,
+		putParameter:       10 * time.Minute,
+	}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestParameterNameClaims(t *testing.T) {
+	t.Parallel()
+
+	claims := newParameterNameClaims()
+	if !claims.claim("/app/config", "one") {
+		t.Errorf("expected the first claim to succeed")
+	}
+	// The second plan of a replacement
+	if !claims.claim("/app/config", "one") {
+		t.Errorf("expected the claim of the same owner to succeed")
+	}
+	if claims.claim("/app/config", "two") {
+		t.Errorf("expected the claim of another owner to fail")
+	}
+	if !claims.claim("/app/other", "two") {
+		t.Errorf("expected the claim of another name to succeed")
+	}
+}
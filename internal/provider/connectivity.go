@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+// connectivityTimeout bounds each step of the connectivity diagnostics. Endpoints
+// blocked by a security group drop the packets, they'd hang until the HTTP client
+// gives up otherwise.
+const connectivityTimeout = 5 * time.Second
+
+// connectivityProbe is the parameter read to check SSM is reachable, which needn't exist.
+const connectivityProbe = "/fastssm/connectivity-diagnostics"
+
+// authenticationErrorCodes are the errors of endpoints rejecting the credentials, or
+// the signature made with them.
+var authenticationErrorCodes = []string{
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"IncompleteSignature",
+	"InvalidClientTokenId",
+	"InvalidSignatureException",
+	"MissingAuthenticationToken",
+	"SignatureDoesNotMatch",
+	"UnrecognizedClientException",
+}
+
+// connectivityCheck is an endpoint the provider calls, with a call proving it's
+// reachable and authorized without changing anything.
+type connectivityCheck struct {
+	service  string
+	endpoint string
+	call     func(ctx context.Context) error
+}
+
+// connectivityChecks resolves the STS and SSM endpoints of cfg as the provider calls
+// them, VPC endpoint URLs of `endpoints` included. The calls aren't retried, the
+// diagnostics report the first failure.
+func connectivityChecks(ctx context.Context, cfg aws.Config, e endpoints) ([]connectivityCheck, error) {
+	stsClient := sts.NewFromConfig(cfg, e.stsOptions, func(o *sts.Options) { o.Retryer = aws.NopRetryer{} })
+	stsOptions := stsClient.Options()
+	stsEndpoint, err := stsOptions.EndpointResolverV2.ResolveEndpoint(ctx, sts.EndpointParameters{
+		Region:       aws.String(stsOptions.Region),
+		Endpoint:     stsOptions.BaseEndpoint,
+		UseFIPS:      aws.Bool(stsOptions.EndpointOptions.UseFIPSEndpoint == aws.FIPSEndpointStateEnabled),
+		UseDualStack: aws.Bool(stsOptions.EndpointOptions.UseDualStackEndpoint == aws.DualStackEndpointStateEnabled),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolving the STS endpoint: %w", err)
+	}
+
+	ssmClient := ssm.NewFromConfig(cfg, e.ssmOptions, func(o *ssm.Options) { o.Retryer = aws.NopRetryer{} })
+	ssmOptions := ssmClient.Options()
+	ssmEndpoint, err := ssmOptions.EndpointResolverV2.ResolveEndpoint(ctx, ssm.EndpointParameters{
+		Region:       aws.String(ssmOptions.Region),
+		Endpoint:     ssmOptions.BaseEndpoint,
+		UseFIPS:      aws.Bool(ssmOptions.EndpointOptions.UseFIPSEndpoint == aws.FIPSEndpointStateEnabled),
+		UseDualStack: aws.Bool(ssmOptions.EndpointOptions.UseDualStackEndpoint == aws.DualStackEndpointStateEnabled),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolving the SSM endpoint: %w", err)
+	}
+
+	return []connectivityCheck{
+		{
+			service:  "STS",
+			endpoint: stsEndpoint.URI.String(),
+			call: func(ctx context.Context) error {
+				_, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+				return err
+			},
+		},
+		{
+			service:  "SSM",
+			endpoint: ssmEndpoint.URI.String(),
+			call: func(ctx context.Context) error {
+				_, err := ssmClient.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(connectivityProbe)})
+				return ignoreErrorCodes(err, "ParameterNotFound")
+			},
+		},
+	}, nil
+}
+
+// diagnoseConnectivity reports, for each endpoint, how far the provider gets: the
+// name resolution, the connection and the call, explaining the first failing step.
+func diagnoseConnectivity(ctx context.Context, checks []connectivityCheck) string {
+	reports := make([]string, 0, len(checks))
+	for _, check := range checks {
+		reports = append(reports, check.service+"\n"+strings.Join(diagnoseEndpoint(ctx, check), "\n"))
+	}
+
+	return strings.Join(reports, "\n\n")
+}
+
+func diagnoseEndpoint(ctx context.Context, check connectivityCheck) []string {
+	report := []string{"Endpoint: " + check.endpoint}
+
+	u, err := url.Parse(check.endpoint)
+	if err != nil {
+		return append(report, "Invalid endpoint: "+err.Error())
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	addrs, err := net.DefaultResolver.LookupNetIP(resolveCtx, "ip", u.Hostname())
+	cancel()
+	if err != nil {
+		return append(report, "Resolution failed: "+err.Error(),
+			"The name doesn't resolve from here. With an interface VPC endpoint and private DNS enabled, the VPC needs "+
+				"DNS resolution and DNS hostnames enabled, and resolvers outside the VPC a Route 53 Resolver inbound endpoint. "+
+				"Without private DNS, set the DNS name of the VPC endpoint in the `endpoints` of the provider.")
+	}
+
+	for i, addr := range addrs {
+		// The resolver of the system may answer IPv4 addresses mapped to IPv6
+		addrs[i] = addr.Unmap()
+	}
+	private := !slices.ContainsFunc(addrs, func(addr netip.Addr) bool {
+		return !addr.IsPrivate() && !addr.IsLoopback()
+	})
+	resolved := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		resolved = append(resolved, addr.String())
+	}
+	if private {
+		report = append(report, "Resolves to: "+strings.Join(resolved, ", ")+", private addresses, likely an interface VPC endpoint")
+	} else {
+		report = append(report, "Resolves to: "+strings.Join(resolved, ", ")+", public addresses")
+	}
+
+	dialer := net.Dialer{Timeout: connectivityTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0].String(), port))
+	if err != nil {
+		explanation := "Nothing accepts connections on port " + port + " from here, unless the provider goes through a proxy. "
+		if private {
+			explanation += "The security groups of the interface VPC endpoint must allow inbound HTTPS from the subnets, " +
+				"and the network ACLs of the subnets the return traffic."
+		} else {
+			explanation += "Reaching public endpoints from a private subnet needs a NAT gateway, or an interface VPC endpoint " +
+				"of the service with private DNS."
+		}
+		return append(report, "Connection failed: "+err.Error(), explanation)
+	}
+	_ = conn.Close()
+	report = append(report, "Connection: established")
+
+	callCtx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	err = check.call(callCtx)
+	cancel()
+
+	return append(report, explainCallError(err, private)...)
+}
+
+// explainCallError tells apart the failures of a call that reached the endpoint:
+// timeouts, rejected credentials, and denials by IAM or by the VPC endpoint policy.
+func explainCallError(err error, private bool) []string {
+	if err == nil {
+		return []string{"Call: authorized"}
+	}
+
+	var apiErr smithy.APIError
+	isAPIError := errors.As(err, &apiErr)
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout():
+		return []string{"Call timed out: " + err.Error(),
+			"The connection opened but no answer came back in time. A firewall or proxy inspecting TLS, or an endpoint " +
+				"of another service or region at this address, are the likely causes."}
+	case isAPIError && slices.Contains(authenticationErrorCodes, apiErr.ErrorCode()):
+		return []string{"Authentication failed: " + err.Error(),
+			"The endpoint answered but rejected the credentials. Check they're current, and belong to the partition and " +
+				"region of the endpoint, with `debug_credentials`."}
+	case isAPIError && isAccessDeniedError(err) && strings.Contains(apiErr.ErrorMessage(), "VPC endpoint policy"):
+		return []string{"Denied by the VPC endpoint policy: " + err.Error(),
+			"The policy of the interface VPC endpoint doesn't allow the call. It must allow the SSM and STS actions of the " +
+				"provider, for its principal and on the parameters it manages, like the default policy granting full access."}
+	case isAccessDeniedError(err) && private:
+		return []string{"Access denied: " + err.Error(),
+			"Either the IAM policies of the identity or the policy of the interface VPC endpoint deny the call. " +
+				"The message above names the policy when AWS tells it, an endpoint policy restricting principals or " +
+				"resources denies the calls outside of them."}
+	case isAccessDeniedError(err):
+		return []string{"Access denied: " + err.Error(),
+			"The IAM policies of the identity deny the call, see the message above for the action."}
+	}
+
+	return []string{"Call failed: " + err.Error()}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/smithy-go"
+)
+
+func TestDiagnoseConnectivity(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	})
+
+	// A port nothing listens on anymore
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	closed := "http://" + listener.Addr().String()
+	_ = listener.Close()
+
+	report := diagnoseConnectivity(context.Background(), []connectivityCheck{
+		{
+			service:  "SSM",
+			endpoint: server.URL,
+			call: func(ctx context.Context) error {
+				_, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(connectivityProbe)})
+				return ignoreErrorCodes(err, "ParameterNotFound")
+			},
+		},
+		{
+			service:  "STS",
+			endpoint: closed,
+			call:     func(ctx context.Context) error { return nil },
+		},
+		{
+			service:  "Unresolved",
+			endpoint: "https://fastssm.invalid",
+			call:     func(ctx context.Context) error { return nil },
+		},
+	})
+
+	for _, expected := range []string{
+		"SSM\nEndpoint: " + server.URL + "\nResolves to: 127.0.0.1, private addresses, likely an interface VPC endpoint\nConnection: established\nCall: authorized",
+		"STS\nEndpoint: " + closed + "\nResolves to: 127.0.0.1, private addresses, likely an interface VPC endpoint\nConnection failed: ",
+		"The security groups of the interface VPC endpoint must allow inbound HTTPS",
+		"Unresolved\nEndpoint: https://fastssm.invalid\nResolution failed: ",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("expected report containing %q, got:\n%s", expected, report)
+		}
+	}
+}
+
+func TestExplainCallError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Err      error
+		Private  bool
+		Expected string
+	}{
+		{
+			Name:     "authorized",
+			Expected: "Call: authorized",
+		},
+		{
+			Name: "endpoint policy",
+			Err: fmt.Errorf("permanent failure: %w", &smithy.GenericAPIError{
+				Code:    "AccessDeniedException",
+				Message: "User: arn:aws:sts::123456789012:assumed-role/ci/session is not authorized to perform: ssm:GetParameter on resource: arn:aws:ssm:eu-west-1:123456789012:parameter/app because no VPC endpoint policy allows the ssm:GetParameter action",
+			}),
+			Private:  true,
+			Expected: "Denied by the VPC endpoint policy",
+		},
+		{
+			Name:     "denied through an endpoint",
+			Err:      &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: ssm:GetParameter"},
+			Private:  true,
+			Expected: "Either the IAM policies of the identity or the policy of the interface VPC endpoint",
+		},
+		{
+			Name:     "denied by IAM",
+			Err:      &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: ssm:GetParameter"},
+			Expected: "The IAM policies of the identity deny the call",
+		},
+		{
+			Name:     "authentication",
+			Err:      &smithy.GenericAPIError{Code: "UnrecognizedClientException", Message: "The security token included in the request is invalid."},
+			Expected: "Authentication failed",
+		},
+		{
+			Name:     "timeout",
+			Err:      fmt.Errorf("operation error SSM: GetParameter: %w", context.DeadlineExceeded),
+			Expected: "Call timed out",
+		},
+		{
+			Name:     "other",
+			Err:      &smithy.GenericAPIError{Code: "InternalServerError"},
+			Expected: "Call failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := strings.Join(explainCallError(testCase.Err, testCase.Private), "\n"); !strings.Contains(got, testCase.Expected) {
+				t.Errorf("expected an explanation containing %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// expiredCredentialsErrorCodes are answered for credentials AWS no longer accepts,
+// e.g. the session of an assumed role expired before its announced expiry.
+var expiredCredentialsErrorCodes = []string{"ExpiredToken", "ExpiredTokenException", "InvalidClientTokenId"}
+
+// credentialRefresh retries an attempt rejected for expired credentials once,
+// with fresh credentials, instead of failing the resource partway through a long
+// apply. Refreshing re-assumes the roles.
+type credentialRefresh struct {
+	credentials *aws.CredentialsCache
+
+	// mu serializes the refreshes, so that the calls failing together re-assume the role once
+	mu sync.Mutex
+}
+
+func newCredentialRefresh(credentials *aws.CredentialsCache) *credentialRefresh {
+	return &credentialRefresh{credentials: credentials}
+}
+
+// credentialRefreshID identifies the refresh on a client stack.
+const credentialRefreshID = "FastSSMCredentialRefresh"
+
+// addMiddleware registers the refresh on a client stack, for use in aws.Config.APIOptions.
+// It wraps the retry loop of the SDK, whose attempts are all signed with the
+// credentials resolved once by GetIdentity.
+//
+// The refresh must be bound to the credentials the client signs with. A client
+// derived from the configuration with other credentials, like the ones of the
+// fastssm_parameter_fanout roles, adds the refresh of its own credentials to its
+// APIOptions: registered later, it replaces the one of the configuration.
+func (r *credentialRefresh) addMiddleware(stack *middleware.Stack) error {
+	if _, ok := stack.Finalize.Get("GetIdentity"); !ok {
+		return nil
+	}
+
+	if _, ok := stack.Finalize.Get(credentialRefreshID); ok {
+		if _, err := stack.Finalize.Remove(credentialRefreshID); err != nil {
+			return err
+		}
+	}
+
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc(credentialRefreshID, func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		used, err := r.credentials.Retrieve(ctx)
+		if err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+
+		// Sent from a copy, the original is kept for the retry
+		attempt := in
+		attempt.Request = req.Clone()
+		out, metadata, err := next.HandleFinalize(ctx, attempt)
+
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) || !slices.Contains(expiredCredentialsErrorCodes, apiErr.ErrorCode()) {
+			return out, metadata, err
+		}
+
+		tflog.Info(ctx, "AWS credentials rejected, refreshing them and retrying", map[string]any{
+			"operation":  middleware.GetOperationName(ctx),
+			"error_code": apiErr.ErrorCode(),
+		})
+		if err := r.refresh(ctx, used); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+
+		retry := req.Clone()
+		if err := retry.RewindStream(); err != nil {
+			return out, metadata, err
+		}
+		attempt.Request = retry
+
+		return next.HandleFinalize(ctx, attempt)
+	}), "GetIdentity", middleware.Before)
+}
+
+// refresh invalidates the credentials, unless another call refreshed them since used were retrieved.
+func (r *credentialRefresh) refresh(ctx context.Context, used aws.Credentials) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, err := r.credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	if current.AccessKeyID != used.AccessKeyID || current.SessionToken != used.SessionToken {
+		return nil
+	}
+
+	r.credentials.Invalidate()
+	_, err = r.credentials.Retrieve(ctx)
+
+	return err
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestCredentialRefresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.NewCredentialsCache(staticCredentials{accessKey: "test", secretKey: "test"}),
+	}
+	if diags := assumeRoles(ctx, &cfg, []assumeRoleModel{newAssumeRoleModel("arn:aws:iam::123456789012:role/ci", "ci", "")}, endpoints{}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	cfg.APIOptions = append(cfg.APIOptions, newCredentialRefresh(cfg.Credentials.(*aws.CredentialsCache)).addMiddleware)
+	conn := ssm.NewFromConfig(cfg)
+
+	if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/test"), Value: aws.String("test"), Type: ssm_types.ParameterTypeString}); err != nil {
+		t.Fatal(err)
+	}
+
+	server.ExpireSessions()
+
+	// The calls failing together re-assume the role once
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/test")}); err != nil {
+				t.Errorf("expected the read to succeed with refreshed credentials, got %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(server.AssumedRoles()); got != 2 {
+		t.Errorf("expected the role to be assumed twice, got %d", got)
+	}
+}
+
+// countingCredentials counts the retrievals of its static credentials.
+type countingCredentials struct {
+	staticCredentials
+	retrieved atomic.Int64
+}
+
+func (c *countingCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	c.retrieved.Add(1)
+	return c.staticCredentials.Retrieve(ctx)
+}
+
+func TestCredentialRefreshReplaced(t *testing.T) {
+	t.Parallel()
+
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("GetIdentity", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		return next.HandleFinalize(ctx, in)
+	}), middleware.After); err != nil {
+		t.Fatal(err)
+	}
+
+	// The configuration's refresh first, then the one of a client with other credentials
+	provider := &countingCredentials{staticCredentials: staticCredentials{accessKey: "provider", secretKey: "test"}}
+	role := &countingCredentials{staticCredentials: staticCredentials{accessKey: "role", secretKey: "test"}}
+	for _, credentials := range []*countingCredentials{provider, role} {
+		if err := newCredentialRefresh(aws.NewCredentialsCache(credentials)).addMiddleware(stack); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var refreshes int
+	for _, id := range stack.Finalize.List() {
+		if id == credentialRefreshID {
+			refreshes++
+		}
+	}
+	if refreshes != 1 {
+		t.Fatalf("expected a single refresh on the stack, got %d", refreshes)
+	}
+
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(func(ctx context.Context, in any) (any, middleware.Metadata, error) {
+		return nil, middleware.Metadata{}, nil
+	}), stack)
+	if _, _, err := handler.Handle(context.Background(), struct{}{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if provider.retrieved.Load() != 0 {
+		t.Errorf("expected the replaced refresh not to retrieve the credentials of the configuration")
+	}
+	if role.retrieved.Load() != 1 {
+		t.Errorf("expected the refresh to retrieve the credentials of the client once, got %d", role.retrieved.Load())
+	}
+}
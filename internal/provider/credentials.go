@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
+)
+
+// providerCredentialsSource is the source of the access_key and secret_key of the provider block.
+const providerCredentialsSource = "ProviderConfiguration"
+
+// credentialSources describes the credential providers of the SDK, by the prefix of aws.Credentials.Source.
+var credentialSources = []struct {
+	prefix      string
+	description string
+}{
+	{providerCredentialsSource, "static credentials, the `access_key` and `secret_key` of the provider block"},
+	{"EnvConfigCredentials", "static credentials, the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables"},
+	{"SharedConfigCredentials", "static credentials of the profile"},
+	{"SSOProvider", "IAM Identity Center (SSO) of the profile"},
+	{"WebIdentityCredentials", "web identity (IRSA), the token of AWS_WEB_IDENTITY_TOKEN_FILE"},
+	{"AssumeRoleProvider", "role assumed, from `assume_role` or the profile"},
+	{"EC2RoleProvider", "EC2 instance profile, from the instance metadata (IMDS)"},
+	{"CredentialsEndpointProvider", "container credentials endpoint (ECS task role or EKS Pod Identity)"},
+	{"ProcessProvider", "`credential_process` of the profile"},
+}
+
+// describeCredentials explains where creds come from and when they expire, for
+// telling apart environments that resolve different credentials. The access key
+// ID is masked, the secrets are left out.
+func describeCredentials(creds aws.Credentials, profile, callerARN string, now time.Time) string {
+	source := creds.Source
+	if source == "" {
+		source = "unknown"
+	}
+	for _, s := range credentialSources {
+		if strings.HasPrefix(creds.Source, s.prefix) {
+			source = fmt.Sprintf("%s (%s)", s.description, creds.Source)
+			break
+		}
+	}
+
+	expiry := "never, long-term credentials"
+	if creds.CanExpire {
+		expiry = fmt.Sprintf("%s, in %s", creds.Expires.UTC().Format(time.RFC3339), creds.Expires.Sub(now).Round(time.Second))
+	}
+
+	return strings.Join([]string{
+		"Source: " + source,
+		"Access key ID: " + maskAccessKeyID(creds.AccessKeyID),
+		fmt.Sprintf("Session token: %t", creds.SessionToken != ""),
+		"Expires: " + expiry,
+		"Caller identity: " + callerARN,
+		"Profile: " + profile,
+	}, "\n")
+}
+
+// maskAccessKeyID keeps the prefix telling the key type (AKIA long-term, ASIA
+// temporary) and the last characters, enough to recognize a key.
+func maskAccessKeyID(id string) string {
+	if len(id) < 12 {
+		return strings.Repeat("*", len(id))
+	}
+
+	return id[:4] + strings.Repeat("*", len(id)-8) + id[len(id)-4:]
+}
+
+// eksPodIdentityAgentHost is the address of the EKS Pod Identity agent, in the
+// AWS_CONTAINER_CREDENTIALS_FULL_URI of the pods.
+const eksPodIdentityAgentHost = "169.254.170.23"
+
+// describeCredentialsError renders the failure of the first authenticated call,
+// followed by guidance when the credentials of EKS couldn't be obtained.
+func describeCredentialsError(err error) string {
+	if guidance := credentialsGuidance(err); guidance != "" {
+		return err.Error() + "\n\n" + guidance
+	}
+
+	return err.Error()
+}
+
+// credentialsGuidance explains the failures of the EKS credential sources, the web
+// identity of IAM roles for service accounts (IRSA) and Pod Identity, and of the EC2
+// instance profile, empty otherwise.
+func credentialsGuidance(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		// The errors of AssumeRoleWithWebIdentity
+		switch apiErr.ErrorCode() {
+		case "InvalidIdentityToken":
+			return "STS rejected the web identity token. The OIDC issuer of the EKS cluster must be an IAM identity provider of the account, " +
+				"with the `sts.amazonaws.com` audience, e.g. created with `eksctl utils associate-iam-oidc-provider`."
+		case "ExpiredTokenException":
+			return "The web identity token expired. The kubelet refreshes the projected service account token of the pod, " +
+				"`web_identity_token_file` or AWS_WEB_IDENTITY_TOKEN_FILE must point to it rather than to a copy."
+		case "IDPRejectedClaim":
+			return "The identity provider rejected the claims of the web identity token, check the audience and subject of the token."
+		case "IDPCommunicationError":
+			return "STS couldn't reach the OIDC issuer of the cluster to validate the web identity token, run again."
+		case "AccessDenied":
+			if strings.Contains(apiErr.ErrorMessage(), "sts:AssumeRoleWithWebIdentity") {
+				return "The trust policy of the role doesn't allow the web identity. It must allow sts:AssumeRoleWithWebIdentity to the OIDC provider " +
+					"of the cluster, with the `sub` condition matching `system:serviceaccount:<namespace>:<service account>` of the pod."
+			}
+		}
+
+		return ""
+	}
+
+	message := err.Error()
+	if guidance := ec2MetadataGuidance(message); guidance != "" {
+		return guidance
+	}
+
+	switch {
+	case strings.Contains(message, "failed to retrieve jwt"):
+		return "The web identity token file couldn't be read. On EKS, the service account of the pod must carry the " +
+			"`eks.amazonaws.com/role-arn` annotation for the token to be mounted, at the path of AWS_WEB_IDENTITY_TOKEN_FILE."
+	case strings.Contains(message, "failed to load credentials") && strings.Contains(os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI"), eksPodIdentityAgentHost):
+		return "The EKS Pod Identity agent didn't provide credentials. The `eks-pod-identity-agent` add-on must run on the node, " +
+			"a pod identity association must exist for the namespace and service account of the pod, and the trust policy of the role " +
+			"must allow sts:AssumeRole and sts:TagSession to `pods.eks.amazonaws.com`."
+	}
+
+	return ""
+}
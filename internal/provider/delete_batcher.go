@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deleteParametersMaxNames is the maximum number of names accepted by a single DeleteParameters call.
+const deleteParametersMaxNames = 10
+
+// parameterDeleteBatcher coalesces concurrent deletions into DeleteParameters calls of
+// up to deleteParametersMaxNames names. Terraform destroys many resources at once, so
+// the teardown of a large configuration deletes its parameters 10 at a time.
+type parameterDeleteBatcher struct {
+	conn   *ssm.Client
+	window time.Duration
+
+	mu sync.Mutex
+	// pending is the batch being filled, nil when there's none
+	pending *parameterDeleteBatch
+}
+
+// parameterDeleteBatch is a single DeleteParameters call shared by its callers.
+type parameterDeleteBatch struct {
+	names []string
+
+	// done is closed once invalid and err are set
+	done    chan struct{}
+	invalid []string
+	err     error
+}
+
+func newParameterDeleteBatcher(conn *ssm.Client, window time.Duration) *parameterDeleteBatcher {
+	return &parameterDeleteBatcher{
+		conn:   conn,
+		window: window,
+	}
+}
+
+// delete deletes a parameter through the next batch. A missing parameter fails with
+// ParameterNotFound, as DeleteParameter would. Names other than plain ones, like
+// ARNs, are deleted one by one.
+func (b *parameterDeleteBatcher) delete(ctx context.Context, name string) error {
+	if strings.Contains(name, ":") {
+		return b.deleteOne(ctx, name)
+	}
+
+	batch := b.add(ctx, name)
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// A policy granting ssm:DeleteParameter alone, or not on every parameter of the
+	// batch, denies the whole call
+	if isAccessDeniedError(batch.err) {
+		return b.deleteOne(ctx, name)
+	}
+
+	if batch.err != nil {
+		return batch.err
+	}
+
+	if slices.Contains(batch.invalid, name) {
+		return &ssm_types.ParameterNotFound{
+			Message: aws.String(fmt.Sprintf("SSM Parameter %s not found", name)),
+		}
+	}
+
+	return nil
+}
+
+func (b *parameterDeleteBatcher) deleteOne(ctx context.Context, name string) error {
+	_, err := b.conn.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String(name)})
+
+	return err
+}
+
+// add queues the name on the pending batch, sending the batch once it's full.
+func (b *parameterDeleteBatcher) add(ctx context.Context, name string) *parameterDeleteBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := b.pending
+	if batch == nil {
+		batch = &parameterDeleteBatch{
+			done: make(chan struct{}),
+		}
+		b.pending = batch
+
+		// The batch outlives the deletion that opened it
+		flushCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(b.window, func() {
+			if b.detach(batch) {
+				b.flush(flushCtx, batch)
+			}
+		})
+	}
+
+	if slices.Contains(batch.names, name) {
+		return batch
+	}
+	batch.names = append(batch.names, name)
+
+	if len(batch.names) == deleteParametersMaxNames {
+		b.pending = nil
+		go b.flush(context.WithoutCancel(ctx), batch)
+	}
+
+	return batch
+}
+
+// detach removes the batch from pending, reporting false when it's already sent.
+func (b *parameterDeleteBatcher) detach(batch *parameterDeleteBatch) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending != batch {
+		return false
+	}
+	b.pending = nil
+
+	return true
+}
+
+func (b *parameterDeleteBatcher) flush(ctx context.Context, batch *parameterDeleteBatch) {
+	defer close(batch.done)
+
+	tflog.Debug(ctx, "deleting batched SSM parameters", map[string]any{"count": len(batch.names)})
+
+	output, err := b.conn.DeleteParameters(ctx, &ssm.DeleteParametersInput{
+		Names: batch.names,
+	})
+	if err != nil {
+		batch.err = err
+		return
+	}
+
+	batch.invalid = output.InvalidParameters
+}
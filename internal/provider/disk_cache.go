@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// forceRefreshEnvVar bypasses the entries of the persistent read cache when set to a true value.
+const forceRefreshEnvVar = "FASTSSM_FORCE_REFRESH"
+
+// parameterDiskCache persists parameter reads between runs, so a plan followed by
+// an apply, or a retried CI job, doesn't fetch the same parameters again. Entries
+// live in one file per read, optionally sealed with AES-GCM, in a directory per
+// parameter holding the reads of all its selectors. Without an encryption key
+// SecureString parameters are never written to disk.
+type parameterDiskCache struct {
+	dir string
+	ttl time.Duration
+	// namespace separates the entries of different accounts and regions
+	namespace string
+	// aead is nil without an encryption key
+	aead cipher.AEAD
+	// forceRefresh skips reading the entries, fresh reads are still written
+	forceRefresh bool
+
+	now func() time.Time
+}
+
+// parameterDiskCacheEntry is the content of a cache file.
+type parameterDiskCacheEntry struct {
+	Expires   time.Time            `json:"expires"`
+	Parameter *ssm_types.Parameter `json:"parameter"`
+}
+
+func newParameterDiskCache(dir string, ttl time.Duration, encryptionKey, namespace string) (*parameterDiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the read cache directory: %w", err)
+	}
+
+	c := &parameterDiskCache{
+		dir:       dir,
+		ttl:       ttl,
+		namespace: namespace,
+		now:       time.Now,
+	}
+
+	if encryptionKey != "" {
+		key := sha256.Sum256([]byte(encryptionKey))
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			return nil, err
+		}
+		c.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v, err := strconv.ParseBool(os.Getenv(forceRefreshEnvVar)); err == nil {
+		c.forceRefresh = v
+	}
+
+	return c, nil
+}
+
+// get returns the cached parameter, ok is false on a miss or an expired entry.
+func (c *parameterDiskCache) get(name string, withDecryption bool) (parameter *ssm_types.Parameter, ok bool) {
+	if c == nil || c.forceRefresh {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(c.file(name, withDecryption))
+	if err != nil {
+		return nil, false
+	}
+
+	if c.aead != nil {
+		if b, err = c.open(b); err != nil {
+			return nil, false
+		}
+	}
+
+	var entry parameterDiskCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.Parameter == nil {
+		return nil, false
+	}
+
+	if c.now().After(entry.Expires) {
+		return nil, false
+	}
+
+	return entry.Parameter, true
+}
+
+// put writes the parameter to the cache. Failures only cost a future read, they're returned for logging.
+func (c *parameterDiskCache) put(name string, withDecryption bool, parameter *ssm_types.Parameter) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.aead == nil && parameter.Type == ssm_types.ParameterTypeSecureString {
+		return nil
+	}
+
+	b, err := json.Marshal(parameterDiskCacheEntry{
+		Expires:   c.now().Add(c.ttl),
+		Parameter: parameter,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.aead != nil {
+		if b, err = c.seal(b); err != nil {
+			return err
+		}
+	}
+
+	// Written aside and renamed, concurrent runs never see a partial file
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	file := c.file(name, withDecryption)
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), file)
+}
+
+// forget removes the cached reads of a parameter after it has been written or deleted,
+// those of its `name:version` and `name:label` selectors included.
+func (c *parameterDiskCache) forget(name string) {
+	if c == nil {
+		return
+	}
+
+	// A parameter that was never cached has nothing to remove
+	_ = os.RemoveAll(c.parameterDir(name))
+}
+
+// file names the cache file of a read, without revealing the parameter name.
+func (c *parameterDiskCache) file(name string, withDecryption bool) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%t", c.namespace, name, withDecryption)))
+
+	return filepath.Join(c.parameterDir(parameterSelectorName(name)), hex.EncodeToString(sum[:]))
+}
+
+// parameterDir names the directory of the cache files of a parameter.
+func (c *parameterDiskCache) parameterDir(name string) string {
+	sum := sha256.Sum256([]byte(c.namespace + "\x00" + name))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// parameterSelectorName returns the name of the parameter a read selects, without its
+// `:version` or `:label` selector. Names don't contain colons, only ARNs, whose last
+// part always has a slash, unlike selectors.
+func parameterSelectorName(name string) string {
+	i := strings.LastIndex(name, ":")
+	if i < 0 || strings.Contains(name[i:], "/") {
+		return name
+	}
+
+	return name[:i]
+}
+
+func (c *parameterDiskCache) seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c *parameterDiskCache) open(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, errors.New("cache entry too short")
+	}
+	nonce, ciphertext := ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():]
+
+	return c.aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+func TestParameterDiskCache(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		EncryptionKey string
+		Type          ssm_types.ParameterType
+		Expected      bool
+	}{
+		{Name: "string", Type: ssm_types.ParameterTypeString, Expected: true},
+		{Name: "secure string without key", Type: ssm_types.ParameterTypeSecureString, Expected: false},
+		{Name: "secure string with key", EncryptionKey: "secret", Type: ssm_types.ParameterTypeSecureString, Expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			cache, err := newParameterDiskCache(t.TempDir(), time.Minute, testCase.EncryptionKey, "123456789012/eu-west-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			parameter := &ssm_types.Parameter{Name: aws.String("/test"), Type: testCase.Type, Value: aws.String("v"), Version: 1}
+			if err := cache.put("/test", true, parameter); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, ok := cache.get("/test", true)
+			if ok != testCase.Expected {
+				t.Fatalf("expected cached %t, got %t", testCase.Expected, ok)
+			}
+			if ok && aws.ToString(got.Value) != "v" {
+				t.Errorf("expected value v, got %s", aws.ToString(got.Value))
+			}
+
+			// Not shared with the other decryption mode
+			if _, ok := cache.get("/test", false); ok {
+				t.Errorf("unexpected cached read without decryption")
+			}
+
+			cache.forget("/test")
+			if _, ok := cache.get("/test", true); ok {
+				t.Errorf("unexpected cached read after forgetting the parameter")
+			}
+		})
+	}
+}
+
+func TestParameterDiskCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	cache, err := newParameterDiskCache(t.TempDir(), time.Minute, "", "123456789012/eu-west-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	parameter := &ssm_types.Parameter{Name: aws.String("/test"), Type: ssm_types.ParameterTypeString, Value: aws.String("v")}
+	if err := cache.put("/test", true, parameter); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := cache.get("/test", true); !ok {
+		t.Errorf("expected a cached read")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.get("/test", true); ok {
+		t.Errorf("unexpected cached read after the TTL")
+	}
+}
+
+func TestParameterDiskCacheWrongKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writer, err := newParameterDiskCache(dir, time.Minute, "secret", "123456789012/eu-west-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reader, err := newParameterDiskCache(dir, time.Minute, "other", "123456789012/eu-west-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	parameter := &ssm_types.Parameter{Name: aws.String("/test"), Type: ssm_types.ParameterTypeSecureString, Value: aws.String("v")}
+	if err := writer.put("/test", true, parameter); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := reader.get("/test", true); ok {
+		t.Errorf("unexpected cached read with another key")
+	}
+}
+
+func TestParameterDiskCacheForgetSelectors(t *testing.T) {
+	t.Parallel()
+
+	cache, err := newParameterDiskCache(t.TempDir(), time.Minute, "", "123456789012/eu-west-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := []string{"/test", "/test:1", "/test:stable", "/test/nested", "/other:stable"}
+	for _, name := range names {
+		parameter := &ssm_types.Parameter{Name: aws.String(name), Type: ssm_types.ParameterTypeString, Value: aws.String("v")}
+		if err := cache.put(name, false, parameter); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	cache.forget("/test")
+
+	for _, name := range names {
+		_, ok := cache.get(name, false)
+		if expected := name == "/test/nested" || name == "/other:stable"; ok != expected {
+			t.Errorf("expected %s cached %t, got %t", name, expected, ok)
+		}
+	}
+}
+
+func TestParameterSelectorName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{Name: "/app/image", Expected: "/app/image"},
+		{Name: "/app/image:3", Expected: "/app/image"},
+		{Name: "/app/image:stable", Expected: "/app/image"},
+		{Name: "arn:aws:ssm:eu-west-1:123456789012:parameter/app/image", Expected: "arn:aws:ssm:eu-west-1:123456789012:parameter/app/image"},
+		{Name: "arn:aws:ssm:eu-west-1:123456789012:parameter/app/image:stable", Expected: "arn:aws:ssm:eu-west-1:123456789012:parameter/app/image"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := parameterSelectorName(testCase.Name); got != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, got)
+			}
+		})
+	}
+}
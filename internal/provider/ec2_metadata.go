@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+)
+
+// ec2MetadataSettings are the `ec2_metadata_*` attributes of the provider block,
+// for the instance profile credentials and the region of EC2 instances.
+type ec2MetadataSettings struct {
+	endpoint     string
+	endpointMode imds.EndpointModeState
+	v1Disabled   bool
+}
+
+func newEC2MetadataSettings(data FastSSMProviderModel) ec2MetadataSettings {
+	settings := ec2MetadataSettings{
+		endpoint:   data.EC2MetadataEndpoint.ValueString(),
+		v1Disabled: data.EC2MetadataV1Disabled.ValueBool(),
+	}
+	// Already validated
+	_ = settings.endpointMode.SetFromString(data.EC2MetadataEndpointMode.ValueString())
+
+	return settings
+}
+
+// loadOptions returns the options of config.LoadDefaultConfig applying the settings.
+// The endpoint settings take precedence over the environment and the profile.
+func (s ec2MetadataSettings) loadOptions() []func(*config.LoadOptions) error {
+	var options []func(*config.LoadOptions) error
+
+	if s.endpoint != "" {
+		options = append(options, config.WithEC2IMDSEndpoint(s.endpoint))
+	}
+	if s.endpointMode != imds.EndpointModeStateUnset {
+		options = append(options, config.WithEC2IMDSEndpointMode(s.endpointMode))
+	}
+	if s.v1Disabled {
+		// The load options can't disable the fallback, the instance profile gets its own client
+		options = append(options, config.WithEC2RoleCredentialOptions(func(o *ec2rolecreds.Options) {
+			o.Client = imds.New(imds.Options{}, s.clientOptions)
+		}))
+	}
+
+	return options
+}
+
+// clientOptions applies the settings to the options of an IMDS client.
+func (s ec2MetadataSettings) clientOptions(o *imds.Options) {
+	if s.endpoint != "" {
+		o.Endpoint = s.endpoint
+	}
+	if s.endpointMode != imds.EndpointModeStateUnset {
+		o.EndpointMode = s.endpointMode
+	}
+	if s.v1Disabled {
+		o.EnableFallback = aws.FalseTernary
+	}
+}
+
+// ec2MetadataGuidance explains the failures of the instance metadata service seen in
+// message, the instance profile credentials or the region lookup, empty otherwise.
+func ec2MetadataGuidance(message string) string {
+	if !strings.Contains(message, "ec2imds") {
+		return ""
+	}
+
+	switch {
+	case strings.Contains(message, "getToken") && (strings.Contains(message, "deadline exceeded") || strings.Contains(message, "canceled")),
+		strings.Contains(message, "StatusCode: 401"):
+		return "The IMDSv2 token request got no answer. From a container on the instance, e.g. Docker or a Kubernetes pod without host networking, " +
+			"the response needs a hop limit of at least 2: `aws ec2 modify-instance-metadata-options --instance-id <id> --http-put-response-hop-limit 2`. " +
+			"Instances requiring IMDSv2 reject the IMDSv1 fallback with a 401."
+	case strings.Contains(message, "no EC2 IMDS role found") && strings.Contains(message, "StatusCode: 404"):
+		return "The instance has no IAM instance profile, attach one with a role to the instance, or configure other credentials."
+	case strings.Contains(message, "dial tcp") || strings.Contains(message, "connect:"):
+		return "The instance metadata service isn't reachable. Off EC2, configure other credentials. On EC2, the metadata endpoint of the instance " +
+			"may be disabled (`--http-endpoint enabled`), or the instance IPv6-only: set `ec2_metadata_service_endpoint_mode = \"IPv6\"`."
+	}
+
+	return ""
+}
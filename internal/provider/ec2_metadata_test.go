@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEC2MetadataSettings(t *testing.T) {
+	t.Parallel()
+
+	// An instance without IMDSv2, the token request is refused
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodGet && r.URL.Path == "/latest/dynamic/instance-identity/document":
+			fmt.Fprint(w, `{"region": "eu-west-3"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		Name           string
+		V1Disabled     bool
+		ExpectedRegion string
+	}{
+		{
+			Name:           "IMDSv1 fallback",
+			ExpectedRegion: "eu-west-3",
+		},
+		{
+			Name:       "IMDSv1 disabled",
+			V1Disabled: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			settings := newEC2MetadataSettings(FastSSMProviderModel{
+				EC2MetadataEndpoint:     types.StringValue(server.URL),
+				EC2MetadataEndpointMode: types.StringNull(),
+				EC2MetadataV1Disabled:   types.BoolValue(testCase.V1Disabled),
+			})
+
+			region, outcome := imdsRegion(context.Background(), aws.Config{}, settings.clientOptions)
+			if region != testCase.ExpectedRegion {
+				t.Errorf("expected region %q, got %q (%s)", testCase.ExpectedRegion, region, outcome)
+			}
+		})
+	}
+}
+
+func TestEC2MetadataSettingsEndpointMode(t *testing.T) {
+	t.Parallel()
+
+	settings := newEC2MetadataSettings(FastSSMProviderModel{
+		EC2MetadataEndpoint:     types.StringNull(),
+		EC2MetadataEndpointMode: types.StringValue("IPv6"),
+		EC2MetadataV1Disabled:   types.BoolNull(),
+	})
+
+	var options imds.Options
+	settings.clientOptions(&options)
+	if options.EndpointMode != imds.EndpointModeStateIPv6 || options.Endpoint != "" || options.EnableFallback != aws.UnknownTernary {
+		t.Errorf("expected the IPv6 endpoint mode only, got %+v", options)
+	}
+	if got := len(settings.loadOptions()); got != 1 {
+		t.Errorf("expected a single load option, got %d", got)
+	}
+}
+
+func TestEC2MetadataGuidance(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Message  string
+		Expected string
+	}{
+		{
+			Name:     "hop limit",
+			Message:  "no EC2 IMDS role found, operation error ec2imds: GetMetadata, failed to get API token, operation error ec2imds: getToken, canceled, context deadline exceeded",
+			Expected: "--http-put-response-hop-limit 2",
+		},
+		{
+			Name:     "IMDSv2 required",
+			Message:  "no EC2 IMDS role found, operation error ec2imds: GetMetadata, http response error StatusCode: 401, request to EC2 IMDS failed",
+			Expected: "--http-put-response-hop-limit 2",
+		},
+		{
+			Name:     "no instance profile",
+			Message:  "no EC2 IMDS role found, operation error ec2imds: GetMetadata, http response error StatusCode: 404, request to EC2 IMDS failed",
+			Expected: "no IAM instance profile",
+		},
+		{
+			Name:     "unreachable",
+			Message:  "no EC2 IMDS role found, operation error ec2imds: GetMetadata, exceeded maximum number of attempts, 3, request send failed, Get \"http://169.254.169.254/latest/meta-data/iam/security-credentials/\": dial tcp 169.254.169.254:80: connect: host is down",
+			Expected: "ec2_metadata_service_endpoint_mode",
+		},
+		{
+			Name:    "other",
+			Message: "failed to load credentials, dial tcp 169.254.170.2:80: connect: connection refused",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ec2MetadataGuidance(testCase.Message)
+			if testCase.Expected == "" {
+				if got != "" {
+					t.Errorf("expected no guidance, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, testCase.Expected) {
+				t.Errorf("expected guidance containing %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
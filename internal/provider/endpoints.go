@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// endpoints are the service endpoints set in the provider block, empty for those
+// left to the SDK. The SDK resolves them with the endpoint rules of the service
+// (resolution v2), from AWS_ENDPOINT_URL_<SERVICE>, AWS_ENDPOINT_URL or the
+// `endpoint_url` of the profile when set, the provider block taking precedence.
+type endpoints struct {
+	ssm string
+	sts string
+}
+
+func newEndpoints(ctx context.Context, value types.Dynamic) (endpoints, diag.Diagnostics) {
+	e, diags := parseEndpoints(value)
+	if diags.HasError() || e == (endpoints{}) {
+		return e, diags
+	}
+	tflog.Debug(ctx, "AWS endpoints overridden", map[string]any{"ssm": e.ssm, "sts": e.sts})
+
+	return e, diags
+}
+
+// parseEndpoints reads the `endpoints` attribute, either a single object, e.g.
+// `endpoints = { ssm = "..." }`, or the set of at most one object it used to be.
+func parseEndpoints(value types.Dynamic) (endpoints, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() || value.IsUnderlyingValueNull() || value.IsUnderlyingValueUnknown() {
+		return endpoints{}, diags
+	}
+
+	var elements []attr.Value
+	switch v := value.UnderlyingValue().(type) {
+	case types.Object:
+		elements = []attr.Value{v}
+	case types.Tuple:
+		elements = v.Elements()
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	default:
+		diags.AddAttributeError(path.Root("endpoints"), "Invalid endpoints", fmt.Sprintf("Expected an object with the `ssm` and `sts` endpoints, got %s.", v.Type(context.Background())))
+		return endpoints{}, diags
+	}
+
+	if len(elements) > 1 {
+		diags.AddAttributeError(path.Root("endpoints"), "Invalid endpoints", fmt.Sprintf("Expected a single object with the `ssm` and `sts` endpoints, got %d.", len(elements)))
+		return endpoints{}, diags
+	}
+
+	var e endpoints
+	for _, element := range elements {
+		object, ok := element.(types.Object)
+		if !ok {
+			diags.AddAttributeError(path.Root("endpoints"), "Invalid endpoints", fmt.Sprintf("Expected an object with the `ssm` and `sts` endpoints, got %s.", element.Type(context.Background())))
+			return endpoints{}, diags
+		}
+
+		for name, attribute := range object.Attributes() {
+			url, ok := attribute.(types.String)
+			if !ok {
+				diags.AddAttributeError(path.Root("endpoints").AtName(name), "Invalid endpoint", fmt.Sprintf("Expected a URL, got %s.", attribute.Type(context.Background())))
+				continue
+			}
+
+			switch name {
+			case "ssm":
+				e.ssm = url.ValueString()
+			case "sts":
+				e.sts = url.ValueString()
+			default:
+				diags.AddAttributeError(path.Root("endpoints").AtName(name), "Unsupported endpoint", fmt.Sprintf("Only the `ssm` and `sts` endpoints can be overridden, got %q.", name))
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return endpoints{}, diags
+	}
+
+	return e, diags
+}
+
+// endpointsValidator reports an invalid `endpoints` attribute at validation time.
+type endpointsValidator struct{}
+
+func (v endpointsValidator) Description(ctx context.Context) string {
+	return "Validates that the value is an object with the `ssm` and `sts` endpoint URLs, or a set holding one."
+}
+
+func (v endpointsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v endpointsValidator) ValidateDynamic(ctx context.Context, req validator.DynamicRequest, resp *validator.DynamicResponse) {
+	_, diags := parseEndpoints(req.ConfigValue)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (e endpoints) ssmOptions(o *ssm.Options) {
+	if e.ssm != "" {
+		o.BaseEndpoint = &e.ssm
+	}
+}
+
+func (e endpoints) stsOptions(o *sts.Options) {
+	if e.sts != "" {
+		o.BaseEndpoint = &e.sts
+	}
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEndpoints(t *testing.T) {
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	// Nothing listens there, a call reaching it fails
+	const unreachable = "http://127.0.0.1:1"
+
+	testCases := []struct {
+		Name      string
+		Env       map[string]string
+		Endpoints endpoints
+	}{
+		{
+			Name: "AWS_ENDPOINT_URL",
+			Env:  map[string]string{"AWS_ENDPOINT_URL": server.URL},
+		},
+		{
+			Name: "service specific environment variables",
+			Env:  map[string]string{"AWS_ENDPOINT_URL": unreachable, "AWS_ENDPOINT_URL_SSM": server.URL, "AWS_ENDPOINT_URL_STS": server.URL},
+		},
+		{
+			Name:      "provider block over the environment",
+			Env:       map[string]string{"AWS_ENDPOINT_URL": unreachable, "AWS_ENDPOINT_URL_SSM": unreachable},
+			Endpoints: endpoints{ssm: server.URL, sts: server.URL},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			for _, env := range []string{"AWS_ENDPOINT_URL", "AWS_ENDPOINT_URL_SSM", "AWS_ENDPOINT_URL_STS"} {
+				t.Setenv(env, testCase.Env[env])
+			}
+
+			ctx := context.Background()
+			cfg, err := config.LoadDefaultConfig(ctx,
+				config.WithRegion("eu-west-1"),
+				config.WithCredentialsProvider(staticCredentials{accessKey: "test", secretKey: "test"}),
+				config.WithRetryMaxAttempts(1),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before := server.Calls()
+
+			if _, err := sts.NewFromConfig(cfg, testCase.Endpoints.stsOptions).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+				t.Fatalf("GetCallerIdentity: %s", err)
+			}
+
+			client := newFastSSMClient(ssm.NewFromConfig(cfg, testCase.Endpoints.ssmOptions), defaultParameterBatchOptions)
+			if _, err := client.readParameter(ctx, "/missing", true); !tfresource.NotFound(err) {
+				t.Fatalf("expected the parameter not to be found, got %v", err)
+			}
+
+			var ssmCalls int64
+			for operation, n := range server.Calls() {
+				if n -= before[operation]; operation == "GetCallerIdentity" && n != 1 {
+					t.Errorf("expected GetCallerIdentity answered by the fake, got %d calls", n)
+				} else if operation != "GetCallerIdentity" {
+					ssmCalls += n
+				}
+			}
+			if ssmCalls != 1 {
+				t.Errorf("expected the parameter read answered by the fake, got %d calls", ssmCalls)
+			}
+		})
+	}
+}
+
+func TestParseEndpoints(t *testing.T) {
+	t.Parallel()
+
+	endpointTypes := map[string]attr.Type{"ssm": types.StringType, "sts": types.StringType}
+	object := types.ObjectValueMust(endpointTypes, map[string]attr.Value{
+		"ssm": types.StringValue("http://ssm.local"),
+		"sts": types.StringValue("http://sts.local"),
+	})
+	ssmOnly := types.ObjectValueMust(map[string]attr.Type{"ssm": types.StringType}, map[string]attr.Value{
+		"ssm": types.StringValue("http://ssm.local"),
+	})
+
+	testCases := []struct {
+		Name        string
+		Value       types.Dynamic
+		Expected    endpoints
+		ExpectError bool
+	}{
+		{
+			Name:  "null",
+			Value: types.DynamicNull(),
+		},
+		{
+			Name:     "object",
+			Value:    types.DynamicValue(object),
+			Expected: endpoints{ssm: "http://ssm.local", sts: "http://sts.local"},
+		},
+		{
+			Name:     "object with a single endpoint",
+			Value:    types.DynamicValue(ssmOnly),
+			Expected: endpoints{ssm: "http://ssm.local"},
+		},
+		{
+			Name:     "list of one object",
+			Value:    types.DynamicValue(types.TupleValueMust([]attr.Type{ssmOnly.Type(context.Background())}, []attr.Value{ssmOnly})),
+			Expected: endpoints{ssm: "http://ssm.local"},
+		},
+		{
+			Name:     "set of one object",
+			Value:    types.DynamicValue(types.SetValueMust(object.Type(context.Background()), []attr.Value{object})),
+			Expected: endpoints{ssm: "http://ssm.local", sts: "http://sts.local"},
+		},
+		{
+			Name:  "empty list",
+			Value: types.DynamicValue(types.TupleValueMust([]attr.Type{}, []attr.Value{})),
+		},
+		{
+			Name:        "several objects",
+			Value:       types.DynamicValue(types.ListValueMust(ssmOnly.Type(context.Background()), []attr.Value{ssmOnly, ssmOnly})),
+			ExpectError: true,
+		},
+		{
+			Name: "unsupported service",
+			Value: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"s3": types.StringType}, map[string]attr.Value{
+				"s3": types.StringValue("http://s3.local"),
+			})),
+			ExpectError: true,
+		},
+		{
+			Name: "not a URL",
+			Value: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"ssm": types.BoolType}, map[string]attr.Value{
+				"ssm": types.BoolValue(true),
+			})),
+			ExpectError: true,
+		},
+		{
+			Name:        "string",
+			Value:       types.DynamicValue(types.StringValue("http://ssm.local")),
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := parseEndpoints(testCase.Value)
+			if testCase.ExpectError {
+				if !diags.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != testCase.Expected {
+				t.Errorf("expected %+v, got %+v", testCase.Expected, got)
+			}
+		})
+	}
+}
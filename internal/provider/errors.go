@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/smithy-go"
+)
+
+// kmsResourceRegexp extracts the KMS key from an access denied message, e.g.
+// "... is not authorized to perform: kms:Decrypt on resource: arn:aws:kms:...:key/... because ..."
+var kmsResourceRegexp = regexache.MustCompile(`on resource: (arn:[^:]+:kms:\S+)`)
+
+// kmsThrottlingRegexp matches the messages of SSM errors relaying a KMS throttling,
+// e.g. "... (Service: AWSKMS; Status Code: 400; Error Code: ThrottlingException ...)".
+var kmsThrottlingRegexp = regexache.MustCompile(`(?i)kms.*(throttl|rate exceeded)|(throttl|rate exceeded).*kms`)
+
+// isKMSThrottlingError reports whether err is KMS throttling the encryption or
+// decryption of a SecureString, relayed by SSM under its own error code.
+func isKMSThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.ErrorCode() == "KMSThrottlingException" || kmsThrottlingRegexp.MatchString(apiErr.ErrorMessage())
+}
+
+// isAccessDeniedError reports whether IAM, or the policy of a shared resource, denied the call.
+func isAccessDeniedError(err error) bool {
+	var apiErr smithy.APIError
+
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDeniedException"
+}
+
+// isConnectivityError reports whether err is a failure to reach the endpoint: its name
+// doesn't resolve, the connection fails, or no answer comes back in time.
+func isConnectivityError(err error) bool {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var netErr net.Error
+
+	return errors.As(err, &dnsErr) || errors.As(err, &opErr) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// describeError renders an error for a diagnostic, followed by guidance on the
+// likely fix when the error is a well-known one.
+func describeError(err error) string {
+	if guidance := errorGuidance(err); guidance != "" {
+		return err.Error() + "\n\n" + guidance
+	}
+
+	return err.Error()
+}
+
+// errorGuidance returns targeted advice for well-known SSM and KMS failures, empty otherwise.
+func errorGuidance(err error) string {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		if isConnectivityError(err) {
+			return "The AWS endpoint couldn't be reached. Set `debug_connectivity = true` in the provider block to tell apart " +
+				"name resolution, connection and authorization failures, e.g. of interface VPC endpoints."
+		}
+		return ""
+	}
+
+	message := apiErr.ErrorMessage()
+
+	if isKMSThrottlingError(err) {
+		return "KMS throttled the encryption or decryption of the SecureString parameters. The KMS request quota is shared " +
+			"by the whole account and region, reduce the reads with `prefetch_paths` or `read_cache`, or raise the quota."
+	}
+
+	switch apiErr.ErrorCode() {
+	case "AccessDeniedException":
+		if strings.Contains(message, "VPC endpoint policy") {
+			return "The policy of the interface VPC endpoint the call went through denies it, not IAM. " +
+				"It must allow the SSM actions of the provider for its principal and on the parameters it manages."
+		}
+		if strings.Contains(message, "kms:Decrypt") {
+			if match := kmsResourceRegexp.FindStringSubmatch(message); match != nil {
+				return "The identity is missing kms:Decrypt on the key " + strings.TrimSuffix(match[1], ",") + " protecting the SecureString parameter. " +
+					"Grant it, or read the parameter with `with_decryption = false`."
+			}
+			return "The identity is missing kms:Decrypt on the key protecting the SecureString parameter. " +
+				"Grant it, or read the parameter with `with_decryption = false`."
+		}
+		if strings.Contains(message, "kms:") {
+			return "The identity is missing a KMS permission on the key of the SecureString parameter, see the message above for the action and the key."
+		}
+		return "The identity is missing an IAM permission, see the message above for the action. " +
+			"Reads by name need both ssm:GetParameter and ssm:GetParameters."
+	case "ParameterMaxVersionLimitExceeded":
+		return "The parameter already has 100 versions and the oldest one carries a label, so SSM can't drop it. " +
+			"Move the label to a newer version, e.g. with the `fastssm_parameter_label` action."
+	case "ParameterLimitExceeded":
+		return "The account reached its limit of Standard tier parameters in this region. " +
+			"Delete unused parameters, or use the Advanced tier."
+	case "HierarchyLevelLimitExceededException":
+		return "Parameter names can have at most 15 levels, check the name with `provider::fastssm::validate_name`."
+	case "ValidationException":
+		if strings.Contains(message, "name") || strings.Contains(message, "Name") {
+			return "The parameter name is invalid, check it with `provider::fastssm::validate_name`."
+		}
+	case "ParameterAlreadyExists":
+		return "The parameter exists already, import it into the state to manage it."
+	case "InvalidKeyId":
+		return "The KMS key of the parameter doesn't exist, is disabled, or isn't usable by the identity."
+	case "ParameterPatternMismatchException":
+		return "The value doesn't match the `allowed_pattern` of the parameter."
+	case "TooManyUpdates":
+		return "The parameter is being updated concurrently, run again once the other change completed."
+	case "ThrottlingException":
+		return "SSM throttled the calls. Reduce them with `prefetch_paths` or `read_cache`, or raise the SSM throughput setting of the account."
+	}
+
+	return ""
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestErrorGuidance(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Err      error
+		Expected string
+	}{
+		{
+			Name: "kms decrypt",
+			Err: &smithy.GenericAPIError{
+				Code:    "AccessDeniedException",
+				Message: "User: arn:aws:sts::123456789012:assumed-role/ci/session is not authorized to perform: kms:Decrypt on resource: arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab because no identity-based policy allows the kms:Decrypt action",
+			},
+			Expected: "missing kms:Decrypt on the key arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			Name:     "iam",
+			Err:      &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: ssm:GetParameters"},
+			Expected: "missing an IAM permission",
+		},
+		{
+			Name:     "max versions",
+			Err:      &smithy.GenericAPIError{Code: "ParameterMaxVersionLimitExceeded"},
+			Expected: "Move the label to a newer version",
+		},
+		{
+			Name:     "invalid name",
+			Err:      &smithy.GenericAPIError{Code: "ValidationException", Message: "Parameter name: can't be prefixed with \"aws\" or \"ssm\" (case-insensitive)."},
+			Expected: "validate_name",
+		},
+		{
+			Name: "wrapped",
+			Err:  fmt.Errorf("permanent failure: %w", &smithy.GenericAPIError{Code: "HierarchyLevelLimitExceededException"}),
+			// Still recognised behind the retry wrapping
+			Expected: "at most 15 levels",
+		},
+		{
+			Name:     "kms throttling",
+			Err:      &smithy.GenericAPIError{Code: "InternalServerError", Message: "Rate exceeded (Service: AWSKMS; Status Code: 400; Error Code: ThrottlingException)"},
+			Expected: "KMS request quota",
+		},
+		{
+			Name:     "other validation",
+			Err:      &smithy.GenericAPIError{Code: "ValidationException", Message: "1 validation error detected"},
+			Expected: "",
+		},
+		{
+			Name:     "vpc endpoint policy",
+			Err:      &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: ssm:GetParameter with an explicit deny in a VPC endpoint policy"},
+			Expected: "policy of the interface VPC endpoint",
+		},
+		{
+			Name:     "unresolved endpoint",
+			Err:      fmt.Errorf("operation error SSM: GetParameter: %w", &net.DNSError{Err: "no such host", Name: "ssm.eu-west-1.amazonaws.com", IsNotFound: true}),
+			Expected: "debug_connectivity",
+		},
+		{
+			Name:     "not an API error",
+			Err:      errors.New("connection reset"),
+			Expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := errorGuidance(testCase.Err)
+			if testCase.Expected == "" && got != "" {
+				t.Errorf("expected no guidance, got %q", got)
+			}
+			if !strings.Contains(got, testCase.Expected) {
+				t.Errorf("expected guidance containing %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
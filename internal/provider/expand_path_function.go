@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ExpandPathFunction{}
+
+func NewExpandPathFunction() function.Function {
+	return &ExpandPathFunction{}
+}
+
+// ExpandPathFunction joins path segments into a fully qualified parameter name.
+type ExpandPathFunction struct{}
+
+func (f *ExpandPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "expand_path"
+}
+
+func (f *ExpandPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Join path segments into a parameter name",
+		Description: "Given path segments, returns the fully qualified parameter name joining them, e.g. `/org/team/prod/db/password` for " +
+			"`\"org\", \"/team/\", \"prod\", \"db/password\"`. Segments may contain slashes themselves, the leading, trailing and repeated ones are dropped " +
+			"and empty segments are skipped, so optional parts can be passed as `\"\"`. Fails when the result doesn't satisfy the " +
+			"[AWS parameter name constraints](https://docs.aws.amazon.com/systems-manager/latest/userguide/sysman-parameter-name-constraints.html).",
+
+		VariadicParameter: function.StringParameter{
+			Name:        "segments",
+			Description: "Path segments, in order.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ExpandPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var segments []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &segments))
+	if resp.Error != nil {
+		return
+	}
+
+	name, err := expandPath(segments)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
+
+// expandPath joins the non-empty parts of segments with single slashes, behind a
+// leading one, and validates the result.
+func expandPath(segments []string) (string, error) {
+	var b strings.Builder
+	for _, segment := range segments {
+		for _, part := range strings.Split(segment, "/") {
+			if part != "" {
+				b.WriteString("/" + part)
+			}
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", fmt.Errorf("at least one non-empty path segment is required")
+	}
+
+	name := b.String()
+	if err := validateParameterName(name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
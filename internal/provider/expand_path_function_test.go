@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandPathFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Segments    []string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "single segment",
+			Segments: []string{"app"},
+			Expected: "/app",
+		},
+		{
+			Name:     "segments",
+			Segments: []string{"org", "team", "prod", "db_password"},
+			Expected: "/org/team/prod/db_password",
+		},
+		{
+			Name:     "slashes",
+			Segments: []string{"/org/", "//team", "prod/db/", "password"},
+			Expected: "/org/team/prod/db/password",
+		},
+		{
+			Name:     "empty segment skipped",
+			Segments: []string{"org", "", "prod"},
+			Expected: "/org/prod",
+		},
+		{
+			Name:        "no segments",
+			ExpectError: true,
+		},
+		{
+			Name:        "only slashes",
+			Segments:    []string{"/", ""},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid characters",
+			Segments:    []string{"app", "db password"},
+			ExpectError: true,
+		},
+		{
+			Name:        "reserved prefix",
+			Segments:    []string{"aws", "app"},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			elementTypes := make([]attr.Type, len(testCase.Segments))
+			elements := make([]attr.Value, len(testCase.Segments))
+			for i, segment := range testCase.Segments {
+				elementTypes[i] = types.StringType
+				elements[i] = types.StringValue(segment)
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.TupleValueMust(elementTypes, elements)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewExpandPathFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(testCase.Expected)) {
+				t.Errorf("got %s, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &HashFunction{}
+
+func NewHashFunction() function.Function {
+	return &HashFunction{}
+}
+
+// HashFunction fingerprints a secret value, e.g. to detect its rotation, without revealing it.
+type HashFunction struct{}
+
+func (f *HashFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hash"
+}
+
+func (f *HashFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Fingerprint a secret value with a salted hash",
+		Description: "Given a value and a salt, returns the hex encoded HMAC-SHA256 of the value keyed by the salt. " +
+			"The same value and salt always give the same fingerprint, so it can be compared or output to detect a rotation, " +
+			"while the salt prevents guessing short or common values from the fingerprint. " +
+			"Terraform keeps the result of a sensitive value sensitive, wrap it in `nonsensitive()` to output it.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "Value to fingerprint, e.g. the value of a `SecureString` parameter.",
+			},
+			function.StringParameter{
+				Name:        "salt",
+				Description: "Secret salt of the fingerprint, kept constant for the fingerprints to stay comparable.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *HashFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value, salt string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &salt))
+	if resp.Error != nil {
+		return
+	}
+
+	if salt == "" {
+		resp.Error = function.NewArgumentFuncError(1, "salt must not be empty, an unsalted hash of a short value can be reversed by brute force")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, saltedHash(value, salt)))
+}
+
+func saltedHash(value, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
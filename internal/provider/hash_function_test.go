@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHashFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Value       string
+		Salt        string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "value",
+			Value:    "hunter2",
+			Salt:     "pepper",
+			Expected: "e075c939d4acb1f39c7839b235d928a7bff10dbef921146af19ca9832808bbfc",
+		},
+		{
+			Name:     "other salt",
+			Value:    "hunter2",
+			Salt:     "salt",
+			Expected: "d03a122c2d18fa87be323b02ba3ae0cc342bc6a1cc8d98409495ef5629755f45",
+		},
+		{
+			Name:     "empty value",
+			Value:    "",
+			Salt:     "pepper",
+			Expected: "83be6d3a18e430703da8ce9e3f44ed8b20f835c7535e1343b191332cbaef9192",
+		},
+		{
+			Name:        "empty salt",
+			Value:       "hunter2",
+			Salt:        "",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.Value), types.StringValue(testCase.Salt)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewHashFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.StringValue(testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const highThroughputSettingID = "/ssm/parameter-store/high-throughput-enabled"
+
+// Read throughput of Parameter Store per account and region, in transactions per second,
+// by default and with the high throughput setting enabled.
+const (
+	standardThroughputTPS = 40
+	highThroughputTPS     = 10000
+)
+
+// highThroughputCheck warns when the planned writes of the fastssm_parameter resources
+// of a provider configuration reach `high_throughput_warning_threshold` while the
+// account runs Parameter Store at its default throughput. The setting is read once,
+// when the threshold is reached, and the warning is reported once per run.
+type highThroughputCheck struct {
+	threshold int64
+	planned   atomic.Int64
+}
+
+func newHighThroughputCheck(threshold int64) *highThroughputCheck {
+	return &highThroughputCheck{threshold: threshold}
+}
+
+// plan counts a planned write, checking the setting when it reaches the threshold.
+func (c *highThroughputCheck) plan(ctx context.Context, conn *ssm.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c.planned.Add(1) != c.threshold {
+		return diags
+	}
+
+	output, err := conn.GetServiceSetting(ctx, &ssm.GetServiceSettingInput{SettingId: aws.String(highThroughputSettingID)})
+	if err != nil {
+		diags.AddWarning(
+			"High throughput check failed",
+			fmt.Sprintf("At least %d parameters are planned to be written, reading the %s service setting failed: %s\n\n"+
+				"Grant ssm:GetServiceSetting to the identity of the provider, or unset high_throughput_warning_threshold.", c.threshold, highThroughputSettingID, describeError(err)),
+		)
+		return diags
+	}
+
+	if output.ServiceSetting != nil && aws.ToString(output.ServiceSetting.SettingValue) == "true" {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Parameter Store high throughput is disabled",
+		highThroughputWarning(c.threshold),
+	)
+
+	return diags
+}
+
+// highThroughputWarning explains the throughput impact of writing planned parameters
+// at the default throughput. Every write is read back, so a run makes at least as
+// many reads, on top of the refresh.
+func highThroughputWarning(planned int64) string {
+	standard := time.Duration(math.Ceil(float64(planned)/standardThroughputTPS)) * time.Second
+
+	return fmt.Sprintf("At least %[1]d parameters are planned to be written while %[2]s is false in this account and region. "+
+		"Every write is read back, and Parameter Store serves %[3]d read transactions per second by default, against %[4]d with high throughput: "+
+		"the reads of this run alone take at least %[5]s of the account's read throughput, throttling this run into retries "+
+		"and every other reader of the account meanwhile.\n\n"+
+		"Enable high throughput, e.g. with the fastssm_service_setting resource, to raise the limit. It's charged per API interaction.",
+		planned, highThroughputSettingID, standardThroughputTPS, highThroughputTPS, standard)
+}
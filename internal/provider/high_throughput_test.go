@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func TestHighThroughputCheck(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Enabled  bool
+		Planned  int
+		Warnings int
+	}{
+		{
+			Name:    "below the threshold",
+			Planned: 2,
+		},
+		{
+			Name:     "threshold reached",
+			Planned:  3,
+			Warnings: 1,
+		},
+		{
+			Name:     "warned once",
+			Planned:  10,
+			Warnings: 1,
+		},
+		{
+			Name:    "enabled",
+			Enabled: true,
+			Planned: 10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			server := fakessm.NewServer()
+			defer server.Close()
+
+			conn := ssm.NewFromConfig(aws.Config{
+				Region:       "eu-west-1",
+				BaseEndpoint: aws.String(server.URL),
+				Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+			})
+			if testCase.Enabled {
+				_, err := conn.UpdateServiceSetting(ctx, &ssm.UpdateServiceSettingInput{SettingId: aws.String(highThroughputSettingID), SettingValue: aws.String("true")})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			check := newHighThroughputCheck(3)
+			warnings := 0
+			for range testCase.Planned {
+				diags := check.plan(ctx, conn)
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				warnings += diags.WarningsCount()
+			}
+
+			if warnings != testCase.Warnings {
+				t.Errorf("expected %d warnings, got %d", testCase.Warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestHighThroughputWarning(t *testing.T) {
+	t.Parallel()
+
+	warning := highThroughputWarning(1000)
+	for _, expected := range []string{"At least 1000 parameters", "40 read transactions per second", "against 10000", "at least 25s"} {
+		if !strings.Contains(warning, expected) {
+			t.Errorf("expected a warning containing %q, got %q", expected, warning)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"crypto/x509"
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+)
+
+const (
+	// httpMaxIdleConns bounds the idle connections kept by a shared transport, for every endpoint
+	httpMaxIdleConns = 256
+	// httpMaxIdleConnsPerHost keeps a connection per concurrent call of a large apply to
+	// an SSM endpoint, the SDK defaults to 10 and closes the rest once they return
+	httpMaxIdleConnsPerHost = 64
+)
+
+// sharedHTTPClient is an HTTP client built once and reused by every AWS client of the
+// provider trusting the same root certificates.
+type sharedHTTPClient struct {
+	roots  *x509.CertPool
+	client aws.HTTPClient
+}
+
+var (
+	sharedHTTPClientsMu sync.Mutex
+	sharedHTTPClients   []sharedHTTPClient
+)
+
+// sharedHTTPClientFor returns the HTTP client to use instead of the one resolved by
+// LoadDefaultConfig. The SDK copies its buildable clients, and their transport, for
+// every SSM and STS client, so each alias, region and assumed role would open and
+// handshake its own connections to the same endpoints. The returned client is frozen,
+// the clients share the pool of connections of a single tuned transport. A custom CA
+// bundle, from `AWS_CA_BUNDLE` or the profile, gets a transport of its own.
+func sharedHTTPClientFor(resolved aws.HTTPClient) aws.HTTPClient {
+	buildable, ok := resolved.(*awshttp.BuildableClient)
+	if !ok {
+		return resolved
+	}
+
+	var roots *x509.CertPool
+	if tlsConfig := buildable.GetTransport().TLSClientConfig; tlsConfig != nil {
+		roots = tlsConfig.RootCAs
+	}
+
+	sharedHTTPClientsMu.Lock()
+	defer sharedHTTPClientsMu.Unlock()
+
+	for _, shared := range sharedHTTPClients {
+		if shared.roots.Equal(roots) {
+			return shared.client
+		}
+	}
+
+	client := buildable.WithTransportOptions(func(tr *http.Transport) {
+		tr.MaxIdleConns = httpMaxIdleConns
+		tr.MaxIdleConnsPerHost = httpMaxIdleConnsPerHost
+	}).Freeze()
+	sharedHTTPClients = append(sharedHTTPClients, sharedHTTPClient{roots: roots, client: client})
+
+	return client
+}
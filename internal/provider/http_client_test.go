@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func TestSharedHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = io.WriteString(w, `{"Parameter":{"Name":"/test","Type":"String","Value":"v","Version":1}}`)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	ctx := context.Background()
+	// Each provider alias loads its own configuration
+	for _, region := range []string{"eu-west-1", "eu-central-1", "us-east-1"} {
+		cfg, err := config.LoadDefaultConfig(ctx,
+			config.WithRegion(region),
+			config.WithCredentialsProvider(staticCredentials{accessKey: "test", secretKey: "test"}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.HTTPClient = sharedHTTPClientFor(cfg.HTTPClient)
+
+		conn := ssm.NewFromConfig(cfg, func(o *ssm.Options) { o.BaseEndpoint = aws.String(server.URL) })
+		if _, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/test")}); err != nil {
+			t.Fatalf("%s: %s", region, err)
+		}
+	}
+
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected the aliases to share a single connection, got %d", got)
+	}
+}
+
+func TestSharedHTTPClientCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	httpClient := func() aws.HTTPClient {
+		cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion("eu-west-1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return sharedHTTPClientFor(cfg.HTTPClient)
+	}
+
+	t.Setenv("AWS_CA_BUNDLE", "")
+	system := httpClient()
+
+	t.Setenv("AWS_CA_BUNDLE", bundle)
+	custom := httpClient()
+	if custom == system {
+		t.Error("expected a custom CA bundle to get its own HTTP client")
+	}
+	if again := httpClient(); again != custom {
+		t.Error("expected the same CA bundle to share the HTTP client")
+	}
+
+	// The shared client trusts the bundle
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := custom.Do(req)
+	if err != nil {
+		t.Fatalf("expected the server certificate to be trusted, got %s", err)
+	}
+	res.Body.Close()
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JoinStringListFunction{}
+
+func NewJoinStringListFunction() function.Function {
+	return &JoinStringListFunction{}
+}
+
+// JoinStringListFunction turns a list of strings into the value of a StringList parameter.
+type JoinStringListFunction struct{}
+
+func (f *JoinStringListFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "join_stringlist"
+}
+
+func (f *JoinStringListFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Join a list into a StringList parameter value",
+		Description: "Given a list of strings, returns them separated by commas as the value of a `StringList` SSM parameter, " +
+			"the reverse of `split_stringlist`. Fails when an item contains a comma, which would split it in two, or when the list is empty, as SSM rejects empty values.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "items",
+				ElementType: types.StringType,
+				Description: "Items of the `StringList` parameter.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JoinStringListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var items []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &items))
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := joinStringList(items)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+// joinStringList joins on the StringList separator, which SSM doesn't allow to escape.
+func joinStringList(items []string) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("a StringList parameter needs at least one item")
+	}
+
+	for i, item := range items {
+		if strings.Contains(item, ",") {
+			return "", fmt.Errorf("item %d %q contains a comma, the separator of StringList items", i, item)
+		}
+	}
+
+	return strings.Join(items, ","), nil
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJoinStringListFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Items       []attr.Value
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "single item",
+			Items:    []attr.Value{types.StringValue("one")},
+			Expected: "one",
+		},
+		{
+			Name:     "several items",
+			Items:    []attr.Value{types.StringValue("one"), types.StringValue("two"), types.StringValue("three")},
+			Expected: "one,two,three",
+		},
+		{
+			Name:     "empty items are kept",
+			Items:    []attr.Value{types.StringValue("one"), types.StringValue(""), types.StringValue("three")},
+			Expected: "one,,three",
+		},
+		{
+			Name:        "empty list",
+			Items:       []attr.Value{},
+			ExpectError: true,
+		},
+		{
+			Name:        "item with a comma",
+			Items:       []attr.Value{types.StringValue("one"), types.StringValue("two,three")},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, testCase.Items)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewJoinStringListFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.StringValue(testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"terraform-provider-fastssm/internal/retry"
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// decodeJSONDocument decodes a JSON value, numbers as json.Number so they are
+// written back as they were.
+func decodeJSONDocument(value string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %s", err)
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+
+	return document, nil
+}
+
+// encodeJSONDocument encodes a document with sorted keys, leaving HTML characters unescaped.
+func encodeJSONDocument(document any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// decodeJSONMergePatch decodes a merge patch, which must be an object: any other
+// value would replace the whole document, the keys of the other writers included.
+func decodeJSONMergePatch(value string) (map[string]any, error) {
+	document, err := decodeJSONDocument(value)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, ok := document.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("a JSON merge patch must be an object")
+	}
+
+	return patch, nil
+}
+
+// jsonMergePatch applies patch to target as described by RFC 7386: the keys of the
+// patch replace those of the target, nested objects are merged, and null removes a key.
+// A target that isn't an object is replaced by one.
+func jsonMergePatch(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	merged := make(map[string]any, len(targetObject)+len(patchObject))
+	for key, value := range targetObject {
+		merged[key] = value
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = jsonMergePatch(merged[key], value)
+	}
+
+	return merged
+}
+
+// jsonMergePatchRemove removes from document every key patch sets. The objects patch
+// merges into are kept for the keys of the other writers, and removed once left empty.
+func jsonMergePatchRemove(document any, patch map[string]any) any {
+	object, ok := document.(map[string]any)
+	if !ok {
+		return document
+	}
+
+	remaining := make(map[string]any, len(object))
+	for key, value := range object {
+		remaining[key] = value
+	}
+
+	for key, value := range patch {
+		nested, isObject := value.(map[string]any)
+		current, isCurrentObject := remaining[key].(map[string]any)
+		if isObject && isCurrentObject {
+			if left := jsonMergePatchRemove(current, nested).(map[string]any); len(left) > 0 {
+				remaining[key] = left
+				continue
+			}
+		}
+		delete(remaining, key)
+	}
+
+	return remaining
+}
+
+// jsonMergePatchProjection is the part of document covered by patch, shaped like the
+// patch: equal to it when applying the patch wouldn't change the document, missing
+// keys becoming null.
+func jsonMergePatchProjection(document any, patch map[string]any) map[string]any {
+	object, _ := document.(map[string]any)
+
+	projection := make(map[string]any, len(patch))
+	for key, value := range patch {
+		current, found := object[key]
+		nested, ok := value.(map[string]any)
+		if _, isObject := current.(map[string]any); ok && found && isObject {
+			projection[key] = jsonMergePatchProjection(current, nested)
+			continue
+		}
+		projection[key] = current
+	}
+
+	return projection
+}
+
+// jsonMergePatchApplied reports whether applying patch leaves document unchanged.
+func jsonMergePatchApplied(document any, patch map[string]any) bool {
+	return reflect.DeepEqual(jsonMergePatchProjection(document, patch), patch)
+}
+
+// mergedJSONDocument reads the document stored in the parameter name, removes the keys
+// of the removed patch, applies patch and returns the resulting document, along with
+// the parameter read, nil when it doesn't exist yet. Null patches are skipped.
+func mergedJSONDocument(ctx context.Context, conn *ssm.Client, name string, removed, patch types.String) (string, *ssm_types.Parameter, error) {
+	current, err := findParameterByName(ctx, conn, name, true)
+	if tfresource.NotFound(err) {
+		current, err = nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("reading the current document of SSM Parameter %s: %s", name, describeError(err))
+	}
+
+	var document any = map[string]any{}
+	if current != nil {
+		if document, err = decodeJSONDocument(aws.ToString(current.Value)); err != nil {
+			return "", nil, fmt.Errorf("the current value of SSM Parameter %s can't be patched: %s", name, err)
+		}
+	}
+
+	if !removed.IsNull() {
+		// A patch of the state is always valid, it was validated or refreshed
+		removal, err := decodeJSONMergePatch(removed.ValueString())
+		if err != nil {
+			return "", nil, err
+		}
+		document = jsonMergePatchRemove(document, removal)
+	}
+
+	if !patch.IsNull() {
+		object, err := decodeJSONMergePatch(patch.ValueString())
+		if err != nil {
+			return "", nil, err
+		}
+		document = jsonMergePatch(document, object)
+	}
+
+	merged, err := encodeJSONDocument(document)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return merged, current, nil
+}
+
+// refreshJSONMergePatch returns the patch of the state as it is, unless applying it
+// would change the document stored now. The part of the document it covers is returned
+// then, showing the changes made outside Terraform in the plan.
+func refreshJSONMergePatch(prior types.String, value string) types.String {
+	patch, err := decodeJSONMergePatch(prior.ValueString())
+	if err != nil {
+		return prior
+	}
+
+	// A value that isn't JSON anymore has none of the keys
+	document, err := decodeJSONDocument(value)
+	if err != nil {
+		document = nil
+	}
+
+	if jsonMergePatchApplied(document, patch) {
+		return prior
+	}
+
+	projection, err := encodeJSONDocument(jsonMergePatchProjection(document, patch))
+	if err != nil {
+		return prior
+	}
+
+	return types.StringValue(projection)
+}
+
+// removeJSONMergePatch removes the keys of the patch of a destroyed resource from the
+// document, and reports whether the document is left empty, to be deleted.
+func (r *ParameterResource) removeJSONMergePatch(ctx context.Context, data ParameterResourceModel, resp *resource.DeleteResponse) bool {
+	name := r.client.parameterName(data.Name.ValueString())
+
+	remaining, current, err := mergedJSONDocument(ctx, r.client.Client, name, data.ValueJSONMergePatch, types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove the JSON merge patch from ssm parameter, got error: %s", err))
+		return false
+	}
+
+	if current == nil {
+		// Already deleted
+		return false
+	}
+
+	if remaining == "{}" {
+		return true
+	}
+
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(remaining),
+		Type:      current.Type,
+		DataType:  current.DataType,
+		Overwrite: aws.Bool(true),
+	}
+
+	var result = &ssm.PutParameterOutput{}
+	var erri error
+	err = retry.RetryContext(ctx, r.client.timeouts.putParameter, func() *retry.RetryError {
+		result, erri = r.client.PutParameter(ctx, input)
+		if erri != nil {
+			if r.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	r.client.forgetParameter(name)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove the JSON merge patch from ssm parameter, got error: %s", describeError(err)))
+		return false
+	}
+
+	resp.Diagnostics.Append(r.client.audit.putParameter(r.client.Options().Region, name, result.Version)...)
+
+	return false
+}
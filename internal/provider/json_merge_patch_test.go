@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONMergePatch(t *testing.T) {
+	t.Parallel()
+
+	// The examples of RFC 7386, with objects as patches
+	testCases := []struct {
+		Name     string
+		Target   string
+		Patch    string
+		Expected string
+	}{
+		{Name: "replace", Target: `{"a":"b"}`, Patch: `{"a":"c"}`, Expected: `{"a":"c"}`},
+		{Name: "add", Target: `{"a":"b"}`, Patch: `{"b":"c"}`, Expected: `{"a":"b","b":"c"}`},
+		{Name: "remove", Target: `{"a":"b"}`, Patch: `{"a":null}`, Expected: `{}`},
+		{Name: "remove one of two", Target: `{"a":"b","b":"c"}`, Patch: `{"a":null}`, Expected: `{"b":"c"}`},
+		{Name: "array replaced", Target: `{"a":["b"]}`, Patch: `{"a":"c"}`, Expected: `{"a":"c"}`},
+		{Name: "scalar replaced by array", Target: `{"a":"c"}`, Patch: `{"a":["b"]}`, Expected: `{"a":["b"]}`},
+		{Name: "nested", Target: `{"a":{"b":"c"}}`, Patch: `{"a":{"b":"d","c":null}}`, Expected: `{"a":{"b":"d"}}`},
+		{Name: "arrays not merged", Target: `{"a":[{"b":"c"}]}`, Patch: `{"a":[1]}`, Expected: `{"a":[1]}`},
+		{Name: "target not an object", Target: `["a","b"]`, Patch: `{"a":"b"}`, Expected: `{"a":"b"}`},
+		{Name: "null target", Target: `null`, Patch: `{"a":"foo"}`, Expected: `{"a":"foo"}`},
+		{Name: "nested nulls removed", Target: `{}`, Patch: `{"a":{"bb":{"ccc":null}}}`, Expected: `{"a":{"bb":{}}}`},
+		{Name: "numbers kept", Target: `{"a":1.50,"b":12345678901234567890}`, Patch: `{"c":1}`, Expected: `{"a":1.50,"b":12345678901234567890,"c":1}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			target, err := decodeJSONDocument(testCase.Target)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			patch, err := decodeJSONMergePatch(testCase.Patch)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := encodeJSONDocument(jsonMergePatch(target, patch))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONMergePatch(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{`"a"`, `["a"]`, `null`, `{"a":`, `{} {}`} {
+		if _, err := decodeJSONMergePatch(value); err == nil {
+			t.Errorf("expected an error decoding %s", value)
+		}
+	}
+}
+
+func TestRefreshJSONMergePatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Patch    string
+		Value    string
+		Expected string
+	}{
+		{
+			Name:     "applied",
+			Patch:    `{ "a": 1, "b": { "c": "d" }, "e": null }`,
+			Value:    `{"a":1,"b":{"c":"d","x":true},"y":"z"}`,
+			Expected: `{ "a": 1, "b": { "c": "d" }, "e": null }`,
+		},
+		{
+			Name:     "changed",
+			Patch:    `{"a":1,"b":{"c":"d"}}`,
+			Value:    `{"a":2,"b":{"c":"d","x":true}}`,
+			Expected: `{"a":2,"b":{"c":"d"}}`,
+		},
+		{
+			Name:     "removed key",
+			Patch:    `{"a":1,"b":{"c":"d"}}`,
+			Value:    `{"a":1,"b":{"x":true}}`,
+			Expected: `{"a":1,"b":{"c":null}}`,
+		},
+		{
+			Name:     "added key",
+			Patch:    `{"a":1,"e":null}`,
+			Value:    `{"a":1,"e":"f"}`,
+			Expected: `{"a":1,"e":"f"}`,
+		},
+		{
+			Name:     "object replaced",
+			Patch:    `{"b":{"c":"d"}}`,
+			Value:    `{"b":"c"}`,
+			Expected: `{"b":"c"}`,
+		},
+		{
+			Name:     "not JSON",
+			Patch:    `{"a":1}`,
+			Value:    `a=1`,
+			Expected: `{"a":null}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := refreshJSONMergePatch(types.StringValue(testCase.Patch), testCase.Value)
+
+			if got.ValueString() != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, got.ValueString())
+			}
+		})
+	}
+}
+
+func TestMergedJSONDocument(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	})
+
+	// Missing parameters are created from the patch
+	merged, current, err := mergedJSONDocument(ctx, conn, "/app/config", types.StringNull(), types.StringValue(`{"db":{"host":"db.example.com"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if current != nil {
+		t.Errorf("expected no current parameter, got %v", current)
+	}
+	if expected := `{"db":{"host":"db.example.com"}}`; merged != expected {
+		t.Errorf("expected %s, got %s", expected, merged)
+	}
+
+	_, err = conn.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:  aws.String("/app/config"),
+		Value: aws.String(`{"cache":{"ttl":60},"db":{"host":"db.example.com","port":5432}}`),
+		Type:  ssm_types.ParameterTypeString,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The keys dropped from the patch are removed, the keys of other writers stay
+	merged, current, err = mergedJSONDocument(ctx, conn, "/app/config", types.StringValue(`{"db":{"host":"db.example.com","port":5432}}`), types.StringValue(`{"db":{"host":"db2.example.com"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if current == nil {
+		t.Fatalf("expected the current parameter")
+	}
+	if expected := `{"cache":{"ttl":60},"db":{"host":"db2.example.com"}}`; merged != expected {
+		t.Errorf("expected %s, got %s", expected, merged)
+	}
+
+	// Objects left empty are removed along
+	merged, _, err = mergedJSONDocument(ctx, conn, "/app/config", types.StringValue(`{"db":{"host":"db.example.com","port":5432}}`), types.StringNull())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := `{"cache":{"ttl":60}}`; merged != expected {
+		t.Errorf("expected %s, got %s", expected, merged)
+	}
+
+	merged, _, err = mergedJSONDocument(ctx, conn, "/app/config", types.StringValue(`{"cache":{"ttl":60},"db":{"host":"db.example.com","port":5432}}`), types.StringNull())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := `{}`; merged != expected {
+		t.Errorf("expected %s, got %s", expected, merged)
+	}
+
+	_, err = conn.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:  aws.String("/app/plain"),
+		Value: aws.String("not json"),
+		Type:  ssm_types.ParameterTypeString,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, _, err := mergedJSONDocument(ctx, conn, "/app/plain", types.StringNull(), types.StringValue(`{"a":1}`)); err == nil {
+		t.Errorf("expected an error patching a value that isn't JSON")
+	}
+}
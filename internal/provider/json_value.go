@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jsonDynamicValue parses a JSON value the way jsondecode does, objects becoming
+// objects and arrays tuples, null when the value isn't valid JSON. Numbers keep
+// the 512 bits precision of Terraform.
+func jsonDynamicValue(ctx context.Context, value string) types.Dynamic {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return types.DynamicNull()
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return types.DynamicNull()
+	}
+
+	converted, ok := jsonAttrValue(ctx, document)
+	if !ok || document == nil {
+		return types.DynamicNull()
+	}
+
+	return types.DynamicValue(converted)
+}
+
+func jsonAttrValue(ctx context.Context, document any) (attr.Value, bool) {
+	switch v := document.(type) {
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, element := range v {
+			converted, ok := jsonAttrValue(ctx, element)
+			if !ok {
+				return nil, false
+			}
+			attrTypes[key] = converted.Type(ctx)
+			attrs[key] = converted
+		}
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		return object, !diags.HasError()
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, element := range v {
+			converted, ok := jsonAttrValue(ctx, element)
+			if !ok {
+				return nil, false
+			}
+			elemTypes = append(elemTypes, converted.Type(ctx))
+			elems = append(elems, converted)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		return tuple, !diags.HasError()
+	case string:
+		return types.StringValue(v), true
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, false
+		}
+		return types.NumberValue(number), true
+	case bool:
+		return types.BoolValue(v), true
+	case nil:
+		// Typed, as the state can't hold a null of unknown type within an object
+		return types.StringNull(), true
+	}
+
+	return nil, false
+}
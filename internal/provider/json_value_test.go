@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONDynamicValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Input    string
+		Expected types.Dynamic
+	}{
+		{
+			Name:  "object",
+			Input: `{"replicas":2,"debug":false,"owner":null}`,
+			Expected: types.DynamicValue(types.ObjectValueMust(
+				map[string]attr.Type{"replicas": types.NumberType, "debug": types.BoolType, "owner": types.StringType},
+				map[string]attr.Value{"replicas": types.NumberValue(big.NewFloat(2)), "debug": types.BoolValue(false), "owner": types.StringNull()},
+			)),
+		},
+		{
+			Name:  "tuple",
+			Input: `["a", 1]`,
+			Expected: types.DynamicValue(types.TupleValueMust(
+				[]attr.Type{types.StringType, types.NumberType},
+				[]attr.Value{types.StringValue("a"), types.NumberValue(big.NewFloat(1))},
+			)),
+		},
+		{Name: "string", Input: `"plain"`, Expected: types.DynamicValue(types.StringValue("plain"))},
+		{Name: "null", Input: `null`, Expected: types.DynamicNull()},
+		{Name: "not JSON", Input: `not json`, Expected: types.DynamicNull()},
+		{Name: "trailing data", Input: `{} {}`, Expected: types.DynamicNull()},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := jsonDynamicValue(context.Background(), testCase.Input)
+			if !got.Equal(testCase.Expected) {
+				t.Errorf("expected %s, got %s", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// localstackEndpointEnvVar overrides the endpoint of `use_localstack`, and points
+	// the acceptance tests at a LocalStack already running
+	localstackEndpointEnvVar = "LOCALSTACK_ENDPOINT"
+	// localstackDefaultEndpoint is where LocalStack listens by default
+	localstackDefaultEndpoint = "http://localhost:4566"
+	// localstackDefaultRegion is the region of LocalStack when none is set
+	localstackDefaultRegion = "us-east-1"
+	// localstackAccountID is the account LocalStack runs everything in by default
+	localstackAccountID = "000000000000"
+)
+
+// applyLocalStack completes the configuration of a provider with `use_localstack`:
+// dummy static credentials and a region unless set, and returns the endpoint of
+// LocalStack, from LOCALSTACK_ENDPOINT or the default one.
+func applyLocalStack(data *FastSSMProviderModel) string {
+	// LocalStack accepts any credentials
+	if data.AccessKey.IsNull() && data.SecretKey.IsNull() {
+		data.AccessKey = types.StringValue("test")
+		data.SecretKey = types.StringValue("test")
+	}
+
+	if region, _ := explicitRegion(data.Region); region == "" {
+		data.Region = types.StringValue(localstackDefaultRegion)
+	}
+
+	if endpoint := os.Getenv(localstackEndpointEnvVar); endpoint != "" {
+		return endpoint
+	}
+
+	return localstackDefaultEndpoint
+}
+
+// withLocalStack points the endpoints left unset in the provider block at LocalStack.
+func (e endpoints) withLocalStack(endpoint string) endpoints {
+	if e.ssm == "" {
+		e.ssm = endpoint
+	}
+	if e.sts == "" {
+		e.sts = endpoint
+	}
+
+	return e
+}
+
+// localstackCallerIdentity stands for GetCallerIdentity, which isn't called against
+// LocalStack: it accepts any credentials, there's nothing to validate.
+func localstackCallerIdentity() *sts.GetCallerIdentityOutput {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(localstackAccountID),
+		Arn:     aws.String("arn:aws:iam::" + localstackAccountID + ":root"),
+		UserId:  aws.String(localstackAccountID),
+	}
+}
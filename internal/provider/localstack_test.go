@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/localstack"
+)
+
+// localstackImage is the LocalStack release the acceptance tests run against
+const localstackImage = "localstack/localstack:4.4"
+
+// startLocalStack runs a LocalStack container limited to SSM and STS, returning
+// its endpoint and a function removing it.
+func startLocalStack(ctx context.Context) (endpoint string, stop func(), err error) {
+	container, err := localstack.Run(ctx, localstackImage, testcontainers.WithEnv(map[string]string{
+		"SERVICES": "ssm,sts",
+	}))
+	stop = func() {
+		if container != nil {
+			_ = testcontainers.TerminateContainer(container)
+		}
+	}
+	if err != nil {
+		stop()
+		return "", nil, err
+	}
+
+	endpoint, err = container.PortEndpoint(ctx, "4566/tcp", "http")
+	if err != nil {
+		stop()
+		return "", nil, err
+	}
+
+	return endpoint, stop, nil
+}
+
+func TestApplyLocalStack(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Env              map[string]string
+		Data             FastSSMProviderModel
+		ExpectedEndpoint string
+		ExpectedKey      string
+		ExpectedRegion   string
+	}{
+		{
+			Name:             "defaults",
+			ExpectedEndpoint: "http://localhost:4566",
+			ExpectedKey:      "test",
+			ExpectedRegion:   "us-east-1",
+		},
+		{
+			Name:             "LOCALSTACK_ENDPOINT",
+			Env:              map[string]string{"LOCALSTACK_ENDPOINT": "http://localstack:4566"},
+			ExpectedEndpoint: "http://localstack:4566",
+			ExpectedKey:      "test",
+			ExpectedRegion:   "us-east-1",
+		},
+		{
+			Name:             "configured credentials and region",
+			Data:             FastSSMProviderModel{AccessKey: types.StringValue("AKIDEXAMPLE"), SecretKey: types.StringValue("secret"), Region: types.StringValue("eu-west-1")},
+			ExpectedEndpoint: "http://localhost:4566",
+			ExpectedKey:      "AKIDEXAMPLE",
+			ExpectedRegion:   "eu-west-1",
+		},
+		{
+			// Left to the environment
+			Name:             "AWS_REGION",
+			Env:              map[string]string{"AWS_REGION": "eu-central-1"},
+			ExpectedEndpoint: "http://localhost:4566",
+			ExpectedKey:      "test",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			for _, env := range []string{"LOCALSTACK_ENDPOINT", "AWS_REGION", "AWS_DEFAULT_REGION"} {
+				t.Setenv(env, testCase.Env[env])
+			}
+
+			data := testCase.Data
+			if endpoint := applyLocalStack(&data); endpoint != testCase.ExpectedEndpoint {
+				t.Errorf("expected endpoint %s, got %s", testCase.ExpectedEndpoint, endpoint)
+			}
+			if got := data.AccessKey.ValueString(); got != testCase.ExpectedKey {
+				t.Errorf("expected access key %s, got %s", testCase.ExpectedKey, got)
+			}
+			if got := data.Region.ValueString(); got != testCase.ExpectedRegion {
+				t.Errorf("expected region %q, got %q", testCase.ExpectedRegion, got)
+			}
+		})
+	}
+}
+
+func TestEndpointsWithLocalStack(t *testing.T) {
+	t.Parallel()
+
+	got := endpoints{ssm: "http://ssm.example.com"}.withLocalStack("http://localhost:4566")
+	if expected := (endpoints{ssm: "http://ssm.example.com", sts: "http://localhost:4566"}); got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
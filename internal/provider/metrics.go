@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsTextfileInterval is how often the `metrics_textfile` is rewritten during the run.
+const metricsTextfileInterval = 10 * time.Second
+
+// metrics exposes the AWS API calls of the process to Prometheus, across provider aliases.
+var metrics = newMetricsExporter(apiCalls)
+
+// metricsExporter serves the figures of apiCallStats in the Prometheus text format on
+// `metrics_listen_address`, and writes them to `metrics_textfile` for the textfile
+// collector of the node exporter, so long-running pipelines can scrape the provider.
+type metricsExporter struct {
+	stats *apiCallStats
+
+	mu sync.Mutex
+	// listening are the addresses served, textfiles the files written, so that
+	// aliases configured alike start them once
+	listening map[string]bool
+	textfiles map[string]bool
+}
+
+func newMetricsExporter(stats *apiCallStats) *metricsExporter {
+	return &metricsExporter{
+		stats:     stats,
+		listening: make(map[string]bool),
+		textfiles: make(map[string]bool),
+	}
+}
+
+// listen serves the metrics on /metrics at address until the provider exits.
+func (m *metricsExporter) listen(address string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listening[address] {
+		return nil
+	}
+
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", m.serveHTTP)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	// Serves for the lifetime of the process
+	go func() { _ = server.Serve(ln) }()
+
+	m.listening[address] = true
+
+	return nil
+}
+
+func (m *metricsExporter) serveHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = io.WriteString(w, m.stats.prometheus())
+}
+
+// writeTextfile writes the metrics to path now, then every metricsTextfileInterval
+// and once more when the provider exits.
+func (m *metricsExporter) writeTextfile(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.textfiles[path] {
+		return nil
+	}
+
+	// Fail at configure time rather than silently during the run
+	if err := m.write(path); err != nil {
+		return err
+	}
+	m.textfiles[path] = true
+
+	go func() {
+		for range time.Tick(metricsTextfileInterval) {
+			// Checked at configure time, a failure is retried on the next tick
+			_ = m.write(path)
+		}
+	}()
+
+	return nil
+}
+
+// flush rewrites the textfiles with the final figures of the run.
+func (m *metricsExporter) flush() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for path := range m.textfiles {
+		// Nowhere left to report a failure
+		_ = m.write(path)
+	}
+}
+
+// write replaces the file atomically, the collector never reads a partial file.
+func (m *metricsExporter) write(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing the metrics textfile: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(m.stats.prometheus()); err != nil {
+		f.Close()
+		return fmt.Errorf("writing the metrics textfile: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing the metrics textfile: %w", err)
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return fmt.Errorf("writing the metrics textfile: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("writing the metrics textfile: %w", err)
+	}
+
+	return nil
+}
+
+// FlushMetrics writes the final metrics of the run to `metrics_textfile`, meant for the end of the run.
+func FlushMetrics() {
+	metrics.flush()
+}
+
+// prometheus renders the figures in the Prometheus text exposition format, labelled
+// by service and operation and sorted by operation.
+func (s *apiCallStats) prometheus() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.operations))
+	for name := range s.operations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// family, help and kind head the lines of a metric family, left empty for the
+	// series continuing the previous one
+	series := []struct {
+		family, help, kind string
+		name               string
+		value              func(*apiOperationStats) string
+	}{
+		{
+			family: "fastssm_aws_api_calls_total", kind: "counter",
+			help:  "AWS API calls made by the provider, each made of one or more attempts.",
+			name:  "fastssm_aws_api_calls_total",
+			value: func(o *apiOperationStats) string { return fmt.Sprint(o.Calls) },
+		},
+		{
+			family: "fastssm_aws_api_retries_total", kind: "counter",
+			help:  "Attempts of AWS API calls beyond the first.",
+			name:  "fastssm_aws_api_retries_total",
+			value: func(o *apiOperationStats) string { return fmt.Sprint(o.Attempts - o.Calls) },
+		},
+		{
+			family: "fastssm_aws_api_throttles_total", kind: "counter",
+			help:  "Attempts of AWS API calls rejected by throttling.",
+			name:  "fastssm_aws_api_throttles_total",
+			value: func(o *apiOperationStats) string { return fmt.Sprint(o.Throttles) },
+		},
+		{
+			family: "fastssm_aws_api_call_duration_seconds", kind: "summary",
+			help:  "Time spent in AWS API calls, retries and back-off included.",
+			name:  "fastssm_aws_api_call_duration_seconds_sum",
+			value: func(o *apiOperationStats) string { return fmt.Sprint(o.Duration.Seconds()) },
+		},
+		{
+			name:  "fastssm_aws_api_call_duration_seconds_count",
+			value: func(o *apiOperationStats) string { return fmt.Sprint(o.Calls) },
+		},
+	}
+
+	var b strings.Builder
+	for _, serie := range series {
+		if serie.family != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", serie.family, serie.help, serie.family, serie.kind)
+		}
+
+		for _, name := range names {
+			service, operation, _ := strings.Cut(name, ".")
+			fmt.Fprintf(&b, "%s{service=%q,operation=%q} %s\n", serie.name, service, operation, serie.value(s.operations[name]))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPICallStatsPrometheus(t *testing.T) {
+	t.Parallel()
+
+	stats := newAPICallStats()
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) {
+		o.Calls += 2
+		o.Attempts += 3
+		o.Throttles++
+		o.Duration += 1500 * time.Millisecond
+	})
+	stats.record("STS.GetCallerIdentity", func(o *apiOperationStats) {
+		o.Calls++
+		o.Attempts++
+		o.Duration += 250 * time.Millisecond
+	})
+
+	expected := `# HELP fastssm_aws_api_calls_total AWS API calls made by the provider, each made of one or more attempts.
+# TYPE fastssm_aws_api_calls_total counter
+fastssm_aws_api_calls_total{service="SSM",operation="GetParameter"} 2
+fastssm_aws_api_calls_total{service="STS",operation="GetCallerIdentity"} 1
+# HELP fastssm_aws_api_retries_total Attempts of AWS API calls beyond the first.
+# TYPE fastssm_aws_api_retries_total counter
+fastssm_aws_api_retries_total{service="SSM",operation="GetParameter"} 1
+fastssm_aws_api_retries_total{service="STS",operation="GetCallerIdentity"} 0
+# HELP fastssm_aws_api_throttles_total Attempts of AWS API calls rejected by throttling.
+# TYPE fastssm_aws_api_throttles_total counter
+fastssm_aws_api_throttles_total{service="SSM",operation="GetParameter"} 1
+fastssm_aws_api_throttles_total{service="STS",operation="GetCallerIdentity"} 0
+# HELP fastssm_aws_api_call_duration_seconds Time spent in AWS API calls, retries and back-off included.
+# TYPE fastssm_aws_api_call_duration_seconds summary
+fastssm_aws_api_call_duration_seconds_sum{service="SSM",operation="GetParameter"} 1.5
+fastssm_aws_api_call_duration_seconds_sum{service="STS",operation="GetCallerIdentity"} 0.25
+fastssm_aws_api_call_duration_seconds_count{service="SSM",operation="GetParameter"} 2
+fastssm_aws_api_call_duration_seconds_count{service="STS",operation="GetCallerIdentity"} 1
+`
+	if got := stats.prometheus(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestMetricsExporter(t *testing.T) {
+	t.Parallel()
+
+	stats := newAPICallStats()
+	exporter := newMetricsExporter(stats)
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) { o.Calls++ })
+
+	recorder := httptest.NewRecorder()
+	exporter.serveHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(recorder.Body.String(), `fastssm_aws_api_calls_total{service="SSM",operation="GetParameter"} 1`) {
+		t.Errorf("unexpected metrics served:\n%s", recorder.Body.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "fastssm.prom")
+	if err := exporter.writeTextfile(path); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The final figures of the run are written when flushed
+	stats.record("SSM.GetParameter", func(o *apiOperationStats) { o.Calls++ })
+	exporter.flush()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(content), `fastssm_aws_api_calls_total{service="SSM",operation="GetParameter"} 2`) {
+		t.Errorf("unexpected metrics written:\n%s", content)
+	}
+
+	if err := exporter.writeTextfile(filepath.Join(path, "missing", "fastssm.prom")); err == nil {
+		t.Errorf("expected an error writing the metrics to a missing directory")
+	}
+}
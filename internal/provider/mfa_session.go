@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// maxMFASessionAge is the longest session AWS grants to a role, older session files are removed.
+const maxMFASessionAge = 12 * time.Hour
+
+// userCacheDir is the cache directory of the user, replaced in the tests.
+var userCacheDir = os.UserCacheDir
+
+// mfaSessionCredentials persists the session of a role assumed with an MFA code. Terraform
+// runs the plan and the apply, and every provider alias, in their own plugin process, each
+// assuming the role with the same `token_code` or FASTSSM_MFA_TOKEN_CODE, while AWS accepts
+// a code once: the processes configured with the same code share the session until it
+// expires instead. Like the cache of the AWS CLI, the session is stored in the cache
+// directory of the user, in a file only the user can read.
+type mfaSessionCredentials struct {
+	provider aws.CredentialsProvider
+	file     string
+
+	now func() time.Time
+}
+
+// mfaSessionEntry is the content of a session file.
+type mfaSessionEntry struct {
+	AccessKeyID     string    `json:"access_key_id"`
+	SecretAccessKey string    `json:"secret_access_key"`
+	SessionToken    string    `json:"session_token"`
+	Expires         time.Time `json:"expires"`
+}
+
+// newMFASessionCredentials shares the session of provider, assuming roleARN with the code of
+// the MFA device serialNumber, between the processes configured with the same code.
+func newMFASessionCredentials(provider aws.CredentialsProvider, roleARN, serialNumber, code string) (*mfaSessionCredentials, error) {
+	dir, err := userCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Join(dir, "terraform-provider-fastssm", "mfa")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the MFA session directory: %w", err)
+	}
+
+	key := sha256.Sum256([]byte(strings.Join([]string{roleARN, serialNumber, code}, "\n")))
+
+	return &mfaSessionCredentials{
+		provider: provider,
+		file:     filepath.Join(dir, hex.EncodeToString(key[:])+".json"),
+		now:      time.Now,
+	}, nil
+}
+
+// Retrieve returns the session stored by another process, or assumes the role and
+// stores its session. The file is locked meanwhile, the aliases configured at the same
+// time wait for the first one to assume the role.
+func (c *mfaSessionCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	file, err := os.OpenFile(c.file, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("opening the MFA session file: %w", err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return aws.Credentials{}, fmt.Errorf("locking the MFA session file: %w", err)
+	}
+	defer func() { _ = unlockFile(file) }()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("reading the MFA session file: %w", err)
+	}
+	var entry mfaSessionEntry
+	// An expired session is assumed again, the code is rejected unless it's a new one
+	if json.Unmarshal(content, &entry) == nil && c.now().Before(entry.Expires) {
+		return aws.Credentials{
+			AccessKeyID:     entry.AccessKeyID,
+			SecretAccessKey: entry.SecretAccessKey,
+			SessionToken:    entry.SessionToken,
+			Source:          "fastssm MFA session",
+			CanExpire:       true,
+			Expires:         entry.Expires,
+		}, nil
+	}
+
+	creds, err := c.provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	content, err = json.Marshal(mfaSessionEntry{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		Expires:         creds.Expires,
+	})
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	if err := file.Truncate(0); err != nil {
+		return aws.Credentials{}, fmt.Errorf("writing the MFA session file: %w", err)
+	}
+	if _, err := file.WriteAt(content, 0); err != nil {
+		return aws.Credentials{}, fmt.Errorf("writing the MFA session file: %w", err)
+	}
+	c.removeExpired()
+
+	return creds, nil
+}
+
+// removeExpired removes the files of the sessions of older codes.
+func (c *mfaSessionCredentials) removeExpired() {
+	dir := filepath.Dir(c.file)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && c.now().Sub(info.ModTime()) > maxMFASessionAge {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMFASessionSharedBetweenConfigures(t *testing.T) {
+	const (
+		roleARN = "arn:aws:iam::123456789012:role/break-glass"
+		mfaARN  = "arn:aws:iam::123456789012:mfa/operator"
+	)
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv(mfaTokenCodeEnvVar, "654321")
+
+	// Terraform configures the provider in a new process for the plan, then for the apply
+	configure := func() {
+		ctx := context.Background()
+		p := New("test")()
+
+		var schemaResp provider.SchemaResponse
+		p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+		typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+		roleType := typ.AttributeTypes["assume_role"].(tftypes.List).ElementType.(tftypes.Object)
+		role := make(map[string]tftypes.Value, len(roleType.AttributeTypes))
+		for name, attributeType := range roleType.AttributeTypes {
+			role[name] = tftypes.NewValue(attributeType, nil)
+		}
+		role["role_arn"] = tftypes.NewValue(tftypes.String, roleARN)
+		role["session_name"] = tftypes.NewValue(tftypes.String, "operator")
+		role["serial_number"] = tftypes.NewValue(tftypes.String, mfaARN)
+
+		values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attributeType := range typ.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		values["region"] = tftypes.NewValue(tftypes.String, "eu-west-1")
+		values["access_key"] = tftypes.NewValue(tftypes.String, "test")
+		values["secret_key"] = tftypes.NewValue(tftypes.String, "test")
+		values["assume_role"] = tftypes.NewValue(typ.AttributeTypes["assume_role"], []tftypes.Value{tftypes.NewValue(roleType, role)})
+
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, values)}}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+	}
+
+	// The second one uses the session of the first, AWS rejects the code used already
+	configure()
+	configure()
+
+	if assumed := server.AssumedRoles(); len(assumed) != 1 {
+		t.Errorf("expected the role assumed once, got %+v", assumed)
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var awsAccountIDRegexp = regexache.MustCompile(`^\d{12}$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NameToARNFunction{}
+
+func NewNameToARNFunction() function.Function {
+	return &NameToARNFunction{}
+}
+
+// NameToARNFunction builds the ARN of an SSM parameter without looking it up.
+type NameToARNFunction struct{}
+
+func (f *NameToARNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "name_to_arn"
+}
+
+func (f *NameToARNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the ARN of an SSM parameter",
+		Description: "Given a partition, region, account ID and parameter name, returns the ARN of the SSM parameter. " +
+			"No API call is made, so the parameter doesn't need to exist yet.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "partition",
+				Description: "AWS partition, e.g. `aws`, `aws-cn` or `aws-us-gov`.",
+			},
+			function.StringParameter{
+				Name:        "region",
+				Description: "AWS region of the parameter.",
+			},
+			function.StringParameter{
+				Name:        "account_id",
+				Description: "AWS account ID owning the parameter.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the parameter.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NameToARNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var partition, region, accountID, name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &partition, &region, &accountID, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	if !partitionRegexp.MatchString(partition) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid partition %q (expecting to match regular expression: %s)", partition, partitionRegexp))
+		return
+	}
+
+	if !regionRegexp.MatchString(region) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid region %q (expecting to match regular expression: %s)", region, regionRegexp))
+		return
+	}
+
+	if !awsAccountIDRegexp.MatchString(accountID) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("invalid account ID %q, expecting 12 digits", accountID))
+		return
+	}
+
+	if name == "" || name == "/" {
+		resp.Error = function.NewArgumentFuncError(3, "parameter name must not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parameterARN(partition, region, accountID, name)))
+}
+
+// parameterARN is the inverse of parameterNameFromARN.
+func parameterARN(partition, region, accountID, name string) string {
+	return arn.ARN{
+		Partition: partition,
+		Service:   "ssm",
+		Region:    region,
+		AccountID: accountID,
+		Resource:  "parameter/" + strings.TrimPrefix(name, "/"),
+	}.String()
+}
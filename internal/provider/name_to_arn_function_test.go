@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNameToARNFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Arguments   []string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:      "hierarchical name",
+			Arguments: []string{"aws", "eu-west-1", "123456789012", "/app/prod/db"},
+			Expected:  "arn:aws:ssm:eu-west-1:123456789012:parameter/app/prod/db",
+		},
+		{
+			Name:      "flat name",
+			Arguments: []string{"aws", "eu-west-1", "123456789012", "db"},
+			Expected:  "arn:aws:ssm:eu-west-1:123456789012:parameter/db",
+		},
+		{
+			Name:      "other partition",
+			Arguments: []string{"aws-us-gov", "us-gov-west-1", "123456789012", "/app/db"},
+			Expected:  "arn:aws-us-gov:ssm:us-gov-west-1:123456789012:parameter/app/db",
+		},
+		{
+			Name:        "invalid partition",
+			Arguments:   []string{"azure", "eu-west-1", "123456789012", "/app/db"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid region",
+			Arguments:   []string{"aws", "Europe", "123456789012", "/app/db"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid account ID",
+			Arguments:   []string{"aws", "eu-west-1", "1234", "/app/db"},
+			ExpectError: true,
+		},
+		{
+			Name:        "empty name",
+			Arguments:   []string{"aws", "eu-west-1", "123456789012", ""},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			args := make([]attr.Value, 0, len(testCase.Arguments))
+			for _, arg := range testCase.Arguments {
+				args = append(args, types.StringValue(arg))
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData(args),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewNameToARNFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.StringValue(testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
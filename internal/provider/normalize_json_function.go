@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeJSONFunction{}
+
+func NewNormalizeJSONFunction() function.Function {
+	return &NormalizeJSONFunction{}
+}
+
+// NormalizeJSONFunction rewrites a JSON document in a canonical form.
+type NormalizeJSONFunction struct{}
+
+func (f *NormalizeJSONFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_json"
+}
+
+func (f *NormalizeJSONFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Canonicalize a JSON string",
+		Description: "Returns the JSON document with object keys sorted and insignificant whitespace removed, " +
+			"so reformatting the source doesn't produce a new parameter version. Numbers are kept exactly as written.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "JSON document to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeJSON(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
+
+// normalizeJSON decodes numbers as json.Number, so large integers and decimals
+// survive the round trip untouched, and leaves HTML characters unescaped.
+func normalizeJSON(value string) (string, error) {
+	document, err := decodeJSONDocument(value)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSONDocument(document)
+}
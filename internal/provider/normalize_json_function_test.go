@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeJSONFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Value       string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "keys are sorted and whitespace removed",
+			Value:    "{\n  \"b\": 1,\n  \"a\": [1, 2, {\"d\": true, \"c\": null}]\n}\n",
+			Expected: `{"a":[1,2,{"c":null,"d":true}],"b":1}`,
+		},
+		{
+			Name:     "numbers are kept as written",
+			Value:    `{"big": 12345678901234567890, "dec": 1.50}`,
+			Expected: `{"big":12345678901234567890,"dec":1.50}`,
+		},
+		{
+			Name:     "html is not escaped",
+			Value:    `{"url": "https://example.com/?a=1&b=<2>"}`,
+			Expected: `{"url":"https://example.com/?a=1&b=<2>"}`,
+		},
+		{
+			Name:     "scalar",
+			Value:    ` "text" `,
+			Expected: `"text"`,
+		},
+		{
+			Name:        "invalid JSON",
+			Value:       `{"a":}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "trailing data",
+			Value:       `{"a":1} {"b":2}`,
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.Value)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewNormalizeJSONFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(testCase.Expected)) {
+				t.Errorf("got %s, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ParameterCopyAction{}
+var _ action.ActionWithConfigure = &ParameterCopyAction{}
+
+func NewParameterCopyAction() action.Action {
+	return &ParameterCopyAction{}
+}
+
+// ParameterCopyAction copies a parameter to a new name, optionally deleting the source,
+// so renames don't go through a window where neither name exists.
+type ParameterCopyAction struct {
+	client *FastSSMClient
+}
+
+// ParameterCopyActionModel describes the action data model.
+type ParameterCopyActionModel struct {
+	DeleteSource types.Bool   `tfsdk:"delete_source"`
+	Destination  types.String `tfsdk:"destination"`
+	KeyID        types.String `tfsdk:"key_id"`
+	Overwrite    types.Bool   `tfsdk:"overwrite"`
+	Source       types.String `tfsdk:"source"`
+}
+
+func (a *ParameterCopyAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_copy"
+}
+
+func (a *ParameterCopyAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Copies the latest version of an SSM parameter to a new name, with its value, type, data type, description, " +
+			"allowed pattern, tier and KMS key, optionally deleting the source afterwards. " +
+			"Renaming a `fastssm_parameter` destroys and creates it, leaving a window where neither name exists: " +
+			"copy the parameter first, then move the resource to the new name, and the destination already exists when it's adopted.",
+
+		Attributes: map[string]schema.Attribute{
+			"delete_source": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Delete the source parameter once the copy is written. Defaults to `false`.",
+			},
+			"destination": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the parameter to write.",
+			},
+			"key_id": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{kmsKeyIDValidator{}},
+				Description: "KMS key to encrypt the copy of a `SecureString` parameter with, instead of the key of the source: " +
+					"a key ID, key ARN, alias name such as `alias/my-key`, or alias ARN. Keys of other accounts need their ARN.",
+			},
+			"overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Overwrite the destination parameter when it already exists, writing a new version of it. Defaults to `false`, failing instead.",
+			},
+			"source": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the parameter to copy.",
+			},
+		},
+	}
+}
+
+func (a *ParameterCopyAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *ParameterCopyAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterCopyActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := a.client.parameterName(data.Source.ValueString())
+	destination := a.client.parameterName(data.Destination.ValueString())
+
+	if source == destination {
+		resp.Diagnostics.AddError("SSM parameter copy error", fmt.Sprintf("source and destination are the same parameter %q", source))
+		return
+	}
+
+	var res = &ssm_types.Parameter{}
+	var erri error
+	err := retry.RetryContext(ctx, a.client.timeouts.getParameter, func() *retry.RetryError {
+		res, erri = findParameterByName(ctx, a.client.Client, source, true)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("parameter get failed", fmt.Sprintf("Couldn't get SSM Parameter %q: %s", source, describeError(err)))
+		return
+	}
+
+	// GetParameter doesn't return the description, allowed pattern, tier and key
+	var metadata = &ssm_types.ParameterMetadata{}
+	err = retry.RetryContext(ctx, a.client.timeouts.describeParameters, func() *retry.RetryError {
+		metadata, erri = findParameterMetadataByName(ctx, a.client.Client, source)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("parameter describe failed", fmt.Sprintf("Couldn't describe SSM Parameter %q: %s", source, describeError(err)))
+		return
+	}
+
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(destination),
+		Value:     res.Value,
+		Type:      res.Type,
+		DataType:  res.DataType,
+		Overwrite: aws.Bool(data.Overwrite.ValueBool()),
+	}
+
+	if metadata.Description != nil {
+		input.Description = metadata.Description
+	}
+	if metadata.AllowedPattern != nil {
+		input.AllowedPattern = metadata.AllowedPattern
+	}
+	if metadata.Tier != "" {
+		input.Tier = metadata.Tier
+	}
+	if res.Type == ssm_types.ParameterTypeSecureString {
+		input.KeyId = metadata.KeyId
+		if !data.KeyID.IsNull() {
+			input.KeyId = data.KeyID.ValueStringPointer()
+		}
+	} else if !data.KeyID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("key_id"), "SSM parameter copy error", fmt.Sprintf("%s is a %s parameter, only SecureString parameters are encrypted with a KMS key", source, res.Type))
+		return
+	}
+
+	var result = &ssm.PutParameterOutput{}
+	err = retry.RetryContext(ctx, a.client.timeouts.putParameter, func() *retry.RetryError {
+		result, erri = a.client.PutParameter(ctx, input)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	a.client.forgetParameter(destination)
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM parameter copy error", fmt.Sprintf("copying SSM Parameter (%s) to %s: %s", source, destination, describeError(err)))
+		return
+	}
+
+	resp.Diagnostics.Append(a.client.audit.putParameter(a.client.Options().Region, destination, result.Version)...)
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("copied version %d of %s to %s, now at version %d", res.Version, source, destination, result.Version),
+	})
+
+	if !data.DeleteSource.ValueBool() {
+		return
+	}
+
+	err = retry.RetryContext(ctx, a.client.timeouts.deleteParameter, func() *retry.RetryError {
+		_, erri = a.client.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String(source)})
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	a.client.forgetParameter(source)
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM parameter delete error", fmt.Sprintf("deleting SSM Parameter (%s) after copying it to %s: %s", source, destination, describeError(err)))
+		return
+	}
+
+	resp.Diagnostics.Append(a.client.audit.deleteParameter(a.client.Options().Region, source, res.Version)...)
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("deleted %s", source),
+	})
+}
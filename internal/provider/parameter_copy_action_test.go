@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParameterCopyAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(testAccActionsVersion),
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccPutVersions(t, "/fastssm/acctest/copy/source", "one")
+					t.Cleanup(func() {
+						ctx := context.Background()
+						if conn, err := testAccSSMClient(ctx); err == nil {
+							_, _ = conn.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String("/fastssm/acctest/copy/destination")})
+						}
+					})
+				},
+				Config: `
+action "fastssm_parameter_copy" "test" {
+  config {
+    source        = "/fastssm/acctest/copy/source"
+    destination   = "/fastssm/acctest/copy/destination"
+    delete_source = true
+  }
+}
+
+resource "terraform_data" "trigger" {
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.fastssm_parameter_copy.test]
+    }
+  }
+}
+`,
+				Check: func(s *terraform.State) error {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						return err
+					}
+
+					res, err := findParameterByName(ctx, conn, "/fastssm/acctest/copy/destination", true)
+					if err != nil {
+						return err
+					}
+					if aws.ToString(res.Value) != "one" {
+						return fmt.Errorf("expected the value one, got %q", aws.ToString(res.Value))
+					}
+
+					if _, err := findParameterByName(ctx, conn, "/fastssm/acctest/copy/source", true); !tfresource.NotFound(err) {
+						return fmt.Errorf("expected the source to be deleted, got %v", err)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestParameterCopyActionInvoke(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		Source       string
+		Destination  string
+		KeyID        tftypes.Value
+		Overwrite    tftypes.Value
+		DeleteSource tftypes.Value
+		// Existing is the value of the destination before the copy, if any
+		Existing      string
+		ExpectError   bool
+		ExpectedKeyID string
+		// ExpectedVersion is the version of the destination after the copy
+		ExpectedVersion     int64
+		ExpectSourceDeleted bool
+	}{
+		{
+			Name:            "copy",
+			Source:          "/app/image",
+			Destination:     "/app/copy",
+			KeyID:           tftypes.NewValue(tftypes.String, nil),
+			Overwrite:       tftypes.NewValue(tftypes.Bool, nil),
+			DeleteSource:    tftypes.NewValue(tftypes.Bool, nil),
+			ExpectedKeyID:   "alias/app",
+			ExpectedVersion: 1,
+		},
+		{
+			Name:            "key_id",
+			Source:          "/app/image",
+			Destination:     "/app/copy",
+			KeyID:           tftypes.NewValue(tftypes.String, "alias/other"),
+			Overwrite:       tftypes.NewValue(tftypes.Bool, nil),
+			DeleteSource:    tftypes.NewValue(tftypes.Bool, nil),
+			ExpectedKeyID:   "alias/other",
+			ExpectedVersion: 1,
+		},
+		{
+			Name:         "same name",
+			Source:       "/app/image",
+			Destination:  "/app/image",
+			KeyID:        tftypes.NewValue(tftypes.String, nil),
+			Overwrite:    tftypes.NewValue(tftypes.Bool, true),
+			DeleteSource: tftypes.NewValue(tftypes.Bool, true),
+			ExpectError:  true,
+		},
+		{
+			Name:         "key_id of a String",
+			Source:       "/app/plain",
+			Destination:  "/app/copy",
+			KeyID:        tftypes.NewValue(tftypes.String, "alias/other"),
+			Overwrite:    tftypes.NewValue(tftypes.Bool, nil),
+			DeleteSource: tftypes.NewValue(tftypes.Bool, nil),
+			ExpectError:  true,
+		},
+		{
+			Name:         "existing destination",
+			Source:       "/app/image",
+			Destination:  "/app/copy",
+			KeyID:        tftypes.NewValue(tftypes.String, nil),
+			Overwrite:    tftypes.NewValue(tftypes.Bool, false),
+			DeleteSource: tftypes.NewValue(tftypes.Bool, true),
+			Existing:     "existing",
+			ExpectError:  true,
+		},
+		{
+			Name:            "overwrite",
+			Source:          "/app/image",
+			Destination:     "/app/copy",
+			KeyID:           tftypes.NewValue(tftypes.String, nil),
+			Overwrite:       tftypes.NewValue(tftypes.Bool, true),
+			DeleteSource:    tftypes.NewValue(tftypes.Bool, nil),
+			Existing:        "existing",
+			ExpectedKeyID:   "alias/app",
+			ExpectedVersion: 2,
+		},
+		{
+			Name:                "delete_source",
+			Source:              "/app/image",
+			Destination:         "/app/copy",
+			KeyID:               tftypes.NewValue(tftypes.String, nil),
+			Overwrite:           tftypes.NewValue(tftypes.Bool, nil),
+			DeleteSource:        tftypes.NewValue(tftypes.Bool, true),
+			ExpectedKeyID:       "alias/app",
+			ExpectedVersion:     1,
+			ExpectSourceDeleted: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client, _ := newTestActionClient(t)
+
+			if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/plain"), Value: aws.String("plain"), Type: ssm_types.ParameterTypeString}); err != nil {
+				t.Fatal(err)
+			}
+			if testCase.Existing != "" {
+				if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(testCase.Destination), Value: aws.String(testCase.Existing), Type: ssm_types.ParameterTypeString}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, messages := invokeTestAction(t, NewParameterCopyAction(), client, map[string]tftypes.Value{
+				"source":        tftypes.NewValue(tftypes.String, testCase.Source),
+				"destination":   tftypes.NewValue(tftypes.String, testCase.Destination),
+				"key_id":        testCase.KeyID,
+				"overwrite":     testCase.Overwrite,
+				"delete_source": testCase.DeleteSource,
+			})
+
+			if testCase.ExpectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+
+				// Neither parameter is touched
+				if _, err := findParameterByName(ctx, client.Client, testCase.Source, true); err != nil {
+					t.Errorf("expected the source to be kept, got %s", err)
+				}
+				res, err := findParameterByName(ctx, client.Client, testCase.Destination, true)
+				if testCase.Existing != "" {
+					if err != nil || aws.ToString(res.Value) != testCase.Existing {
+						t.Errorf("expected the destination to be kept, got %v", err)
+					}
+				} else if testCase.Destination != testCase.Source && !tfresource.NotFound(err) {
+					t.Errorf("expected no destination, got %v", err)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			res, err := findParameterByName(ctx, client.Client, testCase.Destination, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Version != testCase.ExpectedVersion || aws.ToString(res.Value) != "two" || res.Type != ssm_types.ParameterTypeSecureString {
+				t.Errorf("expected version %d of a SecureString with value two, got version %d of a %s with %s", testCase.ExpectedVersion, res.Version, res.Type, aws.ToString(res.Value))
+			}
+
+			// The metadata of the source is copied along
+			metadata, err := findParameterMetadataByName(ctx, client.Client, testCase.Destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if aws.ToString(metadata.KeyId) != testCase.ExpectedKeyID {
+				t.Errorf("expected the key %s, got %s", testCase.ExpectedKeyID, aws.ToString(metadata.KeyId))
+			}
+			if aws.ToString(metadata.Description) != "image" || aws.ToString(metadata.AllowedPattern) != "^[a-z]+$" || metadata.Tier != ssm_types.ParameterTierAdvanced {
+				t.Errorf("expected the metadata to be copied, got %+v", metadata)
+			}
+
+			_, err = findParameterByName(ctx, client.Client, testCase.Source, true)
+			if deleted := tfresource.NotFound(err); deleted != testCase.ExpectSourceDeleted {
+				t.Errorf("expected the source deleted %t, got %v", testCase.ExpectSourceDeleted, err)
+			}
+
+			expectedMessages := 1
+			if testCase.ExpectSourceDeleted {
+				expectedMessages++
+			}
+			if len(messages) != expectedMessages {
+				t.Errorf("expected %d progress messages, got %v", expectedMessages, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ParameterCountDataSource{}
+
+func NewParameterCountDataSource() datasource.DataSource {
+	return &ParameterCountDataSource{}
+}
+
+// ParameterCountDataSource defines the data source implementation.
+type ParameterCountDataSource struct {
+	client *FastSSMClient
+}
+
+// ParameterCountDataSourceModel describes the data source data model.
+type ParameterCountDataSourceModel struct {
+	Path      types.String `tfsdk:"path"`
+	Recursive types.Bool   `tfsdk:"recursive"`
+	Total     types.Int64  `tfsdk:"total"`
+	Type      types.String `tfsdk:"type"`
+}
+
+func (d *ParameterCountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_count"
+}
+
+func (d *ParameterCountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Counts the SSM parameters under a path with `DescribeParameters`, e.g. to check the 10,000 standard parameters quota of a region. The values are never read.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "The hierarchy to count, e.g. `/app/prod`, or `/` for every parameter named with a leading slash. Hierarchies start with a forward slash (`/`).",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to count all parameters within the hierarchy, not just the ones directly under `path`. Defaults to `true`.",
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of parameters under `path`, of `type` when set.",
+			},
+			names.AttrType: schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("String", "StringList", "SecureString"),
+				},
+				Description: "Type of the parameters to count, one of `String`, `StringList` or `SecureString`. All types are counted when unset.",
+			},
+		},
+	}
+}
+
+func (d *ParameterCountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ParameterCountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterCountDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recursive := true
+	if !data.Recursive.IsNull() {
+		recursive = data.Recursive.ValueBool()
+	}
+
+	var count int64
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, d.client.timeouts.describeParameters, func() *retry.RetryError {
+		count, erri = countParametersByPath(ctx, d.client.Client, data.Path.ValueString(), recursive, data.Type.ValueString())
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if d.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to count parameters by path %s, got error: %s", data.Path.String(), describeError(err)))
+		return
+	}
+
+	data.Total = basetypes.NewInt64Value(count)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// countParametersByPath walks all pages of DescribeParameters filtered by path and
+// optionally type. Like findParametersByPath, a retry starts from the first page again.
+func countParametersByPath(ctx context.Context, conn *ssm.Client, path string, recursive bool, typ string) (int64, error) {
+	option := "OneLevel"
+	if recursive {
+		option = "Recursive"
+	}
+
+	input := &ssm.DescribeParametersInput{
+		MaxResults: aws.Int32(50),
+		ParameterFilters: []ssm_types.ParameterStringFilter{
+			{
+				Key:    aws.String("Path"),
+				Option: aws.String(option),
+				Values: []string{path},
+			},
+		},
+	}
+	if typ != "" {
+		input.ParameterFilters = append(input.ParameterFilters, ssm_types.ParameterStringFilter{
+			Key:    aws.String("Type"),
+			Option: aws.String("Equals"),
+			Values: []string{typ},
+		})
+	}
+
+	var count int64
+	pages := ssm.NewDescribeParametersPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		count += int64(len(page.Parameters))
+	}
+
+	return count, nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccParameterCountDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterCountDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter_count.all", "total", "3"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter_count.secure", "total", "1"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter_count.one_level", "total", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestCountParametersByPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	})
+
+	// More than a page of DescribeParameters
+	for i := range 60 {
+		typ := ssm_types.ParameterTypeString
+		if i%3 == 0 {
+			typ = ssm_types.ParameterTypeSecureString
+		}
+		name := fmt.Sprintf("/app/nested/%02d", i)
+		if i < 5 {
+			name = fmt.Sprintf("/app/%02d", i)
+		}
+		if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("v"), Type: typ}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/other/00"), Value: aws.String("v"), Type: ssm_types.ParameterTypeString}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name      string
+		Path      string
+		Recursive bool
+		Type      string
+		Expected  int64
+	}{
+		{Name: "recursive", Path: "/app", Recursive: true, Expected: 60},
+		{Name: "one level", Path: "/app", Expected: 5},
+		{Name: "type", Path: "/app", Recursive: true, Type: "SecureString", Expected: 20},
+		{Name: "everything", Path: "/", Recursive: true, Expected: 61},
+		{Name: "empty", Path: "/missing", Recursive: true, Expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := countParametersByPath(ctx, conn, testCase.Path, testCase.Recursive, testCase.Type)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != testCase.Expected {
+				t.Errorf("expected %d parameters, got %d", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+const testAccParameterCountDataSourceConfig = `
+resource "fastssm_parameter" "host" {
+  name  = "/fastssm/acctest/count/db/host"
+  type  = "String"
+  value = "db.example.com"
+}
+
+resource "fastssm_parameter" "password" {
+  name  = "/fastssm/acctest/count/db/password"
+  type  = "SecureString"
+  value = "secret"
+}
+
+resource "fastssm_parameter" "replicas" {
+  name  = "/fastssm/acctest/count/replicas"
+  type  = "String"
+  value = "2"
+}
+
+data "fastssm_parameter_count" "all" {
+  path = "/fastssm/acctest/count"
+
+  depends_on = [
+    fastssm_parameter.host,
+    fastssm_parameter.password,
+    fastssm_parameter.replicas,
+  ]
+}
+
+data "fastssm_parameter_count" "secure" {
+  path = "/fastssm/acctest/count"
+  type = "SecureString"
+
+  depends_on = [
+    fastssm_parameter.host,
+    fastssm_parameter.password,
+    fastssm_parameter.replicas,
+  ]
+}
+
+data "fastssm_parameter_count" "one_level" {
+  path      = "/fastssm/acctest/count"
+  recursive = false
+
+  depends_on = [
+    fastssm_parameter.host,
+    fastssm_parameter.password,
+    fastssm_parameter.replicas,
+  ]
+}
+`
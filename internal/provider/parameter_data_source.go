@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
 	"terraform-provider-fastssm/internal/tfresource"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -16,7 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -28,18 +28,30 @@ func NewParameterDataSource() datasource.DataSource {
 
 // ParameterDataSource defines the data source implementation.
 type ParameterDataSource struct {
-	client *ssm.Client
+	client *FastSSMClient
 }
 
 // ParameterDataSourceModel describes the data source data model.
 type ParameterDataSourceModel struct {
-	Arn            types.String `tfsdk:"arn"`
-	InsecureValue  types.String `tfsdk:"insecure_value"`
-	Name           types.String `tfsdk:"name"`
-	Type           types.String `tfsdk:"type"`
-	Value          types.String `tfsdk:"value"`
-	Version        types.Int64  `tfsdk:"version"`
-	WithDecryption types.Bool   `tfsdk:"with_decryption"`
+	AllowedPattern   types.String  `tfsdk:"allowed_pattern"`
+	Arn              types.String  `tfsdk:"arn"`
+	DefaultValue     types.String  `tfsdk:"default_value"`
+	Description      types.String  `tfsdk:"description"`
+	Found            types.Bool    `tfsdk:"found"`
+	IncludeMetadata  types.Bool    `tfsdk:"include_metadata"`
+	InsecureValue    types.String  `tfsdk:"insecure_value"`
+	KeyId            types.String  `tfsdk:"key_id"`
+	Label            types.String  `tfsdk:"label"`
+	LastModifiedDate types.String  `tfsdk:"last_modified_date"`
+	Name             types.String  `tfsdk:"name"`
+	Optional         types.Bool    `tfsdk:"optional"`
+	Region           types.String  `tfsdk:"region"`
+	Tier             types.String  `tfsdk:"tier"`
+	Type             types.String  `tfsdk:"type"`
+	Value            types.String  `tfsdk:"value"`
+	ValueObject      types.Dynamic `tfsdk:"value_object"`
+	Version          types.Int64   `tfsdk:"version"`
+	WithDecryption   types.Bool    `tfsdk:"with_decryption"`
 }
 
 func (d *ParameterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,11 +64,35 @@ func (d *ParameterDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		MarkdownDescription: "SSM Parameter data source",
 
 		Attributes: map[string]schema.Attribute{
+			"allowed_pattern": schema.StringAttribute{
+				Computed:    true,
+				Description: "Regular expression used to validate the parameter value. Only populated with `include_metadata`.",
+			},
 			names.AttrARN: schema.StringAttribute{
 				// Optional: true,
 				Computed:    true,
 				Description: "ARN of the parameter.",
 			},
+			"default_value": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("optional")),
+				},
+				Description: "Value to return in `value` when the parameter doesn't exist. Requires `optional`.",
+			},
+			names.AttrDescription: schema.StringAttribute{
+				Computed:    true,
+				Description: "Description of the parameter. Only populated with `include_metadata`.",
+			},
+			"found": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the parameter was found. Always `true` unless `optional` is set.",
+			},
+			"include_metadata": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to make the additional, rate-limited, `DescribeParameters` call to populate the metadata attributes. Defaults to `false`. The metadata of a parameter shared through AWS RAM is left null, with a warning, when the share doesn't allow describing it.",
+			},
 			"insecure_value": schema.StringAttribute{
 				Computed: true,
 				Validators: []validator.String{
@@ -69,14 +105,49 @@ func (d *ParameterDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				// PlanModifiers: []planmodifier.String{
 				// 	SyncAttributePlanModifier("value"),
 				// },
-				Description: "Value of the parameter. **Use caution:** This value is never marked as sensitive.",
+				Description: "Value of the parameter, null for a `SecureString`. **Use caution:** This value is never marked as sensitive.",
+			},
+			names.AttrKeyID: schema.StringAttribute{
+				Computed:    true,
+				Description: "KMS key used to encrypt a `SecureString` parameter, null for the other types. The AWS managed key is reported as `alias/aws/ssm`. Only populated with `include_metadata`, `GetParameter` doesn't return it.",
+			},
+			"label": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+					stringvalidator.ConflictsWith(path.MatchRoot(names.AttrVersion)),
+				},
+				Description: "Label of the version to read, e.g. `prod-current`, instead of the latest one. Conflicts with `version`.",
+			},
+			"last_modified_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date the parameter was last changed or updated, in RFC3339 format. Only populated with `include_metadata`.",
 			},
 			names.AttrName: schema.StringAttribute{
 				Required: true,
 				// PlanModifiers: []planmodifier.String{
 				// 	stringplanmodifier.RequiresReplace(),
 				// },
-				Description: "Name of the parameter.",
+				Description: "Name or ARN of the parameter. Use the ARN to read a parameter shared with this account through AWS RAM. The parameter must be in `region`.",
+			},
+			"optional": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether a missing parameter is tolerated. When set and the parameter doesn't exist, `found` is `false` and the value attributes are null, or `default_value`, instead of failing the plan. Defaults to `false`.",
+			},
+			"region": schema.StringAttribute{
+				Optional: true,
+				// Data sources have no schema defaults, the effective value is set in Read
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regionRegexp, "must be a valid AWS region name"),
+				},
+				Description: "Region to read the parameter from. Defaults to the region of the provider. " +
+					"Another region is read with a client created on first use, sharing the credentials and rate limit of the provider, " +
+					"without the batching and caching of the reads in the region of the provider.",
+			},
+			"tier": schema.StringAttribute{
+				Computed:    true,
+				Description: "Tier of the parameter. Only populated with `include_metadata`.",
 			},
 			names.AttrType: schema.StringAttribute{
 				// Required: true,
@@ -100,14 +171,24 @@ func (d *ParameterDataSource) Schema(ctx context.Context, req datasource.SchemaR
 					)},
 				Description: "Value of the parameter. This value is always marked as sensitive in the Terraform plan output, regardless of `type`. In Terraform CLI version 0.15 and later, this may require additional configuration handling for certain scenarios. For more information, see the [Terraform v0.15 Upgrade Guide](https://www.terraform.io/upgrade-guides/0-15.html#sensitive-output-values).",
 			},
+			"value_object": schema.DynamicAttribute{
+				Computed:  true,
+				Sensitive: true,
+				Description: "Value of the parameter parsed as JSON, as `jsondecode` would, null when the value isn't valid JSON. " +
+					"Marked as sensitive like `value`, use `nonsensitive` on the attributes to expose.",
+			},
 			names.AttrVersion: schema.Int64Attribute{
-				Computed:    true,
-				Description: "Version of the parameter.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Version of the parameter. When set, that version is read instead of the latest one.",
 			},
 			"with_decryption": schema.BoolAttribute{
 				Optional: true,
-				// TODO need to set this to default = true
-				// Default:  true,
+				// Data sources have no schema defaults, the effective value is set in Read
+				Computed:    true,
 				Description: "Whether to return decrypted `SecureString` value. Defaults to `true`.",
 			},
 		},
@@ -120,12 +201,12 @@ func (d *ParameterDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*ssm.Client)
+	client, ok := req.ProviderData.(*FastSSMClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *ssm.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -135,6 +216,9 @@ func (d *ParameterDataSource) Configure(ctx context.Context, req datasource.Conf
 }
 
 func (d *ParameterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	var data ParameterDataSourceModel
 
 	// Read Terraform prior state data into the model
@@ -144,24 +228,34 @@ func (d *ParameterDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	const (
-		// Maximum amount of time to wait for asynchronous validation on SSM Parameter creation.
-		timeout = 2 * time.Minute
-	)
+	// Default to true and persist the effective value
+	if data.WithDecryption.IsNull() || data.WithDecryption.IsUnknown() {
+		data.WithDecryption = basetypes.NewBoolValue(true)
+	}
+	decryption := data.WithDecryption.ValueBool()
+
+	conn := d.client.regionalClients.client(d.client.Client, data.Region.ValueString())
+	data.Region = basetypes.NewStringValue(conn.Options().Region)
 
-	decryption := true
-	if !data.WithDecryption.IsNull() {
-		decryption = data.WithDecryption.ValueBool()
+	if err := checkParameterARNRegion(data.Name.ValueString(), conn.Options().Region); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(names.AttrName), "Parameter in another region", err.Error())
+		return
 	}
 
 	var res = &ssm_types.Parameter{}
 	var erri error
 	// Define retry logic
-	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-		res, erri = findParameterByName(ctx, d.client, data.Name.ValueString(), decryption)
+	err := retry.RetryContext(ctx, d.client.timeouts.getParameter, func() *retry.RetryError {
+		selector := parameterSelector(d.client.parameterName(data.Name.ValueString()), data.Version, data.Label)
+		if conn == d.client.Client {
+			res, erri = d.client.readParameter(ctx, selector, decryption)
+		} else {
+			// The reads are shared and batched in the region of the provider only
+			res, erri = findParameterByName(ctx, conn, selector, decryption)
+		}
 		if erri != nil {
 			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
+			if d.client.isRetryableError(ctx, erri) {
 				// Return with retryable error, specifying how long to wait before the next retry
 				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
 			}
@@ -174,6 +268,26 @@ func (d *ParameterDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return nil
 	})
 
+	if tfresource.NotFound(err) && data.Optional.ValueBool() {
+		data.Found = basetypes.NewBoolValue(false)
+		data.AllowedPattern = basetypes.NewStringNull()
+		data.Arn = basetypes.NewStringNull()
+		data.Description = basetypes.NewStringNull()
+		data.InsecureValue = basetypes.NewStringNull()
+		data.KeyId = basetypes.NewStringNull()
+		data.LastModifiedDate = basetypes.NewStringNull()
+		data.Tier = basetypes.NewStringNull()
+		data.Type = basetypes.NewStringNull()
+		// A missing parameter falls back to `default_value`, which is null when unset
+		data.Value = data.DefaultValue
+		data.ValueObject = jsonDynamicValue(ctx, data.DefaultValue.ValueString())
+		if data.Version.IsUnknown() {
+			data.Version = basetypes.NewInt64Null()
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	if tfresource.NotFound(err) {
 		resp.Diagnostics.AddError("parameter not found", fmt.Sprintf("SSM Parameter %s not found, removing from state", data.Name.String()))
 		data.Name = basetypes.NewStringNull()
@@ -182,20 +296,89 @@ func (d *ParameterDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameter, got error: %v", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameter, got error: %s", describeError(err)))
 		return
 	}
 
+	// `name` is kept as configured, it may hold an ARN
+	data.Found = basetypes.NewBoolValue(true)
 	data.Arn = basetypes.NewStringValue(*res.ARN)
-	data.Name = basetypes.NewStringValue(*res.Name)
 	data.Type = basetypes.NewStringValue(string(res.Type))
 	data.Version = basetypes.NewInt64Value(res.Version)
 
 	data.Value = basetypes.NewStringValue(*res.Value)
-	if !data.InsecureValue.IsNull() && res.Type != ssm_types.ParameterTypeSecureString {
-		data.InsecureValue = basetypes.NewStringValue(*res.Value)
+	data.ValueObject = jsonDynamicValue(ctx, *res.Value)
+	data.InsecureValue = insecureValue(res)
+
+	data.AllowedPattern = basetypes.NewStringNull()
+	data.Description = basetypes.NewStringNull()
+	data.KeyId = basetypes.NewStringNull()
+	data.LastModifiedDate = basetypes.NewStringNull()
+	data.Tier = basetypes.NewStringNull()
+
+	if data.IncludeMetadata.ValueBool() {
+		shared := isSharedParameter(res)
+		var md = &ssm_types.ParameterMetadata{}
+		err := retry.RetryContext(ctx, d.client.timeouts.describeParameters, func() *retry.RetryError {
+			if shared {
+				md, erri = findSharedParameterMetadataByARN(ctx, conn, *res.Name)
+			} else {
+				md, erri = findParameterMetadataByName(ctx, conn, *res.Name)
+			}
+			if erri != nil {
+				// Check if the error is retryable (e.g., rate limiting, network issues)
+				if d.client.isRetryableError(ctx, erri) {
+					// Return with retryable error, specifying how long to wait before the next retry
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
+
+				// If it's a permanent error, stop retrying
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+			}
+
+			// If success, return nil (no retry)
+			return nil
+		})
+
+		// The owner may share the parameter without the DescribeParameters access to it
+		if shared && (tfresource.NotFound(err) || isAccessDeniedError(err)) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("include_metadata"),
+				"Metadata of the shared parameter unavailable",
+				fmt.Sprintf("DescribeParameters doesn't list %s among the parameters shared with the account, the metadata attributes are left null: %s", *res.Name, err),
+			)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Something went wrong while getting parameter metadata", describeError(err))
+			return
+		}
+
+		data.AllowedPattern = basetypes.NewStringPointerValue(md.AllowedPattern)
+		data.Description = basetypes.NewStringPointerValue(md.Description)
+		data.KeyId = basetypes.NewStringPointerValue(md.KeyId)
+		data.Tier = basetypes.NewStringValue(string(md.Tier))
+		if md.LastModifiedDate != nil {
+			data.LastModifiedDate = basetypes.NewStringValue(md.LastModifiedDate.Format(time.RFC3339))
+		}
 	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// parameterSelector appends the GetParameter selector for a pinned version or
+// label, `name:version` or `name:label`.
+func parameterSelector(name string, version types.Int64, label types.String) string {
+	if !version.IsNull() && !version.IsUnknown() {
+		return fmt.Sprintf("%s:%d", name, version.ValueInt64())
+	}
+
+	if !label.IsNull() && !label.IsUnknown() {
+		return name + ":" + label.ValueString()
+	}
+
+	return name
+}
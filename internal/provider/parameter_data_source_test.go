@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"terraform-provider-fastssm/internal/fakessm"
 	"terraform-provider-fastssm/internal/names"
 	"testing"
 
@@ -17,6 +20,137 @@ func TestAccParameterDataSource(t *testing.T) {
 				Config: testAccParameterDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.fastssm_parameter.test", names.AttrName, "test"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "with_decryption", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceInsecureValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterDataSourceInsecureValueConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.string", "insecure_value", "plain"),
+					resource.TestCheckNoResourceAttr("data.fastssm_parameter.secure", "insecure_value"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceKeyID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterDataSourceKeyIDConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", names.AttrKeyID, "alias/aws/ssm"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceValueObject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterDataSourceValueObjectConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.json", "value_object.replicas", "2"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.json", "value_object.hosts.1", "b.example.com"),
+					resource.TestCheckNoResourceAttr("data.fastssm_parameter.plain", "value_object"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceShared(t *testing.T) {
+	if testAccFakeBackend == nil {
+		t.Skipf("sharing a parameter from another account needs the fake backend, set %s", fakeBackendEnvVar)
+	}
+
+	region := os.Getenv("AWS_REGION")
+	testAccFakeBackend.Share(region, "/fastssm/acctest/shared", "hub")
+	sharedARN := "arn:aws:ssm:" + region + ":" + fakessm.SharingAccountID + ":parameter/fastssm/acctest/shared"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "fastssm_parameter" "test" {
+  name             = %q
+  include_metadata = true
+}
+`, sharedARN),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", names.AttrARN, sharedARN),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "insecure_value", "hub"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "tier", "Advanced"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "fastssm_parameter_replication" "test" {
+  name    = "/fastssm/acctest/data-source-region"
+  value   = "central"
+  type    = "String"
+  regions = ["eu-central-1"]
+}
+
+data "fastssm_parameter" "test" {
+  name   = fastssm_parameter_replication.test.name
+  region = "eu-central-1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "region", "eu-central-1"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "insecure_value", "central"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterDataSourceOptional(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterDataSourceOptionalConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.fastssm_parameter.test", names.AttrValue),
+					resource.TestCheckNoResourceAttr("data.fastssm_parameter.test", names.AttrVersion),
+				),
+			},
+			{
+				Config: testAccParameterDataSourceDefaultValueConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", "found", "false"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter.test", names.AttrValue, "fallback"),
 				),
 			},
 		},
@@ -28,3 +162,75 @@ data "fastssm_parameter" "test" {
   name = "test"
 }
 `
+
+const testAccParameterDataSourceOptionalConfig = `
+data "fastssm_parameter" "test" {
+  name     = "/fastssm/acctest/does-not-exist"
+  optional = true
+}
+`
+
+const testAccParameterDataSourceDefaultValueConfig = `
+data "fastssm_parameter" "test" {
+  name          = "/fastssm/acctest/does-not-exist"
+  optional      = true
+  default_value = "fallback"
+}
+`
+
+const testAccParameterDataSourceInsecureValueConfig = `
+resource "fastssm_parameter" "string" {
+  name  = "/fastssm/acctest/insecure-value/string"
+  type  = "String"
+  value = "plain"
+}
+
+resource "fastssm_parameter" "secure" {
+  name  = "/fastssm/acctest/insecure-value/secure"
+  type  = "SecureString"
+  value = "secret"
+}
+
+data "fastssm_parameter" "string" {
+  name = fastssm_parameter.string.name
+}
+
+data "fastssm_parameter" "secure" {
+  name = fastssm_parameter.secure.name
+}
+`
+
+const testAccParameterDataSourceValueObjectConfig = `
+resource "fastssm_parameter" "json" {
+  name  = "/fastssm/acctest/value-object/json"
+  type  = "String"
+  value = jsonencode({ replicas = 2, hosts = ["a.example.com", "b.example.com"] })
+}
+
+resource "fastssm_parameter" "plain" {
+  name  = "/fastssm/acctest/value-object/plain"
+  type  = "String"
+  value = "not json"
+}
+
+data "fastssm_parameter" "json" {
+  name = fastssm_parameter.json.name
+}
+
+data "fastssm_parameter" "plain" {
+  name = fastssm_parameter.plain.name
+}
+`
+
+const testAccParameterDataSourceKeyIDConfig = `
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/key-id"
+  type  = "SecureString"
+  value = "secret"
+}
+
+data "fastssm_parameter" "test" {
+  name             = fastssm_parameter.test.name
+  include_metadata = true
+}
+`
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+	"terraform-provider-fastssm/internal/tfresource"
+	"time"
+
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ParameterEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ParameterEphemeralResource{}
+
+func NewParameterEphemeralResource() ephemeral.EphemeralResource {
+	return &ParameterEphemeralResource{}
+}
+
+// ParameterEphemeralResource defines the ephemeral resource implementation.
+type ParameterEphemeralResource struct {
+	client *FastSSMClient
+}
+
+// ParameterEphemeralResourceModel describes the ephemeral resource data model.
+type ParameterEphemeralResourceModel struct {
+	Arn              types.String `tfsdk:"arn"`
+	IncludeMetadata  types.Bool   `tfsdk:"include_metadata"`
+	InsecureValue    types.String `tfsdk:"insecure_value"`
+	KeyId            types.String `tfsdk:"key_id"`
+	Label            types.String `tfsdk:"label"`
+	LastModifiedDate types.String `tfsdk:"last_modified_date"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	Value            types.String `tfsdk:"value"`
+	Version          types.Int64  `tfsdk:"version"`
+	WithDecryption   types.Bool   `tfsdk:"with_decryption"`
+}
+
+func (e *ParameterEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter"
+}
+
+func (e *ParameterEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Opens a single SSM parameter with `GetParameter`, the latest version or one pinned by `version` or `label`. The value never touches the Terraform state or plan, nor the `read_cache` of the provider.",
+
+		Attributes: map[string]schema.Attribute{
+			names.AttrARN: schema.StringAttribute{
+				Computed:    true,
+				Description: "ARN of the parameter.",
+			},
+			"include_metadata": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to make the additional, rate-limited, `DescribeParameters` call to populate `key_id` and `last_modified_date`. Defaults to `false`.",
+			},
+			"insecure_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "Value of the parameter, null for a `SecureString`, as in the `fastssm_parameter` data source. **Use caution:** This value is never marked as sensitive, e.g. when passed to a non-ephemeral argument.",
+			},
+			names.AttrKeyID: schema.StringAttribute{
+				Computed:    true,
+				Description: "KMS key used to encrypt a `SecureString` parameter, null for the other types. The AWS managed key is reported as `alias/aws/ssm`. Only populated with `include_metadata`.",
+			},
+			"label": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+					stringvalidator.ConflictsWith(path.MatchRoot(names.AttrVersion)),
+				},
+				Description: "Label of the version to open, e.g. `prod-current`, instead of the latest one. Conflicts with `version`.",
+			},
+			"last_modified_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date the opened version was written, in RFC3339 format, e.g. to decide on the age of a secret. Only populated with `include_metadata`.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 2048),
+				},
+				Description: "Name or ARN of the parameter. Use the ARN for a parameter shared with this account through AWS RAM, in the region of the provider. " +
+					"A `name:version` or `name:label` selector is accepted too, instead of `version` or `label`.",
+			},
+			names.AttrType: schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the parameter, one of `String`, `StringList` or `SecureString`.",
+			},
+			names.AttrValue: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the parameter.",
+			},
+			names.AttrVersion: schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Version of the parameter. When set, that version is opened instead of the latest one, e.g. to pin a vetted version of a rotating secret during a staged rollout.",
+			},
+			"with_decryption": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return the decrypted value of a `SecureString`. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (e *ParameterEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *ParameterEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := checkParameterARNRegion(data.Name.ValueString(), e.client.Options().Region); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(names.AttrName), "Parameter in another region", err.Error())
+		return
+	}
+
+	decryption := true
+	if !data.WithDecryption.IsNull() {
+		decryption = data.WithDecryption.ValueBool()
+	}
+
+	var res = &ssm_types.Parameter{}
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, e.client.timeouts.getParameter, func() *retry.RetryError {
+		// Bypasses the shared reads of the client, the disk cache would persist the value
+		res, erri = findParameterByName(ctx, e.client.Client, parameterSelector(e.client.parameterName(data.Name.ValueString()), data.Version, data.Label), decryption)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if e.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	if tfresource.NotFound(err) {
+		resp.Diagnostics.AddAttributeError(path.Root(names.AttrName), "Parameter not found", fmt.Sprintf("SSM Parameter %s not found", data.Name.ValueString()))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameter, got error: %s", describeError(err)))
+		return
+	}
+
+	data.Arn = basetypes.NewStringPointerValue(res.ARN)
+	data.Type = basetypes.NewStringValue(string(res.Type))
+	data.Value = basetypes.NewStringPointerValue(res.Value)
+	data.InsecureValue = insecureValue(res)
+	data.Version = basetypes.NewInt64Value(res.Version)
+
+	data.KeyId = basetypes.NewStringNull()
+	data.LastModifiedDate = basetypes.NewStringNull()
+
+	if data.IncludeMetadata.ValueBool() {
+		shared := isSharedParameter(res)
+		var md = &ssm_types.ParameterMetadata{}
+		err := retry.RetryContext(ctx, e.client.timeouts.describeParameters, func() *retry.RetryError {
+			if shared {
+				md, erri = findSharedParameterMetadataByARN(ctx, e.client.Client, *res.Name)
+			} else {
+				md, erri = findParameterMetadataByName(ctx, e.client.Client, *res.Name)
+			}
+			if erri != nil {
+				// Check if the error is retryable (e.g., rate limiting, network issues)
+				if e.client.isRetryableError(ctx, erri) {
+					// Return with retryable error, specifying how long to wait before the next retry
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
+
+				// If it's a permanent error, stop retrying
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+			}
+
+			// If success, return nil (no retry)
+			return nil
+		})
+
+		// The owner may share the parameter without the DescribeParameters access to it
+		if shared && (tfresource.NotFound(err) || isAccessDeniedError(err)) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("include_metadata"),
+				"Metadata of the shared parameter unavailable",
+				fmt.Sprintf("DescribeParameters doesn't list %s among the parameters shared with the account, the metadata attributes are left null: %s", *res.Name, err),
+			)
+			resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError("Something went wrong while getting parameter metadata", describeError(err))
+			return
+		}
+
+		data.KeyId = basetypes.NewStringPointerValue(md.KeyId)
+		// DescribeParameters describes the latest version, GetParameter the opened one
+		if res.LastModifiedDate != nil {
+			data.LastModifiedDate = basetypes.NewStringValue(res.LastModifiedDate.Format(time.RFC3339))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
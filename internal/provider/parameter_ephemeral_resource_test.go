@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParameterEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterEphemeralResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.value", "secret"),
+					resource.TestCheckResourceAttr("echo.test", "data.type", "SecureString"),
+					resource.TestCheckNoResourceAttr("echo.test", "data.insecure_value"),
+					resource.TestCheckResourceAttr("echo.test", "data.version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterEphemeralResource_version(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterEphemeralResourceVersionConfig("v1", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.value", "v1"),
+				),
+			},
+			{
+				// The rollout of v2 keeps the consumers on the vetted version
+				Config: testAccParameterEphemeralResourceVersionConfig("v2", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckParameterStored("/fastssm/acctest/ephemeral/version", "v2"),
+					resource.TestCheckResourceAttr("echo.test", "data.value", "v1"),
+					resource.TestCheckResourceAttr("echo.test", "data.version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterEphemeralResource_includeMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterEphemeralResourceIncludeMetadataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.key_id", "alias/aws/ssm"),
+					resource.TestMatchResourceAttr("echo.test", "data.last_modified_date", regexache.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+		},
+	})
+}
+
+func TestParameterEphemeralResourceOpen(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                  string
+		Type                  ssm_types.ParameterType
+		ExpectedInsecureValue *string
+	}{
+		{Name: "String", Type: ssm_types.ParameterTypeString, ExpectedInsecureValue: aws.String("v")},
+		{Name: "StringList", Type: ssm_types.ParameterTypeStringList, ExpectedInsecureValue: aws.String("v")},
+		{Name: "SecureString", Type: ssm_types.ParameterTypeSecureString},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			server := fakessm.NewServer()
+			t.Cleanup(server.Close)
+
+			client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+				Region:       "eu-west-1",
+				BaseEndpoint: aws.String(server.URL),
+				Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+			}), defaultParameterBatchOptions)
+			ctx := context.Background()
+
+			if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/test"), Value: aws.String("v"), Type: testCase.Type}); err != nil {
+				t.Fatal(err)
+			}
+
+			e := NewParameterEphemeralResource()
+			var schemaResp ephemeral.SchemaResponse
+			e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+			typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			config := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+			for name, attributeType := range typ.AttributeTypes {
+				config[name] = tftypes.NewValue(attributeType, nil)
+			}
+			config["name"] = tftypes.NewValue(tftypes.String, "/test")
+
+			e.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: client}, &ephemeral.ConfigureResponse{})
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema}}
+			e.Open(ctx, ephemeral.OpenRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, config)},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var data ParameterEphemeralResourceModel
+			if diags := resp.Result.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if data.Value.ValueString() != "v" {
+				t.Errorf("expected value v, got %s", data.Value)
+			}
+			if expected := types.StringPointerValue(testCase.ExpectedInsecureValue); !data.InsecureValue.Equal(expected) {
+				t.Errorf("expected insecure_value %s, got %s", expected, data.InsecureValue)
+			}
+		})
+	}
+}
+
+func testAccParameterEphemeralResourceVersionConfig(value, version string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/ephemeral/version"
+  type  = "SecureString"
+  value = %q
+}
+
+ephemeral "fastssm_parameter" "test" {
+  name    = fastssm_parameter.test.name
+  version = %s
+
+  depends_on = [fastssm_parameter.test]
+}
+
+provider "echo" {
+  data = ephemeral.fastssm_parameter.test
+}
+
+resource "echo" "test" {}
+`, value, version)
+}
+
+const testAccParameterEphemeralResourceIncludeMetadataConfig = `
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/ephemeral/include-metadata"
+  type  = "SecureString"
+  value = "secret"
+}
+
+ephemeral "fastssm_parameter" "test" {
+  name             = fastssm_parameter.test.name
+  include_metadata = true
+}
+
+provider "echo" {
+  data = ephemeral.fastssm_parameter.test
+}
+
+resource "echo" "test" {}
+`
+
+const testAccParameterEphemeralResourceConfig = `
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/ephemeral/parameter"
+  type  = "SecureString"
+  value = "secret"
+}
+
+ephemeral "fastssm_parameter" "test" {
+  name = fastssm_parameter.test.name
+}
+
+provider "echo" {
+  data = ephemeral.fastssm_parameter.test
+}
+
+resource "echo" "test" {}
+`
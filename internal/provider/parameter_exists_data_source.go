@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+	"terraform-provider-fastssm/internal/tfresource"
+
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ParameterExistsDataSource{}
+
+func NewParameterExistsDataSource() datasource.DataSource {
+	return &ParameterExistsDataSource{}
+}
+
+// ParameterExistsDataSource defines the data source implementation.
+type ParameterExistsDataSource struct {
+	client *FastSSMClient
+}
+
+// ParameterExistsDataSourceModel describes the data source data model.
+type ParameterExistsDataSourceModel struct {
+	Exists  types.Bool   `tfsdk:"exists"`
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func (d *ParameterExistsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_exists"
+}
+
+func (d *ParameterExistsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether an SSM parameter exists, without failing when it doesn't. The value is never decrypted nor stored.",
+
+		Attributes: map[string]schema.Attribute{
+			"exists": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the parameter exists.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required:    true,
+				Description: "Name or ARN of the parameter. Use the ARN for a parameter shared with this account through AWS RAM, in the region of the provider.",
+			},
+			names.AttrVersion: schema.Int64Attribute{
+				Computed:    true,
+				Description: "Version of the parameter. Null when the parameter doesn't exist.",
+			},
+		},
+	}
+}
+
+func (d *ParameterExistsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ParameterExistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterExistsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := checkParameterARNRegion(data.Name.ValueString(), d.client.Options().Region); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(names.AttrName), "Parameter in another region", err.Error())
+		return
+	}
+
+	var res = &ssm_types.Parameter{}
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, d.client.timeouts.getParameter, func() *retry.RetryError {
+		// The value is of no interest here, so skip the KMS decrypt call
+		res, erri = d.client.readParameter(ctx, d.client.parameterName(data.Name.ValueString()), false)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if d.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	// A missing parameter is a valid answer, not an error
+	if tfresource.NotFound(err) {
+		data.Exists = basetypes.NewBoolValue(false)
+		data.Version = basetypes.NewInt64Null()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameter, got error: %s", describeError(err)))
+		return
+	}
+
+	data.Exists = basetypes.NewBoolValue(true)
+	data.Version = basetypes.NewInt64Value(res.Version)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccParameterExistsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing parameter must not fail the plan
+			{
+				Config: testAccParameterExistsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter_exists.test", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.fastssm_parameter_exists.test", "version"),
+				),
+			},
+		},
+	})
+}
+
+const testAccParameterExistsDataSourceConfig = `
+data "fastssm_parameter_exists" "test" {
+  name = "/fastssm/acctest/does-not-exist"
+}
+`
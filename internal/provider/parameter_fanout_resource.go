@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"terraform-provider-fastssm/internal/names"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultFanoutSessionName is the session name of the roles assumed by fastssm_parameter_fanout.
+const defaultFanoutSessionName = "fastssm-parameter-fanout"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ParameterFanoutResource{}
+
+func NewParameterFanoutResource() resource.Resource {
+	return &ParameterFanoutResource{}
+}
+
+// ParameterFanoutResource writes the same parameter to several accounts, each through
+// a role assumed in it.
+type ParameterFanoutResource struct {
+	client  *FastSSMClient
+	targets *parameterTargets
+}
+
+// ParameterFanoutResourceModel describes the resource data model.
+type ParameterFanoutResourceModel struct {
+	parameterTargetsModel
+	ExternalID  types.String `tfsdk:"external_id"`
+	RoleARNs    types.Set    `tfsdk:"role_arns"`
+	SessionName types.String `tfsdk:"session_name"`
+}
+
+func (r *ParameterFanoutResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_fanout"
+}
+
+func (r *ParameterFanoutResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Distributes an SSM Parameter to a list of accounts.",
+		MarkdownDescription: "Writes the same SSM parameter into every account of `role_arns`, in parallel, in the region of the provider, " +
+			"each through its role assumed with the credentials of the provider, e.g. to distribute an organization-wide value to every member account. " +
+			"Each account is refreshed independently: an account where the parameter was deleted or changed outside of Terraform, " +
+			"its description included, or couldn't be written, is planned to be written again. " +
+			"An account added to `role_arns` already holding a parameter of the same name fails instead of being overwritten.",
+
+		Attributes: map[string]schema.Attribute{
+			names.AttrDescription: schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthBetween(0, 1024)},
+				Description: "Description of the parameter.",
+			},
+			"external_id": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthBetween(2, 1224)},
+				Description: "External ID passed when assuming the roles of `role_arns`, if their trust policies require one.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.String{stringvalidator.LengthBetween(1, 2048)},
+				Description: "Name of the parameter, identical in every account.",
+			},
+			"role_arns": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(arnValidator{kind: "string"}),
+				},
+				Description: "ARNs of the roles to assume to write the parameter, one per target account. " +
+					"Each role needs `ssm:PutParameter`, `ssm:GetParameter`, `ssm:DescribeParameters` and `ssm:DeleteParameter` on the parameter, " +
+					"and must trust the identity of the provider.",
+			},
+			"session_name": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthBetween(2, 64)},
+				Description: "Session name of the assumed roles, `" + defaultFanoutSessionName + "` by default.",
+			},
+			names.AttrType: schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("String", "StringList", "SecureString"),
+				},
+				Description: "Type of the parameter. Valid types are `String`, `StringList` and `SecureString`. " +
+					"A `SecureString` is encrypted with the default key of each account.",
+			},
+			names.AttrValue: schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Validators:  []validator.String{stringListValidator{}},
+				Description: "Value of the parameter. This value is always marked as sensitive in the Terraform plan output, regardless of `type`.",
+			},
+			"versions": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "Version of the parameter in each account, keyed by the ARN of its role.",
+			},
+		},
+	}
+}
+
+func (r *ParameterFanoutResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	r.targets = &parameterTargets{
+		client: client,
+		label:  "role",
+		audit: func(role string) (*auditLog, string) {
+			return client.audit.withRole(role), client.Options().Region
+		},
+	}
+}
+
+func (r *ParameterFanoutResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterFanoutResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.create(ctx, &data.parameterTargetsModel, &data.RoleARNs, r.roleClients(data))...)
+
+	tflog.Trace(ctx, "created a fanned out resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParameterFanoutResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterFanoutResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.read(ctx, &data.parameterTargetsModel, &data.RoleARNs, r.roleClients(data), resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParameterFanoutResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var plan, state ParameterFanoutResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The dropped accounts are left with the roles of the state
+	resp.Diagnostics.Append(r.targets.update(ctx, &plan.parameterTargetsModel, &plan.RoleARNs, state.parameterTargetsModel, state.RoleARNs, r.roleClients(plan), r.roleClients(state), resp.Private)...)
+
+	tflog.Trace(ctx, "updated a fanned out resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParameterFanoutResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterFanoutResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.delete(ctx, data.parameterTargetsModel, data.RoleARNs, r.roleClients(data))...)
+}
+
+// roleClients is the targetClients of the resource, the provider's SSM client signing
+// with the credentials of each role, assumed as configured in data.
+func (r *ParameterFanoutResource) roleClients(data ParameterFanoutResourceModel) targetClients {
+	return func(role string) *ssm.Client {
+		return r.client.assumedRoles.client(r.client.Client, fanoutRole(data, role))
+	}
+}
+
+// assumedRole identifies the credentials of a role assumed by fastssm_parameter_fanout.
+type assumedRole struct {
+	roleARN     string
+	externalID  string
+	sessionName string
+}
+
+// fanoutRole returns how role is assumed for the resource.
+func fanoutRole(data ParameterFanoutResourceModel, role string) assumedRole {
+	sessionName := defaultFanoutSessionName
+	if !data.SessionName.IsNull() {
+		sessionName = data.SessionName.ValueString()
+	}
+
+	return assumedRole{
+		roleARN:     role,
+		externalID:  data.ExternalID.ValueString(),
+		sessionName: sessionName,
+	}
+}
+
+// assumedRoles caches the credentials of the roles assumed by the fastssm_parameter_fanout
+// resources of a provider configuration, so a role shared by several resources is
+// assumed once, not on every call. The roles are assumed with the credentials of the
+// provider, through its STS endpoint.
+type assumedRoles struct {
+	sts *sts.Client
+
+	mu sync.Mutex
+	// refreshes hold the credentials of each role, along with their refresh
+	refreshes map[assumedRole]*credentialRefresh
+}
+
+func newAssumedRoles(client *sts.Client) *assumedRoles {
+	return &assumedRoles{
+		sts:       client,
+		refreshes: make(map[assumedRole]*credentialRefresh),
+	}
+}
+
+// client returns a copy of conn signing with the credentials of role, assumed lazily,
+// on the first API call.
+func (a *assumedRoles) client(conn *ssm.Client, role assumedRole) *ssm.Client {
+	a.mu.Lock()
+	refresh, ok := a.refreshes[role]
+	if !ok {
+		refresh = newCredentialRefresh(aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(a.sts, role.roleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = role.sessionName
+			if role.externalID != "" {
+				o.ExternalID = aws.String(role.externalID)
+			}
+		})))
+		a.refreshes[role] = refresh
+	}
+	a.mu.Unlock()
+
+	return ssm.New(conn.Options(), func(o *ssm.Options) {
+		o.Credentials = refresh.credentials
+		// Replaces the refresh of the provider's credentials, which the role's session doesn't depend on
+		o.APIOptions = append(o.APIOptions, refresh.addMiddleware)
+	})
+}
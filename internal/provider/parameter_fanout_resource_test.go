@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccParameterFanoutResource(t *testing.T) {
+	// LocalStack accepts any role, and keeps the parameters of each account apart
+	first := "arn:aws:iam::111111111111:role/fanout"
+	second := "arn:aws:iam::222222222222:role/fanout"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccParameterFanoutResourceConfig("one", fmt.Sprintf("%q, %q", first, second)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_fanout.test", "role_arns.#", "2"),
+					resource.TestCheckResourceAttr("fastssm_parameter_fanout.test", "versions.%", "2"),
+					resource.TestCheckResourceAttr("fastssm_parameter_fanout.test", "versions."+first, "1"),
+				),
+			},
+			// Shrinking the role list removes the parameter from the dropped account
+			{
+				Config: testAccParameterFanoutResourceConfig("one", fmt.Sprintf("%q", first)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_fanout.test", "role_arns.#", "1"),
+					resource.TestCheckNoResourceAttr("fastssm_parameter_fanout.test", "versions."+second),
+				),
+			},
+			// Value change is written to every account
+			{
+				Config: testAccParameterFanoutResourceConfig("two", fmt.Sprintf("%q", first)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_fanout.test", "versions."+first, "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccParameterFanoutResourceConfig(value, roles string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter_fanout" "test" {
+  name      = "/fastssm/acctest/fanout"
+  value     = %[1]q
+  type      = "String"
+  role_arns = [%[2]s]
+}
+`, value, roles)
+}
+
+func TestAssumedRoles(t *testing.T) {
+	t.Parallel()
+
+	roles := newAssumedRoles(sts.New(sts.Options{Region: "eu-west-1"}))
+	conn := ssm.New(ssm.Options{Region: "eu-west-1"})
+
+	data := ParameterFanoutResourceModel{
+		ExternalID:  types.StringNull(),
+		SessionName: types.StringNull(),
+	}
+	role := fanoutRole(data, "arn:aws:iam::111111111111:role/fanout")
+	if role.sessionName != defaultFanoutSessionName {
+		t.Errorf("expected the default session name, got %s", role.sessionName)
+	}
+
+	first := roles.client(conn, role)
+	second := roles.client(conn, role)
+	if first.Options().Credentials != second.Options().Credentials {
+		t.Errorf("expected the credentials of a role to be shared")
+	}
+
+	data.ExternalID = types.StringValue("external")
+	other := roles.client(conn, fanoutRole(data, "arn:aws:iam::111111111111:role/fanout"))
+	if other.Options().Credentials == first.Options().Credentials {
+		t.Errorf("expected distinct credentials for another external ID")
+	}
+
+	if _, ok := first.Options().Credentials.(*aws.CredentialsCache); !ok {
+		t.Errorf("expected cached credentials, got %T", first.Options().Credentials)
+	}
+	if conn.Options().Credentials != nil {
+		t.Errorf("expected the provider's client to keep its credentials")
+	}
+}
+
+func TestAssumedRolesCredentialRefresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	// As configured by the provider, refreshing its own credentials
+	cfg := aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.NewCredentialsCache(staticCredentials{accessKey: "test", secretKey: "test"}),
+	}
+	cfg.APIOptions = append(cfg.APIOptions, newCredentialRefresh(cfg.Credentials.(*aws.CredentialsCache)).addMiddleware)
+
+	roles := newAssumedRoles(sts.NewFromConfig(cfg))
+	data := ParameterFanoutResourceModel{ExternalID: types.StringNull(), SessionName: types.StringNull()}
+	conn := roles.client(ssm.NewFromConfig(cfg), fanoutRole(data, "arn:aws:iam::111111111111:role/fanout"))
+
+	if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/test"), Value: aws.String("test"), Type: ssm_types.ParameterTypeString}); err != nil {
+		t.Fatal(err)
+	}
+
+	server.ExpireSessions()
+
+	if _, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String("/test")}); err != nil {
+		t.Fatalf("expected the read to succeed with the role assumed again, got %s", err)
+	}
+
+	if got := len(server.AssumedRoles()); got != 2 {
+		t.Errorf("expected the role to be assumed twice, got %d", got)
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ParameterLabelAction{}
+var _ action.ActionWithConfigure = &ParameterLabelAction{}
+
+func NewParameterLabelAction() action.Action {
+	return &ParameterLabelAction{}
+}
+
+// ParameterLabelAction attaches labels to a version of a parameter.
+type ParameterLabelAction struct {
+	client *FastSSMClient
+}
+
+// ParameterLabelActionModel describes the action data model.
+type ParameterLabelActionModel struct {
+	Labels  types.List   `tfsdk:"labels"`
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func (a *ParameterLabelAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_label"
+}
+
+func (a *ParameterLabelAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches labels to a version of an SSM parameter with `LabelParameterVersion`. A label already attached to another version is moved.",
+
+		Attributes: map[string]schema.Attribute{
+			"labels": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 10),
+				},
+				Description: "Labels to attach to the parameter version.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the parameter.",
+			},
+			names.AttrVersion: schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Version of the parameter to label. Defaults to the latest version.",
+			},
+		},
+	}
+}
+
+func (a *ParameterLabelAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *ParameterLabelAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterLabelActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels []string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := &ssm.LabelParameterVersionInput{
+		Name:             aws.String(a.client.parameterName(data.Name.ValueString())),
+		Labels:           labels,
+		ParameterVersion: data.Version.ValueInt64Pointer(),
+	}
+
+	var result = &ssm.LabelParameterVersionOutput{}
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, 5*time.Minute, func() *retry.RetryError {
+		result, erri = a.client.LabelParameterVersion(ctx, input)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if a.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	// Reads by label memoized earlier in the run are stale now
+	a.client.forgetParameter(a.client.parameterName(data.Name.ValueString()))
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM parameter label error", fmt.Sprintf("labeling SSM Parameter (%s): %s", data.Name.String(), describeError(err)))
+		return
+	}
+
+	// Labels failing the naming rules are reported back instead of returning an error
+	if len(result.InvalidLabels) > 0 {
+		resp.Diagnostics.AddError("invalid labels", fmt.Sprintf("SSM rejected the labels: %s", strings.Join(result.InvalidLabels, ", ")))
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("labeled %s version %d with %s", data.Name.ValueString(), result.ParameterVersion, strings.Join(labels, ", ")),
+	})
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParameterLabelAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(testAccActionsVersion),
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccPutVersions(t, "/fastssm/acctest/label", "one", "two") },
+				Config: `
+action "fastssm_parameter_label" "test" {
+  config {
+    name    = "/fastssm/acctest/label"
+    labels  = ["stable"]
+    version = 1
+  }
+}
+
+resource "terraform_data" "trigger" {
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.fastssm_parameter_label.test]
+    }
+  }
+}
+`,
+				Check: func(s *terraform.State) error {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						return err
+					}
+
+					res, err := findParameterByName(ctx, conn, "/fastssm/acctest/label:stable", true)
+					if err != nil {
+						return err
+					}
+					if res.Version != 1 {
+						return fmt.Errorf("expected the label on version 1, got version %d", res.Version)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestParameterLabelActionInvoke(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		Version         tftypes.Value
+		Labels          []string
+		ExpectedVersion int64
+		ExpectError     bool
+	}{
+		{
+			Name:            "version",
+			Version:         tftypes.NewValue(tftypes.Number, 2),
+			Labels:          []string{"candidate"},
+			ExpectedVersion: 2,
+		},
+		{
+			Name:            "latest by default",
+			Version:         tftypes.NewValue(tftypes.Number, nil),
+			Labels:          []string{"candidate"},
+			ExpectedVersion: 2,
+		},
+		{
+			// Attached to version 1 by newTestActionClient
+			Name:            "moved label",
+			Version:         tftypes.NewValue(tftypes.Number, 2),
+			Labels:          []string{"stable"},
+			ExpectedVersion: 2,
+		},
+		{
+			Name:        "invalid label",
+			Version:     tftypes.NewValue(tftypes.Number, 1),
+			Labels:      []string{"awsInvalid"},
+			ExpectError: true,
+		},
+		{
+			Name:        "missing version",
+			Version:     tftypes.NewValue(tftypes.Number, 7),
+			Labels:      []string{"candidate"},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			client, _ := newTestActionClient(t)
+
+			var labels []tftypes.Value
+			for _, label := range testCase.Labels {
+				labels = append(labels, tftypes.NewValue(tftypes.String, label))
+			}
+			resp, _ := invokeTestAction(t, NewParameterLabelAction(), client, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, "/app/image"),
+				"labels":  tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, labels),
+				"version": testCase.Version,
+			})
+
+			if testCase.ExpectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			res, err := findParameterByName(context.Background(), client.Client, "/app/image:"+testCase.Labels[0], true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Version != testCase.ExpectedVersion {
+				t.Errorf("expected the label on version %d, got version %d (%s)", testCase.ExpectedVersion, res.Version, aws.ToString(res.Selector))
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-fastssm/internal/names"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ParameterReplicationResource{}
+
+func NewParameterReplicationResource() resource.Resource {
+	return &ParameterReplicationResource{}
+}
+
+// ParameterReplicationResource writes the same parameter to several regions.
+type ParameterReplicationResource struct {
+	client  *FastSSMClient
+	targets *parameterTargets
+}
+
+// ParameterReplicationResourceModel describes the resource data model.
+type ParameterReplicationResourceModel struct {
+	parameterTargetsModel
+	Regions types.Set `tfsdk:"regions"`
+}
+
+func (r *ParameterReplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_replication"
+}
+
+func (r *ParameterReplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Replicates an SSM Parameter to a list of regions.",
+		MarkdownDescription: "Writes the same SSM parameter to every region in `regions`, in parallel. " +
+			"Each region is refreshed independently: a region where the parameter was deleted or changed outside of Terraform, its description included, is planned to be written again. A region added to `regions` already holding a parameter of the same name fails instead of being overwritten.",
+
+		Attributes: map[string]schema.Attribute{
+			names.AttrDescription: schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthBetween(0, 1024)},
+				Description: "Description of the parameter.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.String{stringvalidator.LengthBetween(1, 2048)},
+				Description: "Name of the parameter, identical in every region.",
+			},
+			"regions": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regionRegexp, "must be a valid AWS region name")),
+				},
+				Description: "Regions to write the parameter to.",
+			},
+			names.AttrType: schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("String", "StringList", "SecureString"),
+				},
+				Description: "Type of the parameter. Valid types are `String`, `StringList` and `SecureString`.",
+			},
+			names.AttrValue: schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Validators:  []validator.String{stringListValidator{}},
+				Description: "Value of the parameter. This value is always marked as sensitive in the Terraform plan output, regardless of `type`.",
+			},
+			"versions": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "Version of the parameter in each region.",
+			},
+		},
+	}
+}
+
+func (r *ParameterReplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+	r.targets = &parameterTargets{
+		client: client,
+		label:  "region",
+		audit: func(region string) (*auditLog, string) {
+			return client.audit, region
+		},
+	}
+}
+
+func (r *ParameterReplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterReplicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.create(ctx, &data.parameterTargetsModel, &data.Regions, r.regionClient)...)
+
+	tflog.Trace(ctx, "created a replicated resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParameterReplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterReplicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.read(ctx, &data.parameterTargetsModel, &data.Regions, r.regionClient, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParameterReplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var plan, state ParameterReplicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.update(ctx, &plan.parameterTargetsModel, &plan.Regions, state.parameterTargetsModel, state.Regions, r.regionClient, r.regionClient, resp.Private)...)
+
+	tflog.Trace(ctx, "updated a replicated resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParameterReplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterReplicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.targets.delete(ctx, data.parameterTargetsModel, data.Regions, r.regionClient)...)
+}
+
+// regionClient is the targetClients of the resource, the provider's SSM client of
+// region, created once and shared with the data sources reading the region.
+func (r *ParameterReplicationResource) regionClient(region string) *ssm.Client {
+	return r.client.regionalClients.client(r.client.Client, region)
+}
+
+// regionalClient returns a copy of client pinned to region.
+func regionalClient(client *ssm.Client, region string) *ssm.Client {
+	return ssm.New(client.Options(), func(o *ssm.Options) {
+		o.Region = region
+	})
+}
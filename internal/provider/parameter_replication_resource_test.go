@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccParameterReplicationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccParameterReplicationResourceConfig("one", `"eu-west-1", "eu-central-1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_replication.test", "regions.#", "2"),
+					resource.TestCheckResourceAttr("fastssm_parameter_replication.test", "versions.%", "2"),
+					resource.TestCheckResourceAttr("fastssm_parameter_replication.test", "versions.eu-west-1", "1"),
+				),
+			},
+			// Shrinking the region list removes the parameter from the dropped region
+			{
+				Config: testAccParameterReplicationResourceConfig("one", `"eu-west-1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_replication.test", "regions.#", "1"),
+					resource.TestCheckNoResourceAttr("fastssm_parameter_replication.test", "versions.eu-central-1"),
+				),
+			},
+			// Value change is written to every region
+			{
+				Config: testAccParameterReplicationResourceConfig("two", `"eu-west-1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter_replication.test", "versions.eu-west-1", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccParameterReplicationResourceConfig(value, regions string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter_replication" "test" {
+  name    = "/fastssm/acctest/replication"
+  value   = %[1]q
+  type    = "String"
+  regions = [%[2]s]
+}
+`, value, regions)
+}
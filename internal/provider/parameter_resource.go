@@ -2,19 +2,29 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
 	"terraform-provider-fastssm/internal/tfresource"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/smithy-go"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,7 +35,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 // const (
@@ -36,6 +45,7 @@ import (
 var _ resource.Resource = &ParameterResource{}
 var _ resource.ResourceWithImportState = &ParameterResource{}
 var _ resource.ResourceWithMoveState = &ParameterResource{}
+var _ resource.ResourceWithModifyPlan = &ParameterResource{}
 
 func NewParameterResource() resource.Resource {
 	return &ParameterResource{}
@@ -43,25 +53,33 @@ func NewParameterResource() resource.Resource {
 
 // ParameterResource defines the resource implementation.
 type ParameterResource struct {
-	client *ssm.Client
+	client *FastSSMClient
 }
 
 // ParameterResourceModel describes the resource data model.
 type ParameterResourceModel struct {
-	AllowedPattern types.String `tfsdk:"allowed_pattern"`
-	Arn            types.String `tfsdk:"arn"`
-	DataType       types.String `tfsdk:"data_type"`
-	Description    types.String `tfsdk:"description"`
-	InsecureValue  types.String `tfsdk:"insecure_value"`
+	AllowedPattern     types.String `tfsdk:"allowed_pattern"`
+	Arn                types.String `tfsdk:"arn"`
+	DataType           types.String `tfsdk:"data_type"`
+	Description        types.String `tfsdk:"description"`
+	ExpectedVersion    types.Bool   `tfsdk:"expected_version"`
+	IgnoreValueChanges types.Bool   `tfsdk:"ignore_value_changes"`
+	InsecureValue      types.String `tfsdk:"insecure_value"`
 	// KeyId     types.String `tfsdk:"key_id"`
-	Name      types.String `tfsdk:"name"`
-	Overwrite types.Bool   `tfsdk:"overwrite"`
-	Tags      types.Map    `tfsdk:"tags"`
+	MetadataRefreshInterval types.String `tfsdk:"metadata_refresh_interval"`
+	Name                    types.String `tfsdk:"name"`
+	NamePrefix              types.String `tfsdk:"name_prefix"`
+	Overwrite               types.Bool   `tfsdk:"overwrite"`
+	Tags                    types.Map    `tfsdk:"tags"`
 	// TagsAll   types.Map    `tfsdk:"tags_all"`
 	// Tier    types.String `tfsdk:"tier"`
-	Type    types.String `tfsdk:"type"`
-	Value   types.String `tfsdk:"value"`
-	Version types.Int64  `tfsdk:"version"`
+	Type                types.String `tfsdk:"type"`
+	Value               types.String `tfsdk:"value"`
+	ValueFile           types.String `tfsdk:"value_file"`
+	ValueFileSHA256     types.String `tfsdk:"value_file_sha256"`
+	ValueJSONMergePatch types.String `tfsdk:"value_json_merge_patch"`
+	Version             types.Int64  `tfsdk:"version"`
+	WithDecryption      types.Bool   `tfsdk:"with_decryption"`
 }
 
 func (r *ParameterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,8 +98,12 @@ func (r *ParameterResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: "Regular expression used to validate the parameter value.",
 			},
 			names.AttrARN: schema.StringAttribute{
-				Optional:    true,
-				Computed:    true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					// Only a new name, which replaces the resource, changes it
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				Description: "ARN of the parameter.",
 			},
 			"data_type": schema.StringAttribute{
@@ -104,6 +126,18 @@ func (r *ParameterResource) Schema(ctx context.Context, req resource.SchemaReque
 				Validators:  []validator.String{stringvalidator.LengthBetween(0, 1024)},
 				Description: "Description of the parameter.",
 			},
+			"expected_version": schema.BoolAttribute{
+				Optional: true,
+				Description: "Check before every update that the parameter is still at the `version` in state, and fail the update with a drift error " +
+					"when another process wrote it since the plan, instead of overwriting its write. Costs a `GetParameter` call per update. " +
+					"SSM has no conditional writes, a write landing between the check and the update itself still goes unnoticed.",
+			},
+			"ignore_value_changes": schema.BoolAttribute{
+				Optional: true,
+				Description: "Ignore changes of the value made outside Terraform, like rotations or version bumps by applications, " +
+					"while still managing the other attributes. Updates of those keep the current value in SSM, " +
+					"changes of the configured value are still applied.",
+			},
 			"insecure_value": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -113,6 +147,7 @@ func (r *ParameterResource) Schema(ctx context.Context, req resource.SchemaReque
 							path.MatchRoot(names.AttrValue),
 						}...),
 						dependentParameterValidator{dependentParamName: "type", requiredValue: []string{"String", "StringList"}},
+						stringListValidator{},
 					)},
 				// PlanModifiers: []planmodifier.String{
 				// 	SyncAttributePlanModifier("value"),
@@ -124,13 +159,35 @@ func (r *ParameterResource) Schema(ctx context.Context, req resource.SchemaReque
 			// 	Optional: true,
 			// 	Computed: true,
 			// },
+			"metadata_refresh_interval": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{timeoutValidator{}},
+				Description: "Duration, e.g. `24h`, after which a refresh also reads `allowed_pattern` and `description` back with a `DescribeParameters` call. " +
+					"By default they're only read when the version of the parameter changed outside Terraform, which misses e.g. a parameter deleted and " +
+					"recreated at the same version with another pattern. The time of the last read is kept in the private state of the resource, " +
+					"the refreshes in between cost no additional call.",
+			},
 			names.AttrName: schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					// Generated from name_prefix, which replaces the resource when changed
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 2048),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name_prefix")),
+				},
+				Description: "Name of the parameter. If the name contains a path (e.g., any forward slashes (`/`)), it must be fully qualified with a leading forward slash (`/`). For additional requirements and constraints, see the [AWS SSM User Guide](https://docs.aws.amazon.com/systems-manager/latest/userguide/sysman-parameter-name-constraints.html). Exactly one of `name` and `name_prefix` must be set.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators:  []validator.String{stringvalidator.LengthBetween(1, 2048)},
-				Description: "Name of the parameter. If the name contains a path (e.g., any forward slashes (`/`)), it must be fully qualified with a leading forward slash (`/`). For additional requirements and constraints, see the [AWS SSM User Guide](https://docs.aws.amazon.com/systems-manager/latest/userguide/sysman-parameter-name-constraints.html).",
+				Validators:  []validator.String{stringvalidator.LengthBetween(1, 2048-uniqueNameSuffixLength)},
+				Description: "Creates a unique name beginning with this prefix, followed by a timestamp and random characters, so parameters of concurrent runs, like CI test parameters, don't collide. The generated name is stored in `name`.",
 			},
 			"overwrite": schema.BoolAttribute{
 				Optional:           true,
@@ -170,15 +227,61 @@ func (r *ParameterResource) Schema(ctx context.Context, req resource.SchemaReque
 						stringvalidator.AtLeastOneOf(path.Expressions{
 							path.MatchRoot("insecure_value"),
 							path.MatchRoot("value"),
+							path.MatchRoot("value_file"),
+							path.MatchRoot("value_json_merge_patch"),
 						}...),
 						// dependentParameterValidator{dependentParamName: "type", requiredValue: []string{"SecureString"}},
+						stringListValidator{},
 					)},
 				Description: "Value of the parameter. This value is always marked as sensitive in the Terraform plan output, regardless of `type`. In Terraform CLI version 0.15 and later, this may require additional configuration handling for certain scenarios. For more information, see the [Terraform v0.15 Upgrade Guide](https://www.terraform.io/upgrade-guides/0-15.html#sensitive-output-values).",
 			},
+			"value_file": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot(names.AttrValue),
+						path.MatchRoot("insecure_value"),
+					}...),
+				},
+				Description: "Path of a local file holding the value of the parameter, read at plan time. " +
+					"The state keeps its SHA-256 in `value_file_sha256` instead of `value`, " +
+					"changes to the file or to the parameter in SSM are planned as updates.",
+			},
+			"value_file_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex SHA-256 of the value of the parameter when `value_file` is set.",
+			},
+			"value_json_merge_patch": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot(names.AttrValue),
+						path.MatchRoot("insecure_value"),
+						path.MatchRoot("value_file"),
+					}...),
+					dependentParameterValidator{dependentParamName: "type", requiredValue: []string{"String", "SecureString"}},
+					jsonMergePatchValidator{},
+				},
+				Description: "JSON object applied as a [JSON merge patch](https://www.rfc-editor.org/rfc/rfc7386) to the JSON document stored in the parameter, " +
+					"instead of replacing the whole value, so several configurations can each manage their own keys of a shared document. " +
+					"The keys set are written, nested objects merged and keys set to `null` removed. Keys dropped from the patch are removed " +
+					"from the document, and destroying the resource removes every key of the patch, deleting the parameter once the document is empty. " +
+					"A missing parameter is created from the patch. Changes of the patched keys outside Terraform are planned as updates, " +
+					"the other keys are left alone. `value` and `insecure_value` stay null. The writers of a document must not be applied concurrently: " +
+					"each one reads the document before writing it back whole.",
+			},
 			names.AttrVersion: schema.Int64Attribute{
 				Computed:    true,
 				Description: "Version of the parameter.",
 			},
+			"with_decryption": schema.BoolAttribute{
+				Optional: true,
+				Description: "Read the value of a `SecureString` decrypted, defaults to `true`. Set to `false` for execution roles without `kms:Decrypt` " +
+					"on the key: the parameter is then read encrypted, the value in state is kept and a version written outside Terraform " +
+					"plans the configured value again, as its changes can't be compared. Not valid with `ignore_value_changes` " +
+					"or `value_json_merge_patch` on a `SecureString`, both read the current value to write it back.",
+			},
 		},
 	}
 }
@@ -189,12 +292,12 @@ func (r *ParameterResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*ssm.Client)
+	client, ok := req.ProviderData.(*FastSSMClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ssm.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -203,7 +306,80 @@ func (r *ParameterResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan hashes `value_file`, plans the computed attributes from what actually
+// changes, and fails the plan when another fastssm_parameter of the provider
+// configuration already resolves to the same parameter name, `normalize_names` included.
+// Terraform attaches the address of the resource to the error, the provider doesn't know it.
+// A `SecureString` read without decryption can't be written back as it is.
+// The planned writes count towards `high_throughput_warning_threshold`, updates
+// are checked against `version_limit_warning_threshold`, and the API calls of every
+// planned change are estimated, destroys included.
+func (r *ParameterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	// Destroy
+	if req.Plan.Raw.IsNull() {
+		if r.client != nil {
+			r.estimateAPICalls(ctx, req, resp, false)
+		}
+		return
+	}
+
+	planValueFileHash(ctx, req, resp)
+	planComputedValues(ctx, req, resp)
+
+	var plan ParameterResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkWithDecryption(plan)...)
+
+	// The provider is not configured yet
+	if r.client == nil {
+		return
+	}
+
+	// The version is only unknown when the parameter is written
+	var version types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(names.AttrVersion), &version)...)
+	r.estimateAPICalls(ctx, req, resp, version.IsUnknown())
+	if r.client.highThroughput != nil && version.IsUnknown() {
+		resp.Diagnostics.Append(r.client.highThroughput.plan(ctx, r.client.Client)...)
+	}
+	if r.client.versionLimitWarning > 0 && version.IsUnknown() && !req.State.Raw.IsNull() {
+		var state ParameterResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(checkVersionLimit(ctx, r.client.Client, r.client.parameterName(state.Name.ValueString()), state.Version.ValueInt64(), r.client.versionLimitWarning)...)
+		}
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(names.AttrName), &name)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() || name.IsNull() {
+		return
+	}
+
+	parameterName := r.client.parameterName(name.ValueString())
+	// Hashed, the configuration holds the value
+	owner := sha256.Sum256([]byte(req.Config.Raw.String()))
+	if !r.client.plannedNames.claim(parameterName, hex.EncodeToString(owner[:])) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(names.AttrName),
+			"Duplicate parameter name",
+			fmt.Sprintf("Another fastssm_parameter resource of this provider configuration is also planned to manage %s. "+
+				"Both would write the same parameter at apply time, the last one overwriting the other. "+
+				"Give each resource its own name, or remove one of them.", parameterName),
+		)
+	}
+}
+
 func (r *ParameterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	var data ParameterResourceModel
 
 	// Read Terraform plan data into the model
@@ -213,18 +389,45 @@ func (r *ParameterResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if data.Name.IsUnknown() {
+		name, err := prefixedUniqueName(data.NamePrefix.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("SSM parameter create error", fmt.Sprintf("generating a name with prefix %s: %s", data.NamePrefix.String(), err))
+			return
+		}
+		data.Name = types.StringValue(name)
+	}
+
 	// Prepare PutParameter request
 	typ := ssm_types.ParameterType(data.Type.ValueString())
 	val := data.Value.ValueString()
+	if !data.ValueFile.IsNull() {
+		var err error
+		if val, err = valueFileContent(data); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value_file"), "SSM parameter create error", err.Error())
+			return
+		}
+	}
 
 	input := &ssm.PutParameterInput{
-		Name:           data.Name.ValueStringPointer(),
+		Name:           aws.String(r.client.parameterName(data.Name.ValueString())),
 		Value:          &val,
 		AllowedPattern: data.AllowedPattern.ValueStringPointer(),
 		Type:           typ,
 		DataType:       data.DataType.ValueStringPointer(),
 	}
 
+	if !data.ValueJSONMergePatch.IsNull() {
+		// The document may already exist, written by the other configurations patching it
+		merged, current, err := mergedJSONDocument(ctx, r.client.Client, *input.Name, types.StringNull(), data.ValueJSONMergePatch)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value_json_merge_patch"), "SSM parameter create error", err.Error())
+			return
+		}
+		val = merged
+		input.Overwrite = aws.Bool(current != nil)
+	}
+
 	if !data.DataType.IsNull() {
 		input.DataType = data.DataType.ValueStringPointer()
 	}
@@ -242,11 +445,11 @@ func (r *ParameterResource) Create(ctx context.Context, req resource.CreateReque
 	var result = &ssm.PutParameterOutput{}
 	var erri error
 	// Define retry logic
-	err := retry.RetryContext(ctx, 10*time.Minute, func() *retry.RetryError {
+	err := retry.RetryContext(ctx, r.client.timeouts.putParameter, func() *retry.RetryError {
 		result, erri = r.client.PutParameter(ctx, input)
 		if erri != nil {
 			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
+			if r.client.isRetryableError(ctx, erri) {
 				// Return with retryable error, specifying how long to wait before the next retry
 				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
 			}
@@ -259,26 +462,35 @@ func (r *ParameterResource) Create(ctx context.Context, req resource.CreateReque
 		return nil
 	})
 
+	// Reads memoized earlier in the run are stale now
+	r.client.forgetParameter(r.client.parameterName(data.Name.ValueString()))
+
 	if err != nil {
-		resp.Diagnostics.AddError("SSM parameter create error", fmt.Sprintf("creating SSM Parameter (%s): %s", data.Name.String(), err))
+		resp.Diagnostics.AddError("SSM parameter create error", fmt.Sprintf("creating SSM Parameter (%s): %s", data.Name.String(), describeError(err)))
 		return
 	}
 
+	resp.Diagnostics.Append(r.client.audit.putParameter(r.client.Options().Region, *input.Name, result.Version)...)
+
 	data.Version = basetypes.NewInt64Value(result.Version)
 
 	// All values must be known after apply
-	withDecryption := true
-	get, err := r.client.GetParameter(ctx, &ssm.GetParameterInput{Name: data.Name.ValueStringPointer(), WithDecryption: &withDecryption})
+	get, err := r.client.readAfterWrite(ctx, r.client.parameterName(data.Name.ValueString()), result.Version, decryptsValue(data))
 	if err != nil {
-		resp.Diagnostics.AddError("parameter get failed", "Couldn't get the SSM parameter data after creation")
+		resp.Diagnostics.AddError("parameter get failed", fmt.Sprintf("Couldn't get the SSM parameter data after creation: %s", describeError(err)))
 		return
 	}
-	data.Arn = basetypes.NewStringValue(*get.Parameter.ARN)
+	data.Arn = basetypes.NewStringValue(*get.ARN)
+	// The metadata in SSM is the one just written
+	resp.Diagnostics.Append(recordMetadataRefresh(ctx, data, resp.Private, time.Now())...)
 
 	data.InsecureValue = basetypes.NewStringNull()
 	// Populate insecure_value if it's not a secure string
-	if get.Parameter.Type != ssm_types.ParameterTypeSecureString {
+	if get.Type != ssm_types.ParameterTypeSecureString {
 		data.InsecureValue = data.Value
+		if !data.ValueFile.IsNull() {
+			data.InsecureValue = types.StringValue(val)
+		}
 	}
 
 	// Write logs using the tflog package
@@ -290,6 +502,9 @@ func (r *ParameterResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *ParameterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	var data ParameterResourceModel
 
 	// Read Terraform prior state data into the model
@@ -299,19 +514,14 @@ func (r *ParameterResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	const (
-		// Maximum amount of time to wait for asynchronous validation on SSM Parameter creation.
-		timeout = 2 * time.Minute
-	)
-
 	var res = &ssm_types.Parameter{}
 	var erri error
 	// Define retry logic
-	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-		res, erri = findParameterByName(ctx, r.client, data.Name.ValueString(), true)
+	err := retry.RetryContext(ctx, r.client.timeouts.getParameter, func() *retry.RetryError {
+		res, erri = r.client.readParameter(ctx, r.client.parameterName(data.Name.ValueString()), decryptsValue(data))
 		if erri != nil {
 			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
+			if r.client.isRetryableError(ctx, erri) {
 				// Return with retryable error, specifying how long to wait before the next retry
 				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
 			}
@@ -332,114 +542,188 @@ func (r *ParameterResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", describeError(err)))
 		return
 	}
 
-	// The following information is only available with DescribeParameter call, to get the additional metadata.
-	// Only call DescribeParameters if nothing but the version has changed!
-	if res.Version != data.Version.ValueInt64() {
-		if data.Name.ValueString() == *res.Name &&
-			data.Type.ValueString() == string(res.Type) &&
-			data.DataType.ValueString() == *res.DataType &&
-			data.Value.ValueString() == *res.Value {
-
-			resp.Diagnostics.AddWarning("Running DescribeParameter call", "We will now do a describe call because we don't know what changed (most likely metadata). This is an expensive operation!")
-			name := "Name"
-			equals := "Equals"
-			oper := &ssm.DescribeParametersInput{ParameterFilters: []ssm_types.ParameterStringFilter{
-				{
-					Key:    &name,
-					Option: &equals,
-					Values: []string{data.Name.ValueString()},
-				},
-			}}
-
-			var md = &ssm.DescribeParametersOutput{}
-			err := retry.RetryContext(ctx, 5*time.Minute, func() *retry.RetryError {
-				md, erri = r.client.DescribeParameters(ctx, oper)
-				if erri != nil {
-					// Check if the error is retryable (e.g., rate limiting, network issues)
-					if isRetryableError(ctx, erri) {
-						// Return with retryable error, specifying how long to wait before the next retry
-						return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
-					}
-
-					// If it's a permanent error, stop retrying
-					return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
-				}
+	// The description and the allowed pattern are only returned by the expensive
+	// DescribeParameters call. Changing them writes a new version, so it's only made
+	// when the version differs from the one in state, whatever else changed along,
+	// or when metadata_refresh_interval elapsed since they were last known.
+	due, diags := metadataRefreshDue(ctx, data, resp.Private, time.Now())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-				// If success, return nil (no retry)
-				return nil
-			})
+	if changed := metadataMayHaveChanged(data, res); changed || due {
+		reason := "its version changed outside Terraform"
+		if !changed {
+			reason = "metadata_refresh_interval elapsed"
+		}
+		tflog.Info(ctx, "describing the parameter, "+reason, map[string]any{
+			"name":    *res.Name,
+			"version": res.Version,
+		})
+
+		var md *ssm_types.ParameterMetadata
+		err := retry.RetryContext(ctx, r.client.timeouts.describeParameters, func() *retry.RetryError {
+			md, erri = findParameterMetadataByName(ctx, r.client.Client, *res.Name)
+			if erri != nil {
+				// Check if the error is retryable (e.g., rate limiting, network issues)
+				if r.client.isRetryableError(ctx, erri) {
+					// Return with retryable error, specifying how long to wait before the next retry
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
 
-			if err != nil {
-				resp.Diagnostics.AddError("Something went wrong while getting parameter metadata", err.Error())
-				return
-			}
-			if len(md.Parameters) == 0 || len(md.Parameters) > 1 {
-				resp.Diagnostics.AddError("Incorrect response for parameter metadata", "None or too many results found.")
-				return
+				// If it's a permanent error, stop retrying
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
 			}
 
-			data.Description = basetypes.NewStringValue("") // default to empty
-			// If description is empty we don't want to panic
-			if md.Parameters[0].Description != nil {
-				data.Description = basetypes.NewStringValue(*md.Parameters[0].Description)
-			}
+			// If success, return nil (no retry)
+			return nil
+		})
 
-			// Metadata contains these extra fields, but we only use & need Description:
-			//
-			// AllowedPattern
-			// Description
-			// KeyId
-			// LastModifiedUser
-			// Policies
-			// Tier
+		if err != nil {
+			resp.Diagnostics.AddError("Something went wrong while getting parameter metadata", describeError(err))
+			return
 		}
+
+		refreshMetadata(&data, md)
+		resp.Diagnostics.Append(recordMetadataRefresh(ctx, data, resp.Private, time.Now())...)
 	}
 
 	data.Arn = basetypes.NewStringValue(*res.ARN)
-	data.Name = basetypes.NewStringValue(*res.Name)
+	// The name as configured is kept when normalize_names turns it into the stored one
+	if r.client.parameterName(data.Name.ValueString()) != *res.Name {
+		data.Name = basetypes.NewStringValue(*res.Name)
+	}
 	data.Type = basetypes.NewStringValue(string(res.Type))
+	written := data.Version.ValueInt64() != res.Version
 	data.Version = basetypes.NewInt64Value(res.Version)
 	data.DataType = basetypes.NewStringValue(*res.DataType)
 
-	data.Value = basetypes.NewStringValue(*res.Value)
-	// In case `value` is not provided, but `insecure_value`, copy it
-	if data.Value.IsNull() || data.Value.IsUnknown() {
-		data.Value = data.InsecureValue
+	if data.IgnoreValueChanges.ValueBool() {
+		// The value, and what derives from it, stays as last applied
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
-	// Populate insecure_value if it's not a secure string
-	if res.Type != ssm_types.ParameterTypeSecureString {
-		data.InsecureValue = basetypes.NewStringValue(*res.Value)
+	if !decryptsValue(data) && res.Type == ssm_types.ParameterTypeSecureString {
+		// Only the ciphertext was read, the version tells whether the value changed
+		if written {
+			data.Value = basetypes.NewStringNull()
+			data.ValueFileSHA256 = basetypes.NewStringNull()
+		}
+		data.InsecureValue = basetypes.NewStringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if !data.ValueJSONMergePatch.IsNull() {
+		// Only the patched keys are managed, the rest of the document belongs to others
+		data.ValueJSONMergePatch = refreshJSONMergePatch(data.ValueJSONMergePatch, aws.ToString(res.Value))
+		data.InsecureValue = basetypes.NewStringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
+	if data.ValueFile.IsNull() {
+		data.Value = basetypes.NewStringValue(*res.Value)
+		// In case `value` is not provided, but `insecure_value`, copy it
+		if data.Value.IsNull() || data.Value.IsUnknown() {
+			data.Value = data.InsecureValue
+		}
+	} else {
+		// The file is compared with the value in SSM at plan time
+		data.ValueFileSHA256 = types.StringValue(valueHash(*res.Value))
+	}
+
+	data.InsecureValue = insecureValue(res)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ParameterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	var data ParameterResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	var state ParameterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !rewritesParameter(data, state) {
+		// Only attributes SSM doesn't store changed, like `tags` or `ignore_value_changes`
+		data.Arn = state.Arn
+		data.Version = state.Version
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if data.ExpectedVersion.ValueBool() {
+		resp.Diagnostics.Append(r.checkExpectedVersion(ctx, r.client.parameterName(data.Name.ValueString()), state.Version)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	val := data.Value.ValueString()
+	if !data.ValueFile.IsNull() {
+		var err error
+		if val, err = valueFileContent(data); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value_file"), "SSM parameter update error", err.Error())
+			return
+		}
+	}
+
+	keepValue := keepsCurrentValue(data, state)
+	if !keepValue && !data.ValueJSONMergePatch.IsNull() {
+		// The keys dropped from the patch are removed along
+		merged, _, err := mergedJSONDocument(ctx, r.client.Client, r.client.parameterName(data.Name.ValueString()), state.ValueJSONMergePatch, data.ValueJSONMergePatch)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value_json_merge_patch"), "SSM parameter update error", err.Error())
+			return
+		}
+		val = merged
+	}
+	if keepValue {
+		// PutParameter needs the value, the one in SSM is written back
+		current, err := findParameterByName(ctx, r.client.Client, r.client.parameterName(data.Name.ValueString()), true)
+		if err != nil {
+			resp.Diagnostics.AddError("SSM parameter update error", fmt.Sprintf("reading the current value of SSM Parameter (%s): %s", data.Name.String(), describeError(err)))
+			return
+		}
+		val = aws.ToString(current.Value)
+	}
+
 	// copy value to insecure_value if it's not a secure string
 	data.InsecureValue = basetypes.NewStringNull()
 	if data.Type.ValueString() != "SecureString" {
 		data.InsecureValue = data.Value
+		if !data.ValueFile.IsNull() {
+			data.InsecureValue = types.StringValue(val)
+		}
+		if keepValue {
+			data.InsecureValue = state.InsecureValue
+		}
 	}
 
 	// Prepare PutParameter request
 	typ := ssm_types.ParameterType(data.Type.ValueString())
-	val := data.Value.ValueString()
 	// Update should always overwrite
 	overwrite := true
 
 	input := &ssm.PutParameterInput{
-		Name:           data.Name.ValueStringPointer(),
+		Name:           aws.String(r.client.parameterName(data.Name.ValueString())),
 		Value:          &val,
 		AllowedPattern: data.AllowedPattern.ValueStringPointer(),
 		Type:           typ,
@@ -454,11 +738,11 @@ func (r *ParameterResource) Update(ctx context.Context, req resource.UpdateReque
 	var result = &ssm.PutParameterOutput{}
 	var erri error
 	// Define retry logic
-	err := retry.RetryContext(ctx, 10*time.Minute, func() *retry.RetryError {
+	err := retry.RetryContext(ctx, r.client.timeouts.putParameter, func() *retry.RetryError {
 		result, erri = r.client.PutParameter(ctx, input)
 		if erri != nil {
 			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
+			if r.client.isRetryableError(ctx, erri) {
 				// Return with retryable error, specifying how long to wait before the next retry
 				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
 			}
@@ -471,41 +755,29 @@ func (r *ParameterResource) Update(ctx context.Context, req resource.UpdateReque
 		return nil
 	})
 
+	// Reads memoized earlier in the run are stale now
+	r.client.forgetParameter(r.client.parameterName(data.Name.ValueString()))
+
 	if err != nil {
-		resp.Diagnostics.AddError("SSM parameter update error", fmt.Sprintf("updating SSM Parameter (%s): %s", data.Name.String(), err))
+		resp.Diagnostics.AddError("SSM parameter update error", fmt.Sprintf("updating SSM Parameter (%s): %s", data.Name.String(), describeError(err)))
 		return
 	}
 
+	resp.Diagnostics.Append(r.client.audit.putParameter(r.client.Options().Region, *input.Name, result.Version)...)
+
 	data.Version = basetypes.NewInt64Value(result.Version)
 
 	// All values must be known after apply!
 	// We need to read once again before the end, to get the ARN,
 	// because it's not included in the response of the PutParameter call.
-	withDecryption := true
-	var res = &ssm_types.Parameter{}
-	// Define retry logic
-	err = retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
-		res, erri = findParameterByName(ctx, r.client, data.Name.ValueString(), withDecryption)
-		if erri != nil {
-			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
-				// Return with retryable error, specifying how long to wait before the next retry
-				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
-			}
-
-			// If it's a permanent error, stop retrying
-			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
-		}
-
-		// If success, return nil (no retry)
-		return nil
-	})
-
+	res, err := r.client.readAfterWrite(ctx, r.client.parameterName(data.Name.ValueString()), result.Version, decryptsValue(data))
 	if err != nil {
-		resp.Diagnostics.AddError("parameter get failed", "Couldn't get the SSM parameter data after creation")
+		resp.Diagnostics.AddError("parameter get failed", fmt.Sprintf("Couldn't get the SSM parameter data after the update: %s", describeError(err)))
 		return
 	}
 	data.Arn = basetypes.NewStringValue(*res.ARN)
+	// The metadata in SSM is the one just written
+	resp.Diagnostics.Append(recordMetadataRefresh(ctx, data, resp.Private, time.Now())...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -520,21 +792,30 @@ func (r *ParameterResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *ParameterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	var data ParameterResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	input := &ssm.DeleteParameterInput{
-		Name: data.Name.ValueStringPointer(),
+	name := r.client.parameterName(data.Name.ValueString())
+
+	if !data.ValueJSONMergePatch.IsNull() {
+		// The parameter is only deleted once no other key is left in the document
+		if !r.removeJSONMergePatch(ctx, data, resp) {
+			return
+		}
 	}
 
 	var erri error
-	err := retry.RetryContext(ctx, 10*time.Minute, func() *retry.RetryError {
-		_, erri = r.client.DeleteParameter(ctx, input)
+	err := retry.RetryContext(ctx, r.client.timeouts.deleteParameter, func() *retry.RetryError {
+		// Batched with the deletions of the other resources being destroyed
+		erri = r.client.deletes.delete(ctx, name)
 		if erri != nil {
 			// Check if the error is retryable (e.g., rate limiting, network issues)
-			if isRetryableError(ctx, erri) {
+			if r.client.isRetryableError(ctx, erri) {
 				// Return with retryable error, specifying how long to wait before the next retry
 				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
 			}
@@ -547,13 +828,17 @@ func (r *ParameterResource) Delete(ctx context.Context, req resource.DeleteReque
 		return nil
 	})
 
+	r.client.forgetParameter(name)
+
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ssm parameter, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete ssm parameter, got error: %s", describeError(err)))
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(r.client.audit.deleteParameter(r.client.Options().Region, name, data.Version.ValueInt64())...)
 }
 
 func (r *ParameterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -636,31 +921,265 @@ func isRetryableError(ctx context.Context, err error) bool {
 		return false // If err is nil, it's not a retryable error
 	}
 	// Type assertion for Smithy (used by AWS SDK v2)
+	// The failed attempts themselves are logged by the aws subsystem
 	var apiErr smithy.APIError
-	if ok := errors.As(err, &apiErr); ok {
-		tflog.Info(ctx, apiErr.ErrorCode())
-		tflog.Info(ctx, apiErr.ErrorMessage())
-		tflog.Info(ctx, apiErr.ErrorFault().String())
-
-		if apiErr.ErrorCode() == "ThrottlingException" {
-			tflog.Info(ctx, "Rate limit exceeded, retrying...")
-			// Implement backoff before retrying
-			time.Sleep(time.Duration(5) * time.Second)
-			return true // Retry on throttling error
-		}
+	if ok := errors.As(err, &apiErr); ok && isThrottlingError(err) {
+		tflog.Debug(ctx, "SSM API throttled, retrying", map[string]any{"error_code": apiErr.ErrorCode(), "kms": isKMSThrottlingError(err)})
+		// Back off before retrying, unless the run is being cancelled
+		return sleepContext(ctx, throttleBackoff)
 	}
 
 	var ratelimited ratelimit.QuotaExceededError
 	if ok := errors.As(err, &ratelimited); ok {
-		tflog.Error(ctx, "we are being rate limited dude")
-		tflog.Info(ctx, "Rate limit exceeded, retrying...")
-		// Implement backoff before retrying
-		time.Sleep(time.Duration(5) * time.Second)
-		return true // Retry on throttling error
+		tflog.Debug(ctx, "SDK retry quota exceeded, retrying")
+		// Back off before retrying, unless the run is being cancelled
+		return sleepContext(ctx, throttleBackoff)
+	}
+
+	if isTransientError(err, nil) {
+		tflog.Debug(ctx, "SSM API transient failure, retrying", map[string]any{"error": err.Error()})
+		return sleepContext(ctx, transientBackoff)
 	}
 	return false
 }
 
+// transientErrorCodes are the server-side failures retried once the SDK gave up on them.
+var transientErrorCodes = []string{
+	"InternalFailure",
+	"InternalServerError",
+	"RequestTimeout",
+	"RequestTimeoutException",
+	"ServiceUnavailable",
+}
+
+// isTransientError reports whether err is a transient server-side failure, one of
+// transientErrorCodes or extraCodes, or a connection reset before the response was read.
+func isTransientError(err error, extraCodes []string) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		if isKMSThrottlingError(err) {
+			// Backed off as a throttling
+			return false
+		}
+		return slices.Contains(transientErrorCodes, apiErr.ErrorCode()) || slices.Contains(extraCodes, apiErr.ErrorCode())
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// throttleBackoff is the wait before retrying a throttled call, shortened by the soak test.
+var throttleBackoff = 5 * time.Second
+
+// transientBackoff is the wait before retrying a transient failure.
+var transientBackoff = time.Second
+
+// sleepContext waits for d, reporting false when ctx is done first, e.g. on Ctrl-C
+// or when Terraform stops the provider.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// insecureValue returns the value of a parameter to expose in `insecure_value`,
+// null for a SecureString, so a secret never shows up unmasked in the plan.
+func insecureValue(parameter *ssm_types.Parameter) types.String {
+	if parameter.Type == ssm_types.ParameterTypeSecureString {
+		return basetypes.NewStringNull()
+	}
+
+	return basetypes.NewStringPointerValue(parameter.Value)
+}
+
+// checkExpectedVersion fails when the parameter isn't at the version in state anymore,
+// written outside Terraform between the plan and the update.
+func (r *ParameterResource) checkExpectedVersion(ctx context.Context, name string, expected types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Fresh from the API, reads memoized earlier in the run may predate the write
+	var res = &ssm_types.Parameter{}
+	var erri error
+	err := retry.RetryContext(ctx, r.client.timeouts.getParameter, func() *retry.RetryError {
+		res, erri = findParameterByName(ctx, r.client.Client, name, false)
+		if erri != nil {
+			if r.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		diags.AddError("SSM parameter update error", fmt.Sprintf("checking the version of SSM Parameter (%s): %s", name, describeError(err)))
+		return diags
+	}
+
+	if res.Version != expected.ValueInt64() {
+		diags.AddAttributeError(
+			path.Root(names.AttrVersion),
+			"SSM parameter changed outside Terraform",
+			fmt.Sprintf("SSM Parameter %s is at version %d, the plan was made against version %d: another process wrote it since. "+
+				"The update was aborted to keep its write, plan again to review the changes.", name, res.Version, expected.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
+// decryptsValue reports whether the value of a `SecureString` is read decrypted,
+// unless `with_decryption` is false.
+func decryptsValue(data ParameterResourceModel) bool {
+	return data.WithDecryption.IsNull() || data.WithDecryption.ValueBool()
+}
+
+// checkWithDecryption fails the plan of a `SecureString` read without decryption
+// along with the attributes writing back the current value, which they can't read.
+func checkWithDecryption(plan ParameterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if decryptsValue(plan) || plan.Type.ValueString() != "SecureString" {
+		return diags
+	}
+
+	if plan.IgnoreValueChanges.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("with_decryption"),
+			"Invalid attribute combination",
+			"ignore_value_changes writes the current value of the SecureString back on updates, it can't be set with with_decryption = false.",
+		)
+	}
+	if !plan.ValueJSONMergePatch.IsNull() {
+		diags.AddAttributeError(
+			path.Root("with_decryption"),
+			"Invalid attribute combination",
+			"value_json_merge_patch merges into the current document of the SecureString, it can't be set with with_decryption = false.",
+		)
+	}
+
+	return diags
+}
+
+// estimateAPICalls adds the calls of the planned change to the estimate of the
+// provider configuration.
+func (r *ParameterResource) estimateAPICalls(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, write bool) {
+	var plan, state ParameterResourceModel
+	exists := !req.State.Raw.IsNull()
+	destroy := resp.Plan.Raw.IsNull()
+	if exists {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if !destroy {
+		resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.client.plannedCalls.plan(ctx, parameterAPICalls(plan, state, exists, destroy, write))...)
+}
+
+// keepsCurrentValue reports whether an update leaves the value in SSM as it is, with
+// `ignore_value_changes` set and the configured value unchanged.
+func keepsCurrentValue(plan, state ParameterResourceModel) bool {
+	if !plan.IgnoreValueChanges.ValueBool() {
+		return false
+	}
+
+	return plan.Value.Equal(state.Value) &&
+		plan.ValueFileSHA256.Equal(state.ValueFileSHA256) &&
+		plan.ValueJSONMergePatch.Equal(state.ValueJSONMergePatch) &&
+		(plan.InsecureValue.IsUnknown() || plan.InsecureValue.Equal(state.InsecureValue))
+}
+
+// rewritesParameter reports whether an update changes what SSM stores, creating a new
+// version. Unknown values count as changes.
+func rewritesParameter(plan, state ParameterResourceModel) bool {
+	return !plan.Value.Equal(state.Value) ||
+		!plan.InsecureValue.Equal(state.InsecureValue) ||
+		!plan.ValueFileSHA256.Equal(state.ValueFileSHA256) ||
+		!plan.ValueJSONMergePatch.Equal(state.ValueJSONMergePatch) ||
+		!plan.Type.Equal(state.Type) ||
+		!plan.Description.Equal(state.Description) ||
+		!plan.AllowedPattern.Equal(state.AllowedPattern) ||
+		!plan.DataType.Equal(state.DataType)
+}
+
+// planComputedValues plans `insecure_value` and `version` instead of leaving them
+// unknown on every change.
+func planComputedValues(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state ParameterResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("insecure_value"), &configured)...)
+
+	update := !req.State.Raw.IsNull()
+	if update {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := plannedComputedValues(plan, state, !configured.IsNull(), update)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("insecure_value"), planned.InsecureValue)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(names.AttrVersion), planned.Version)...)
+}
+
+// plannedComputedValues derives the computed `insecure_value` the way Create and Update
+// set it, staying unknown only while what it's copied from is. `version` keeps its state
+// value unless the update rewrites the parameter.
+func plannedComputedValues(plan, state ParameterResourceModel, insecureValueConfigured, update bool) ParameterResourceModel {
+	if !insecureValueConfigured {
+		switch {
+		case plan.Type.IsUnknown():
+		case plan.Type.ValueString() == "SecureString":
+			plan.InsecureValue = types.StringNull()
+		case update && keepsCurrentValue(plan, state):
+			plan.InsecureValue = state.InsecureValue
+		case !plan.ValueFile.IsNull():
+			// The content of the file isn't in the plan, only its hash
+			if update && plan.Type.Equal(state.Type) && !plan.ValueFileSHA256.IsUnknown() && plan.ValueFileSHA256.Equal(state.ValueFileSHA256) {
+				plan.InsecureValue = state.InsecureValue
+			}
+		default:
+			plan.InsecureValue = plan.Value
+		}
+	}
+
+	if update && !rewritesParameter(plan, state) {
+		plan.Version = state.Version
+	}
+
+	return plan
+}
+
+// uniqueNameSuffixLength is the length of the suffix appended to `name_prefix`.
+const uniqueNameSuffixLength = len(uniqueNameTimestampFormat) + 8
+
+const uniqueNameTimestampFormat = "20060102150405"
+
+// prefixedUniqueName appends the UTC time and 8 random hex characters to prefix.
+// The time keeps the names of a prefix sorted by creation, the random part keeps
+// apart the names created in the same second by concurrent runs.
+func prefixedUniqueName(prefix string) (string, error) {
+	random := make([]byte, 4)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	return prefix + time.Now().UTC().Format(uniqueNameTimestampFormat) + hex.EncodeToString(random), nil
+}
+
 func findParameterByName(ctx context.Context, conn *ssm.Client, name string, withDecryption bool) (*ssm_types.Parameter, error) {
 	input := &ssm.GetParameterInput{
 		Name:           &name,
@@ -687,3 +1206,128 @@ func findParameterByName(ctx context.Context, conn *ssm.Client, name string, wit
 
 	return output.Parameter, nil
 }
+
+// readAfterWrite reads the parameter just written, until the read reflects the write
+// of version. SSM is eventually consistent, a read right after PutParameter may still
+// return the previous version, or no parameter at all after a creation.
+func (c *FastSSMClient) readAfterWrite(ctx context.Context, name string, version int64, withDecryption bool) (*ssm_types.Parameter, error) {
+	var res *ssm_types.Parameter
+	err := retry.RetryContext(ctx, c.timeouts.getParameter, func() *retry.RetryError {
+		var err error
+		res, err = findParameterByName(ctx, c.Client, name, withDecryption)
+		switch {
+		case tfresource.NotFound(err):
+			return retry.RetryableError(fmt.Errorf("version %d of %s not readable yet: %w", version, name, err))
+		case err != nil && c.isRetryableError(ctx, err):
+			return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", err))
+		case err != nil:
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", err))
+		case res.Version < version:
+			return retry.RetryableError(fmt.Errorf("read version %d of %s, older than the version %d written", res.Version, name, version))
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// metadataMayHaveChanged reports whether the metadata of the parameter may differ from
+// the state: only then is its version in SSM another one. A state without version,
+// e.g. after an import, describes the parameter once.
+func metadataMayHaveChanged(state ParameterResourceModel, res *ssm_types.Parameter) bool {
+	return state.Version.IsNull() || state.Version.IsUnknown() || res.Version != state.Version.ValueInt64()
+}
+
+// refreshMetadata copies the description and the allowed pattern of md to data. SSM
+// returns none for unset ones, they stay null when they were.
+func refreshMetadata(data *ParameterResourceModel, md *ssm_types.ParameterMetadata) {
+	refresh := func(prior types.String, value *string) types.String {
+		if aws.ToString(value) == "" && prior.IsNull() {
+			return prior
+		}
+		return types.StringValue(aws.ToString(value))
+	}
+
+	data.Description = refresh(data.Description, md.Description)
+	data.AllowedPattern = refresh(data.AllowedPattern, md.AllowedPattern)
+}
+
+// metadataRefreshedAtKey is the private state key of the time the metadata of the
+// parameter was last read or written, for `metadata_refresh_interval`.
+const metadataRefreshedAtKey = "metadata_refreshed_at"
+
+// privateState is the private state of the requests and responses, whose type is
+// internal to the framework.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// metadataRefreshDue reports whether `metadata_refresh_interval` elapsed since the
+// metadata was last known, or is unknown, e.g. after the interval was just set.
+func metadataRefreshDue(ctx context.Context, data ParameterResourceModel, private privateState, now time.Time) (bool, diag.Diagnostics) {
+	if data.MetadataRefreshInterval.IsNull() || data.MetadataRefreshInterval.IsUnknown() {
+		return false, nil
+	}
+	// Already validated
+	interval, _ := time.ParseDuration(data.MetadataRefreshInterval.ValueString())
+
+	value, diags := private.GetKey(ctx, metadataRefreshedAtKey)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	var refreshedAt time.Time
+	if value == nil || json.Unmarshal(value, &refreshedAt) != nil {
+		return true, diags
+	}
+
+	return now.Sub(refreshedAt) >= interval, diags
+}
+
+// recordMetadataRefresh stores now as the time the metadata was last known, when
+// `metadata_refresh_interval` is set.
+func recordMetadataRefresh(ctx context.Context, data ParameterResourceModel, private privateState, now time.Time) diag.Diagnostics {
+	if data.MetadataRefreshInterval.IsNull() || data.MetadataRefreshInterval.IsUnknown() {
+		return nil
+	}
+
+	// Private state values must be JSON
+	value, err := json.Marshal(now.UTC())
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Private state error", fmt.Sprintf("encoding the time of the metadata refresh: %s", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, metadataRefreshedAtKey, value)
+}
+
+// findParameterMetadataByName runs the expensive DescribeParameters call for a single parameter.
+func findParameterMetadataByName(ctx context.Context, conn *ssm.Client, name string) (*ssm_types.ParameterMetadata, error) {
+	input := &ssm.DescribeParametersInput{
+		ParameterFilters: []ssm_types.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("Equals"),
+				Values: []string{name},
+			},
+		},
+	}
+
+	output, err := conn.DescribeParameters(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.Parameters) == 0 {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	if len(output.Parameters) > 1 {
+		return nil, fmt.Errorf("too many results for parameter %s: %d", name, len(output.Parameters))
+	}
+
+	return &output.Parameters[0], nil
+}
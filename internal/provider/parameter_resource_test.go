@@ -1,25 +1,51 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccParameterResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed("/fastssm/acctest/one", "/fastssm/acctest/two"),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
 				Config: testAccParameterResourceConfig("one"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("fastssm_parameter.test", "name", "one"),
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "name", "/fastssm/acctest/one"),
 					resource.TestCheckResourceAttr("fastssm_parameter.test", "value", "one"),
 					// resource.TestCheckResourceAttr("fastssm_parameter.test", "type", "String"),
-					// resource.TestCheckResourceAttr("fastssm_parameter.test", "insecure_value", "one"),
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "insecure_value", "one"),
+					testAccCheckParameterStored("/fastssm/acctest/one", "one"),
 					// resource.TestCheckResourceAttr("fastssm_parameter.test", "overwrite", "false"),
 					// resource.TestCheckResourceAttr("fastssm_parameter.test", "defaulted", "Parameter value when not configured"),
 					// resource.TestCheckResourceAttr("fastssm_parameter.test", "id", "Parameter-id"),
@@ -37,11 +63,21 @@ func TestAccParameterResource(t *testing.T) {
 			// 	// the upstream service, this can be removed.
 			// 	ImportStateVerifyIgnore: []string{"name", "one"},
 			// },
-			// Update and Read testing
+			// Update and Read testing, replacing the resource under its new name
+			{
+				Config: testAccParameterResourceConfig("two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "name", "/fastssm/acctest/two"),
+					testAccCheckParameterStored("/fastssm/acctest/two", "two"),
+				),
+			},
+			// Replacing the resource under the same name, planned twice by Terraform, doesn't conflict with itself
 			{
 				Config: testAccParameterResourceConfig("two"),
+				Taint:  []string{"fastssm_parameter.test"},
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("fastssm_parameter.test", "name", "two"),
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "version", "1"),
+					testAccCheckParameterStored("/fastssm/acctest/two", "two"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -52,9 +88,1073 @@ func TestAccParameterResource(t *testing.T) {
 func testAccParameterResourceConfig(configurableAttribute string) string {
 	return fmt.Sprintf(`
 resource "fastssm_parameter" "test" {
-  name = %[1]q
+  name = "/fastssm/acctest/%[1]s"
   value = %[1]q
   type = "String"
 }
 `, configurableAttribute)
 }
+
+func TestAccParameterResource_plannedValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed("/fastssm/acctest/planned"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterResourcePlannedValuesConfig("one"),
+			},
+			// Only the version is unknown when the value changes
+			{
+				Config: testAccParameterResourcePlannedValuesConfig("two"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("fastssm_parameter.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("fastssm_parameter.test", tfjsonpath.New("arn"), knownvalue.StringRegexp(regexache.MustCompile(`^arn:aws:ssm:.+:parameter/fastssm/acctest/planned$`))),
+						plancheck.ExpectKnownValue("fastssm_parameter.test", tfjsonpath.New("insecure_value"), knownvalue.StringExact("two")),
+						plancheck.ExpectUnknownValue("fastssm_parameter.test", tfjsonpath.New("version")),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccParameterResourcePlannedValuesConfig(value string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/planned"
+  value = %[1]q
+  type  = "String"
+}
+`, value)
+}
+
+func TestAccParameterResource_duplicateName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "fastssm" {
+  normalize_names = true
+}
+
+resource "fastssm_parameter" "one" {
+  name  = "/fastssm/acctest/duplicate"
+  value = "one"
+  type  = "String"
+}
+
+resource "fastssm_parameter" "two" {
+  name  = "fastssm//acctest/duplicate"
+  value = "two"
+  type  = "String"
+}
+`,
+				ExpectError: regexache.MustCompile(`Duplicate parameter name`),
+			},
+		},
+	})
+}
+
+func TestAccParameterResource_invalidStringList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "fastssm_parameter" "test" {
+  name  = "/fastssm/acctest/stringlist"
+  value = "a,,b"
+  type  = "StringList"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexache.MustCompile(`empty items at positions 1`),
+			},
+		},
+	})
+}
+
+func TestAccParameterResource_namePrefix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "fastssm_parameter" "test" {
+  name_prefix = "/fastssm/acctest/prefix-"
+  value       = "one"
+  type        = "String"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("fastssm_parameter.test", "name", regexache.MustCompile(`^/fastssm/acctest/prefix-\d{14}[0-9a-f]{8}$`)),
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "name_prefix", "/fastssm/acctest/prefix-"),
+				),
+			},
+		},
+	})
+}
+
+func TestPrefixedUniqueName(t *testing.T) {
+	t.Parallel()
+
+	first, err := prefixedUniqueName("/ci/run-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := prefixedUniqueName("/ci/run-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(first, "/ci/run-") || len(first) != len("/ci/run-")+uniqueNameSuffixLength {
+		t.Errorf("unexpected name %q", first)
+	}
+	if first == second {
+		t.Errorf("expected unique names, got %q twice", first)
+	}
+}
+
+func TestAccParameterResource_valueFile(t *testing.T) {
+	valueFile := filepath.Join(t.TempDir(), "app.json")
+	writeValueFile := func(content string) func() {
+		return func() {
+			if err := os.WriteFile(valueFile, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed("/fastssm/acctest/value-file"),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeValueFile(`{"replicas":1}`),
+				Config:    testAccParameterResourceValueFileConfig(valueFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "value_file_sha256", valueHash(`{"replicas":1}`)),
+					resource.TestCheckNoResourceAttr("fastssm_parameter.test", "value"),
+					testAccCheckParameterStored("/fastssm/acctest/value-file", `{"replicas":1}`),
+				),
+			},
+			{
+				PreConfig: writeValueFile(`{"replicas":2}`),
+				Config:    testAccParameterResourceValueFileConfig(valueFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "value_file_sha256", valueHash(`{"replicas":2}`)),
+					testAccCheckParameterStored("/fastssm/acctest/value-file", `{"replicas":2}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccParameterResourceValueFileConfig(valueFile string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name       = "/fastssm/acctest/value-file"
+  value_file = %q
+  type       = "String"
+}
+`, valueFile)
+}
+
+func TestAccParameterResource_valueJSONMergePatch(t *testing.T) {
+	const name = "/fastssm/acctest/json-merge-patch"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The keys written by others outlive the resource
+		CheckDestroy: testAccCheckParameterStored(name, `{"cache":{"ttl":60}}`),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, err = conn.PutParameter(ctx, &ssm.PutParameterInput{
+						Name:      aws.String(name),
+						Value:     aws.String(`{"cache":{"ttl":60},"db":{"port":5432}}`),
+						Type:      ssm_types.ParameterTypeString,
+						Overwrite: aws.Bool(true),
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccParameterResourceValueJSONMergePatchConfig(name, `{"db":{"host":"db.example.com","port":null}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("fastssm_parameter.test", "value"),
+					resource.TestCheckNoResourceAttr("fastssm_parameter.test", "insecure_value"),
+					testAccCheckParameterStored(name, `{"cache":{"ttl":60},"db":{"host":"db.example.com"}}`),
+				),
+			},
+			{
+				Config: testAccParameterResourceValueJSONMergePatchConfig(name, `{"db":{"user":"app"}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckParameterStored(name, `{"cache":{"ttl":60},"db":{"user":"app"}}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccParameterResourceValueJSONMergePatchConfig(name, patch string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name                   = %q
+  value_json_merge_patch = %q
+  type                   = "String"
+}
+`, name, patch)
+}
+
+func TestValueFileContent(t *testing.T) {
+	t.Parallel()
+
+	valueFile := filepath.Join(t.TempDir(), "value")
+	if err := os.WriteFile(valueFile, []byte("planned"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := ParameterResourceModel{
+		ValueFile:       types.StringValue(valueFile),
+		ValueFileSHA256: types.StringValue(valueHash("planned")),
+	}
+
+	if got, err := valueFileContent(data); err != nil || got != "planned" {
+		t.Fatalf("expected the planned content, got %q, %v", got, err)
+	}
+
+	if err := os.WriteFile(valueFile, []byte("changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := valueFileContent(data); err == nil {
+		t.Errorf("expected an error for a file changed after the plan")
+	}
+}
+
+func TestAccParameterResource_ignoreValueChanges(t *testing.T) {
+	const name = "/fastssm/acctest/ignore-value-changes"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed(name),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterResourceIgnoreValueChangesConfig("initial", "first"),
+				Check:  testAccCheckParameterStored(name, "initial"),
+			},
+			// A rotation outside Terraform is no drift
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("rotated"), Type: ssm_types.ParameterTypeString, Overwrite: aws.Bool(true)}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testAccParameterResourceIgnoreValueChangesConfig("initial", "first"),
+				PlanOnly: true,
+			},
+			// and survives the updates of the other attributes
+			{
+				Config: testAccParameterResourceIgnoreValueChangesConfig("initial", "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "description", "second"),
+					testAccCheckParameterStored(name, "rotated"),
+				),
+			},
+			// while a new configured value is applied
+			{
+				Config: testAccParameterResourceIgnoreValueChangesConfig("configured", "second"),
+				Check:  testAccCheckParameterStored(name, "configured"),
+			},
+		},
+	})
+}
+
+func testAccParameterResourceIgnoreValueChangesConfig(value, description string) string {
+	return fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name                 = "/fastssm/acctest/ignore-value-changes"
+  value                = %q
+  description          = %q
+  type                 = "String"
+  ignore_value_changes = true
+}
+`, value, description)
+}
+
+func TestAccParameterResource_metadataDrift(t *testing.T) {
+	const name = "/fastssm/acctest/metadata-drift"
+
+	config := fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name        = %q
+  value       = "initial"
+  description = "managed"
+  type        = "String"
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed(name),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("fastssm_parameter.test", "description", "managed"),
+			},
+			// The description changed outside Terraform along with the value is drift
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("changed"), Description: aws.String("changed"), Type: ssm_types.ParameterTypeString, Overwrite: aws.Bool(true)}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "description", "managed"),
+					testAccCheckParameterStored(name, "initial"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParameterResource_withoutDecryption(t *testing.T) {
+	const name = "/fastssm/acctest/without-decryption"
+
+	config := fmt.Sprintf(`
+resource "fastssm_parameter" "test" {
+  name            = %q
+  value           = "secret"
+  type            = "SecureString"
+  with_decryption = false
+}
+`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroyed(name),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("fastssm_parameter.test", "version", "1"),
+			},
+			// Only the version is compared
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// A write outside Terraform plans the configured value again
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					conn, err := testAccSSMClient(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("rotated"), Type: ssm_types.ParameterTypeSecureString, Overwrite: aws.Bool(true)}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_parameter.test", "version", "3"),
+					testAccCheckParameterStored(name, "secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestCheckWithDecryption(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Plan        ParameterResourceModel
+		ExpectError bool
+	}{
+		{
+			Name: "decrypted by default",
+			Plan: ParameterResourceModel{Type: types.StringValue("SecureString"), IgnoreValueChanges: types.BoolValue(true), ValueJSONMergePatch: types.StringNull()},
+		},
+		{
+			Name: "SecureString without decryption",
+			Plan: ParameterResourceModel{Type: types.StringValue("SecureString"), WithDecryption: types.BoolValue(false), ValueJSONMergePatch: types.StringNull()},
+		},
+		{
+			Name: "String without decryption",
+			Plan: ParameterResourceModel{Type: types.StringValue("String"), WithDecryption: types.BoolValue(false), IgnoreValueChanges: types.BoolValue(true), ValueJSONMergePatch: types.StringNull()},
+		},
+		{
+			Name:        "ignore_value_changes",
+			Plan:        ParameterResourceModel{Type: types.StringValue("SecureString"), WithDecryption: types.BoolValue(false), IgnoreValueChanges: types.BoolValue(true), ValueJSONMergePatch: types.StringNull()},
+			ExpectError: true,
+		},
+		{
+			Name:        "value_json_merge_patch",
+			Plan:        ParameterResourceModel{Type: types.StringValue("SecureString"), WithDecryption: types.BoolValue(false), ValueJSONMergePatch: types.StringValue(`{"a":1}`)},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := checkWithDecryption(testCase.Plan)
+			if diags.HasError() != testCase.ExpectError {
+				t.Errorf("expected error %t, got %v", testCase.ExpectError, diags)
+			}
+		})
+	}
+}
+
+func TestKeepsCurrentValue(t *testing.T) {
+	t.Parallel()
+
+	state := ParameterResourceModel{
+		IgnoreValueChanges: types.BoolValue(true),
+		Value:              types.StringValue("v"),
+		InsecureValue:      types.StringValue("v"),
+		ValueFileSHA256:    types.StringNull(),
+	}
+
+	testCases := []struct {
+		Name     string
+		Plan     func(ParameterResourceModel) ParameterResourceModel
+		Expected bool
+	}{
+		{
+			Name:     "unchanged value",
+			Plan:     func(plan ParameterResourceModel) ParameterResourceModel { return plan },
+			Expected: true,
+		},
+		{
+			Name: "computed insecure_value",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.InsecureValue = types.StringUnknown()
+				return plan
+			},
+			Expected: true,
+		},
+		{
+			Name: "changed value",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Value = types.StringValue("w")
+				return plan
+			},
+		},
+		{
+			Name: "changed value_file",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.ValueFileSHA256 = types.StringValue(valueHash("w"))
+				return plan
+			},
+		},
+		{
+			Name: "not ignored",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.IgnoreValueChanges = types.BoolNull()
+				return plan
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := keepsCurrentValue(testCase.Plan(state), state); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestPlannedComputedValues(t *testing.T) {
+	t.Parallel()
+
+	state := ParameterResourceModel{
+		Type:            types.StringValue("String"),
+		Value:           types.StringValue("v"),
+		InsecureValue:   types.StringValue("v"),
+		ValueFile:       types.StringNull(),
+		ValueFileSHA256: types.StringNull(),
+		Description:     types.StringValue("d"),
+		DataType:        types.StringValue("text"),
+		Version:         types.Int64Value(3),
+	}
+	// As the framework plans an update, the computed attributes unknown
+	plan := state
+	plan.InsecureValue = types.StringUnknown()
+	plan.Version = types.Int64Unknown()
+
+	testCases := []struct {
+		Name                    string
+		Plan                    func(ParameterResourceModel) ParameterResourceModel
+		InsecureValueConfigured bool
+		Create                  bool
+		ExpectedInsecureValue   types.String
+		ExpectedVersion         types.Int64
+	}{
+		{
+			Name:                  "unchanged value",
+			Plan:                  func(plan ParameterResourceModel) ParameterResourceModel { return plan },
+			ExpectedInsecureValue: types.StringValue("v"),
+			ExpectedVersion:       types.Int64Value(3),
+		},
+		{
+			Name: "changed description",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Description = types.StringValue("e")
+				return plan
+			},
+			ExpectedInsecureValue: types.StringValue("v"),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name: "changed value",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Value = types.StringValue("w")
+				return plan
+			},
+			ExpectedInsecureValue: types.StringValue("w"),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name: "unknown value",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Value = types.StringUnknown()
+				return plan
+			},
+			ExpectedInsecureValue: types.StringUnknown(),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name: "secure string",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Type = types.StringValue("SecureString")
+				plan.Value = types.StringUnknown()
+				return plan
+			},
+			ExpectedInsecureValue: types.StringNull(),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name: "configured insecure_value",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.Value = types.StringNull()
+				plan.InsecureValue = types.StringValue("v")
+				return plan
+			},
+			InsecureValueConfigured: true,
+			ExpectedInsecureValue:   types.StringValue("v"),
+			ExpectedVersion:         types.Int64Unknown(),
+		},
+		{
+			Name: "ignored value changes",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.IgnoreValueChanges = types.BoolValue(true)
+				plan.Description = types.StringValue("e")
+				return plan
+			},
+			ExpectedInsecureValue: types.StringValue("v"),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name: "changed value_file",
+			Plan: func(plan ParameterResourceModel) ParameterResourceModel {
+				plan.ValueFile = types.StringValue("value.txt")
+				plan.ValueFileSHA256 = types.StringValue(valueHash("w"))
+				return plan
+			},
+			ExpectedInsecureValue: types.StringUnknown(),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+		{
+			Name:                  "create",
+			Plan:                  func(plan ParameterResourceModel) ParameterResourceModel { return plan },
+			Create:                true,
+			ExpectedInsecureValue: types.StringValue("v"),
+			ExpectedVersion:       types.Int64Unknown(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := plannedComputedValues(testCase.Plan(plan), state, testCase.InsecureValueConfigured, !testCase.Create)
+
+			if !got.InsecureValue.Equal(testCase.ExpectedInsecureValue) {
+				t.Errorf("expected insecure_value %s, got %s", testCase.ExpectedInsecureValue, got.InsecureValue)
+			}
+			if !got.Version.Equal(testCase.ExpectedVersion) {
+				t.Errorf("expected version %s, got %s", testCase.ExpectedVersion, got.Version)
+			}
+		})
+	}
+}
+
+func TestReadAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	}), defaultParameterBatchOptions)
+	ctx := context.Background()
+
+	put := func(value string) int64 {
+		output, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/config"), Value: aws.String(value), Type: ssm_types.ParameterTypeString, Overwrite: aws.Bool(true)})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return output.Version
+	}
+
+	// Not found right after the creation
+	created := put("one")
+	server.SetStaleReads(1)
+	res, err := client.readAfterWrite(ctx, "/app/config", created, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.Version != created {
+		t.Errorf("expected version %d, got %d", created, res.Version)
+	}
+
+	// The previous version right after the update
+	updated := put("two")
+	server.SetStaleReads(2)
+	res, err = client.readAfterWrite(ctx, "/app/config", updated, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res.Version != updated || aws.ToString(res.Value) != "two" {
+		t.Errorf("expected version %d with value two, got %d with %s", updated, res.Version, aws.ToString(res.Value))
+	}
+	if got := server.Calls()["GetParameter"]; got != 5 {
+		t.Errorf("expected 5 GetParameter calls, got %d", got)
+	}
+}
+
+func TestReadAfterWriteDisableRetries(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:           "eu-west-1",
+		BaseEndpoint:     aws.String(server.URL),
+		Credentials:      staticCredentials{accessKey: "test", secretKey: "test"},
+		RetryMaxAttempts: 1,
+	}), defaultParameterBatchOptions)
+	client.disableRetries = true
+	ctx := context.Background()
+
+	output, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/config"), Value: aws.String("one"), Type: ssm_types.ParameterTypeString})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server.SetThrottling(1)
+	start := time.Now()
+	_, err = client.readAfterWrite(ctx, "/app/config", output.Version, true)
+	if !isThrottlingError(err) {
+		t.Fatalf("expected a throttling error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= throttleBackoff {
+		t.Errorf("expected an immediate failure, took %s", elapsed)
+	}
+	if got := server.Calls()["GetParameter"]; got != 1 {
+		t.Errorf("expected a single GetParameter call, got %d", got)
+	}
+}
+
+func TestReadAfterWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	}), defaultParameterBatchOptions)
+	client.timeouts.getParameter = 100 * time.Millisecond
+	ctx := context.Background()
+
+	output, err := client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/config"), Value: aws.String("one"), Type: ssm_types.ParameterTypeString})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Never readable within the timeout
+	server.SetStaleReads(100)
+	start := time.Now()
+	if _, err := client.readAfterWrite(ctx, "/app/config", output.Version, true); err == nil {
+		t.Fatal("expected error, got none")
+	}
+	if elapsed := time.Since(start); elapsed >= defaultOperationTimeouts.getParameter {
+		t.Errorf("expected the get_parameter timeout to apply, took %s", elapsed)
+	}
+}
+
+func TestCheckExpectedVersion(t *testing.T) {
+	t.Parallel()
+
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	r := &ParameterResource{client: newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	}), defaultParameterBatchOptions)}
+	ctx := context.Background()
+
+	for _, value := range []string{"one", "two"} {
+		_, err := r.client.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String("/app/config"), Value: aws.String(value), Type: ssm_types.ParameterTypeString, Overwrite: aws.Bool(true)})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if diags := r.checkExpectedVersion(ctx, "/app/config", types.Int64Value(2)); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	// Written by another process since
+	diags := r.checkExpectedVersion(ctx, "/app/config", types.Int64Value(1))
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "is at version 2, the plan was made against version 1") {
+		t.Errorf("expected a drift error, got: %v", diags)
+	}
+
+	if diags := r.checkExpectedVersion(ctx, "/app/missing", types.Int64Value(1)); !diags.HasError() {
+		t.Errorf("expected an error for a deleted parameter")
+	}
+}
+
+func TestMetadataMayHaveChanged(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Version  types.Int64
+		Expected bool
+	}{
+		{Name: "same version", Version: types.Int64Value(3)},
+		// Whether the value changed along or not
+		{Name: "other version", Version: types.Int64Value(2), Expected: true},
+		{Name: "imported", Version: types.Int64Null(), Expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			state := ParameterResourceModel{Version: testCase.Version}
+			if got := metadataMayHaveChanged(state, &ssm_types.Parameter{Version: 3}); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRefreshMetadata(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name                   string
+		Description            types.String
+		Metadata               ssm_types.ParameterMetadata
+		ExpectedDescription    types.String
+		ExpectedAllowedPattern types.String
+	}{
+		{
+			Name:                   "changed outside Terraform",
+			Description:            types.StringValue("first"),
+			Metadata:               ssm_types.ParameterMetadata{Description: aws.String("second"), AllowedPattern: aws.String("^[a-z]+$")},
+			ExpectedDescription:    types.StringValue("second"),
+			ExpectedAllowedPattern: types.StringValue("^[a-z]+$"),
+		},
+		{
+			Name:                   "removed outside Terraform",
+			Description:            types.StringValue("first"),
+			ExpectedDescription:    types.StringValue(""),
+			ExpectedAllowedPattern: types.StringNull(),
+		},
+		{
+			Name:                   "never set",
+			Description:            types.StringNull(),
+			ExpectedDescription:    types.StringNull(),
+			ExpectedAllowedPattern: types.StringNull(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			data := ParameterResourceModel{Description: testCase.Description, AllowedPattern: types.StringNull()}
+			refreshMetadata(&data, &testCase.Metadata)
+
+			if !data.Description.Equal(testCase.ExpectedDescription) {
+				t.Errorf("expected description %s, got %s", testCase.ExpectedDescription, data.Description)
+			}
+			if !data.AllowedPattern.Equal(testCase.ExpectedAllowedPattern) {
+				t.Errorf("expected allowed_pattern %s, got %s", testCase.ExpectedAllowedPattern, data.AllowedPattern)
+			}
+		})
+	}
+}
+
+// testPrivateState is a private state of the framework, keys are set as they are.
+type testPrivateState map[string][]byte
+
+func (s testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	s[key] = value
+	return nil
+}
+
+func TestMetadataRefreshDue(t *testing.T) {
+	t.Parallel()
+
+	refreshedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Name     string
+		Interval types.String
+		Private  testPrivateState
+		Now      time.Time
+		Expected bool
+	}{
+		{Name: "no interval", Interval: types.StringNull(), Private: testPrivateState{}, Now: refreshedAt.Add(48 * time.Hour)},
+		{Name: "never refreshed", Interval: types.StringValue("24h"), Private: testPrivateState{}, Now: refreshedAt, Expected: true},
+		{Name: "within the interval", Interval: types.StringValue("24h"), Now: refreshedAt.Add(23 * time.Hour)},
+		{Name: "interval elapsed", Interval: types.StringValue("24h"), Now: refreshedAt.Add(24 * time.Hour), Expected: true},
+		{
+			Name:     "unreadable time",
+			Interval: types.StringValue("24h"),
+			Private:  testPrivateState{metadataRefreshedAtKey: []byte(`"yesterday"`)},
+			Now:      refreshedAt,
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			data := ParameterResourceModel{MetadataRefreshInterval: testCase.Interval}
+			private := testCase.Private
+			if private == nil {
+				private = testPrivateState{}
+				if diags := recordMetadataRefresh(context.Background(), data, private, refreshedAt); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+			}
+
+			due, diags := metadataRefreshDue(context.Background(), data, private, testCase.Now)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if due != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, due)
+			}
+		})
+	}
+}
+
+func TestRecordMetadataRefreshWithoutInterval(t *testing.T) {
+	t.Parallel()
+
+	private := testPrivateState{}
+	data := ParameterResourceModel{MetadataRefreshInterval: types.StringNull()}
+	if diags := recordMetadataRefresh(context.Background(), data, private, time.Now()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(private) != 0 {
+		t.Errorf("expected no private state without interval, got %v", private)
+	}
+}
+
+func TestInsecureValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Type     ssm_types.ParameterType
+		Expected types.String
+	}{
+		{Name: "String", Type: ssm_types.ParameterTypeString, Expected: types.StringValue("v")},
+		{Name: "StringList", Type: ssm_types.ParameterTypeStringList, Expected: types.StringValue("v")},
+		{Name: "SecureString", Type: ssm_types.ParameterTypeSecureString, Expected: types.StringNull()},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := insecureValue(&ssm_types.Parameter{Type: testCase.Type, Value: aws.String("v")})
+			if !got.Equal(testCase.Expected) {
+				t.Errorf("expected %s, got %s", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestIsRetryableErrorCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		Name string
+		Err  error
+	}{
+		{Name: "throttling", Err: &smithy.GenericAPIError{Code: "ThrottlingException"}},
+		{Name: "retry quota", Err: ratelimit.QuotaExceededError{}},
+		{Name: "transient", Err: &smithy.GenericAPIError{Code: "InternalServerError"}},
+		{Name: "kms throttling", Err: &smithy.GenericAPIError{Code: "KMSThrottlingException"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			start := time.Now()
+			if isRetryableError(ctx, testCase.Err) {
+				t.Errorf("unexpected retry of a cancelled call")
+			}
+			if elapsed := time.Since(start); elapsed >= throttleBackoff {
+				t.Errorf("expected an immediate return, took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name       string
+		Err        error
+		ExtraCodes []string
+		Expected   bool
+	}{
+		{Name: "internal server error", Err: &smithy.GenericAPIError{Code: "InternalServerError"}, Expected: true},
+		{Name: "request timeout", Err: fmt.Errorf("permanent failure: %w", &smithy.GenericAPIError{Code: "RequestTimeout"}), Expected: true},
+		{Name: "connection reset", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, Expected: true},
+		{Name: "unexpected EOF", Err: fmt.Errorf("reading the response: %w", io.ErrUnexpectedEOF), Expected: true},
+		{Name: "validation", Err: &smithy.GenericAPIError{Code: "ValidationException"}},
+		{Name: "extra code", Err: &smithy.GenericAPIError{Code: "HierarchyLevelLimitExceededException"}, ExtraCodes: []string{"HierarchyLevelLimitExceededException"}, Expected: true},
+		{Name: "throttling", Err: &smithy.GenericAPIError{Code: "ThrottlingException"}},
+		{Name: "kms throttling", Err: &smithy.GenericAPIError{Code: "InternalServerError", Message: "KMS: Rate exceeded"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isTransientError(testCase.Err, testCase.ExtraCodes); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestParameterResourceModifyPlanReplace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewParameterResource().(*ParameterResource)
+	r.client = newFastSSMClient(ssm.New(ssm.Options{Region: "eu-west-1"}), defaultParameterBatchOptions)
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	object := func(value string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attributeType := range typ.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "/app/config")
+		values["type"] = tftypes.NewValue(tftypes.String, "String")
+		values["value"] = tftypes.NewValue(tftypes.String, value)
+
+		return tftypes.NewValue(typ, values)
+	}
+	plan := func(prior, config tftypes.Value) diag.Diagnostics {
+		resp := &fwresource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: config}}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+			Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: config},
+			State:  tfsdk.State{Schema: schemaResp.Schema, Raw: prior},
+		}, resp)
+
+		return resp.Diagnostics
+	}
+
+	// Terraform plans a replacement with the prior state, then without
+	if diags := plan(object("one"), object("two")); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := plan(tftypes.NewValue(typ, nil), object("two")); diags.HasError() {
+		t.Fatalf("unexpected error planning the replacement again: %v", diags)
+	}
+
+	// Another resource of the same name
+	if diags := plan(tftypes.NewValue(typ, nil), object("three")); !diags.HasError() {
+		t.Errorf("expected the duplicate name to fail the plan")
+	}
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ParameterRollbackAction{}
+var _ action.ActionWithConfigure = &ParameterRollbackAction{}
+
+func NewParameterRollbackAction() action.Action {
+	return &ParameterRollbackAction{}
+}
+
+// ParameterRollbackAction restores the value of an older version as the latest one.
+type ParameterRollbackAction struct {
+	client *FastSSMClient
+}
+
+// ParameterRollbackActionModel describes the action data model.
+type ParameterRollbackActionModel struct {
+	Label   types.String `tfsdk:"label"`
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+}
+
+func (a *ParameterRollbackAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_rollback"
+}
+
+func (a *ParameterRollbackAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rolls an SSM parameter back to an older version, selected by `version` or `label`. " +
+			"SSM history can't be rewritten, so the old value is written again and becomes a new version. " +
+			"The description, allowed pattern, tier and KMS key of the parameter are kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"label": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("version")),
+				},
+				Description: "Label of the version to roll back to.",
+			},
+			names.AttrName: schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the parameter.",
+			},
+			names.AttrVersion: schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Version to roll back to.",
+			},
+		},
+	}
+}
+
+func (a *ParameterRollbackAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *ParameterRollbackAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterRollbackActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selector := parameterSelector(a.client.parameterName(data.Name.ValueString()), data.Version, data.Label)
+
+	var res = &ssm_types.Parameter{}
+	var erri error
+	err := retry.RetryContext(ctx, a.client.timeouts.getParameter, func() *retry.RetryError {
+		res, erri = findParameterByName(ctx, a.client.Client, selector, true)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("parameter get failed", fmt.Sprintf("Couldn't get SSM Parameter %q: %s", selector, describeError(err)))
+		return
+	}
+
+	// GetParameter doesn't return the description, allowed pattern, tier and key of the
+	// parameter, which the overwrite would reset, re-encrypting with the default key
+	name := a.client.parameterName(data.Name.ValueString())
+	var metadata = &ssm_types.ParameterMetadata{}
+	err = retry.RetryContext(ctx, a.client.timeouts.describeParameters, func() *retry.RetryError {
+		metadata, erri = findParameterMetadataByName(ctx, a.client.Client, name)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("parameter describe failed", fmt.Sprintf("Couldn't describe SSM Parameter %q: %s", name, describeError(err)))
+		return
+	}
+
+	overwrite := true
+	input := rollbackInput(name, res, metadata)
+	input.Overwrite = &overwrite
+
+	var result = &ssm.PutParameterOutput{}
+	err = retry.RetryContext(ctx, a.client.timeouts.putParameter, func() *retry.RetryError {
+		result, erri = a.client.PutParameter(ctx, input)
+		if erri != nil {
+			if a.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	a.client.forgetParameter(name)
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM parameter rollback error", fmt.Sprintf("rolling back SSM Parameter (%s): %s", data.Name.String(), describeError(err)))
+		return
+	}
+
+	resp.Diagnostics.Append(a.client.audit.putParameter(a.client.Options().Region, *input.Name, result.Version)...)
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("rolled %s back to version %d, now at version %d", data.Name.ValueString(), res.Version, result.Version),
+	})
+}
+
+// rollbackInput writes the value of the version res again, keeping the current metadata
+// of the parameter: its description, allowed pattern, tier and, when the version rolled
+// back to is a SecureString as well, its key.
+func rollbackInput(name string, res *ssm_types.Parameter, metadata *ssm_types.ParameterMetadata) *ssm.PutParameterInput {
+	input := &ssm.PutParameterInput{
+		Name:           aws.String(name),
+		Value:          res.Value,
+		Type:           res.Type,
+		DataType:       res.DataType,
+		Description:    metadata.Description,
+		AllowedPattern: metadata.AllowedPattern,
+		Tier:           metadata.Tier,
+	}
+
+	if res.Type == ssm_types.ParameterTypeSecureString && metadata.Type == ssm_types.ParameterTypeSecureString {
+		input.KeyId = metadata.KeyId
+	}
+
+	return input
+}
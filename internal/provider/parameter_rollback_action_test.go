@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccActionsVersion is the first Terraform version invoking actions.
+var testAccActionsVersion = version.Must(version.NewVersion("1.14.0"))
+
+func TestAccParameterRollbackAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(testAccActionsVersion),
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccPutVersions(t, "/fastssm/acctest/rollback", "one", "two") },
+				Config:    testAccParameterRollbackActionConfig("/fastssm/acctest/rollback", "version = 1"),
+				Check:     testAccCheckRolledBack("/fastssm/acctest/rollback", "one", 3),
+			},
+		},
+	})
+}
+
+func TestAccParameterRollbackAction_label(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(testAccActionsVersion),
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccPutVersions(t, "/fastssm/acctest/rollback-label", "one", "two", "three")
+					testAccLabelVersion(t, "/fastssm/acctest/rollback-label", 2, "stable")
+				},
+				Config: testAccParameterRollbackActionConfig("/fastssm/acctest/rollback-label", `label = "stable"`),
+				Check:  testAccCheckRolledBack("/fastssm/acctest/rollback-label", "two", 4),
+			},
+		},
+	})
+}
+
+// testAccPutVersions writes a version of the parameter per value, with a description.
+func testAccPutVersions(t *testing.T, name string, values ...string) {
+	ctx := context.Background()
+	conn, err := testAccSSMClient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range values {
+		if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{
+			Name:        aws.String(name),
+			Value:       aws.String(value),
+			Type:        ssm_types.ParameterTypeString,
+			Description: aws.String("rolled back"),
+			Overwrite:   aws.Bool(true),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(func() {
+		_, _ = conn.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String(name)})
+	})
+}
+
+func testAccLabelVersion(t *testing.T, name string, version int64, label string) {
+	ctx := context.Background()
+	conn, err := testAccSSMClient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.LabelParameterVersion(ctx, &ssm.LabelParameterVersionInput{Name: aws.String(name), Labels: []string{label}, ParameterVersion: aws.Int64(version)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testAccCheckRolledBack checks the parameter got value as a new version, keeping its description.
+func testAccCheckRolledBack(name, value string, version int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		conn, err := testAccSSMClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		metadata, err := findParameterMetadataByName(ctx, conn, name)
+		if err != nil {
+			return err
+		}
+		if metadata.Version != version || aws.ToString(metadata.Description) != "rolled back" {
+			return fmt.Errorf("expected version %d with its description, got version %d described %q", version, metadata.Version, aws.ToString(metadata.Description))
+		}
+
+		res, err := findParameterByName(ctx, conn, name, true)
+		if err != nil {
+			return err
+		}
+		if aws.ToString(res.Value) != value {
+			return fmt.Errorf("expected the value %q, got %q", value, aws.ToString(res.Value))
+		}
+
+		return nil
+	}
+}
+
+func testAccParameterRollbackActionConfig(name, selector string) string {
+	return fmt.Sprintf(`
+action "fastssm_parameter_rollback" "test" {
+  config {
+    name = %[1]q
+    %[2]s
+  }
+}
+
+resource "terraform_data" "trigger" {
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.fastssm_parameter_rollback.test]
+    }
+  }
+}
+`, name, selector)
+}
+
+// invokeTestAction runs the action configured with values, the attributes left out
+// being null, with client as the provider data. It returns the progress messages.
+func invokeTestAction(t *testing.T, a action.Action, client *FastSSMClient, values map[string]tftypes.Value) (*action.InvokeResponse, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	config := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attributeType := range typ.AttributeTypes {
+		config[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			config[name] = value
+		}
+	}
+
+	var configureResp action.ConfigureResponse
+	a.(action.ActionWithConfigure).Configure(ctx, action.ConfigureRequest{ProviderData: client}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", configureResp.Diagnostics)
+	}
+
+	var messages []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) { messages = append(messages, event.Message) },
+	}
+	a.Invoke(ctx, action.InvokeRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, config)},
+	}, resp)
+
+	return resp, messages
+}
+
+// newTestActionClient is a client of a fake backend holding two versions of the
+// SecureString /app/image, encrypted with alias/app, the first labeled stable.
+func newTestActionClient(t *testing.T) (*FastSSMClient, *fakessm.Server) {
+	t.Helper()
+
+	server := fakessm.NewServer()
+	t.Cleanup(server.Close)
+
+	client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	}), defaultParameterBatchOptions)
+	ctx := context.Background()
+
+	for _, value := range []string{"one", "two"} {
+		if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{
+			Name:           aws.String("/app/image"),
+			Value:          aws.String(value),
+			Type:           ssm_types.ParameterTypeSecureString,
+			KeyId:          aws.String("alias/app"),
+			Description:    aws.String("image"),
+			AllowedPattern: aws.String("^[a-z]+$"),
+			Tier:           ssm_types.ParameterTierAdvanced,
+			Overwrite:      aws.Bool(true),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.LabelParameterVersion(ctx, &ssm.LabelParameterVersionInput{Name: aws.String("/app/image"), Labels: []string{"stable"}, ParameterVersion: aws.Int64(1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	return client, server
+}
+
+func TestParameterRollbackActionInvoke(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Version     tftypes.Value
+		Label       tftypes.Value
+		ExpectError bool
+	}{
+		{
+			Name:    "version",
+			Version: tftypes.NewValue(tftypes.Number, 1),
+			Label:   tftypes.NewValue(tftypes.String, nil),
+		},
+		{
+			Name:    "label",
+			Version: tftypes.NewValue(tftypes.Number, nil),
+			Label:   tftypes.NewValue(tftypes.String, "stable"),
+		},
+		{
+			Name:        "missing version",
+			Version:     tftypes.NewValue(tftypes.Number, 7),
+			Label:       tftypes.NewValue(tftypes.String, nil),
+			ExpectError: true,
+		},
+		{
+			Name:        "missing label",
+			Version:     tftypes.NewValue(tftypes.Number, nil),
+			Label:       tftypes.NewValue(tftypes.String, "unknown"),
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			client, _ := newTestActionClient(t)
+			resp, messages := invokeTestAction(t, NewParameterRollbackAction(), client, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, "/app/image"),
+				"version": testCase.Version,
+				"label":   testCase.Label,
+			})
+
+			if testCase.ExpectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if len(messages) != 1 {
+				t.Errorf("expected a progress message, got %v", messages)
+			}
+
+			ctx := context.Background()
+			res, err := findParameterByName(ctx, client.Client, "/app/image", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Version != 3 || aws.ToString(res.Value) != "one" {
+				t.Errorf("expected version 3 with value one, got version %d with %s", res.Version, aws.ToString(res.Value))
+			}
+
+			// The overwrite keeps the metadata, and the key, of the parameter
+			metadata, err := findParameterMetadataByName(ctx, client.Client, "/app/image")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if aws.ToString(metadata.KeyId) != "alias/app" {
+				t.Errorf("expected the key alias/app, got %s", aws.ToString(metadata.KeyId))
+			}
+			if aws.ToString(metadata.Description) != "image" || aws.ToString(metadata.AllowedPattern) != "^[a-z]+$" || metadata.Tier != ssm_types.ParameterTierAdvanced {
+				t.Errorf("expected the metadata to be kept, got %+v", metadata)
+			}
+		})
+	}
+}
+
+func TestRollbackInput(t *testing.T) {
+	t.Parallel()
+
+	metadata := &ssm_types.ParameterMetadata{
+		Type:        ssm_types.ParameterTypeSecureString,
+		KeyId:       aws.String("alias/app"),
+		Description: aws.String("image"),
+		Tier:        ssm_types.ParameterTierStandard,
+	}
+
+	testCases := []struct {
+		Name          string
+		Type          ssm_types.ParameterType
+		ExpectedKeyID *string
+	}{
+		{Name: "SecureString", Type: ssm_types.ParameterTypeSecureString, ExpectedKeyID: aws.String("alias/app")},
+		// Encrypted since, the old version is written as it was
+		{Name: "String", Type: ssm_types.ParameterTypeString},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			input := rollbackInput("/app/image", &ssm_types.Parameter{Value: aws.String("one"), Type: testCase.Type}, metadata)
+			if aws.ToString(input.KeyId) != aws.ToString(testCase.ExpectedKeyID) {
+				t.Errorf("expected key %q, got %q", aws.ToString(testCase.ExpectedKeyID), aws.ToString(input.KeyId))
+			}
+			if input.Type != testCase.Type || aws.ToString(input.Description) != "image" || input.Tier != ssm_types.ParameterTierStandard {
+				t.Errorf("unexpected input %+v", input)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"terraform-provider-fastssm/internal/retry"
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parameterTargets implements the resources writing the same parameter to several
+// targets in parallel, each refreshed independently: the regions of
+// fastssm_parameter_replication and the accounts of fastssm_parameter_fanout. The
+// resources only define their schema, and how to reach and audit a target.
+type parameterTargets struct {
+	client *FastSSMClient
+	// label names a target in the messages, e.g. "region"
+	label string
+	// audit returns the audit log of the writes to target, and their region
+	audit func(target string) (*auditLog, string)
+}
+
+// targetClients returns the SSM client of a target, as configured by a plan or a state.
+type targetClients func(target string) *ssm.Client
+
+// parameterTargetsModel holds the attributes shared by the resources of
+// parameterTargets, embedded in their models next to the set of targets.
+type parameterTargetsModel struct {
+	Description types.String `tfsdk:"description"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Value       types.String `tfsdk:"value"`
+	Versions    types.Map    `tfsdk:"versions"`
+}
+
+// create writes the planned parameter to the targets, narrowed down to the ones written.
+func (t *parameterTargets) create(ctx context.Context, data *parameterTargetsModel, targets *types.Set, clients targetClients) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planned []string
+	diags.Append(targets.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	versions, errs := t.putParameter(ctx, data, planned, nil, clients)
+	diags.Append(t.auditPuts(t.client.parameterName(data.Name.ValueString()), versions)...)
+	diags.Append(t.setState(ctx, data, targets, versions, errs)...)
+
+	return diags
+}
+
+// read refreshes the parameter in every target, narrowing the targets down to the
+// ones holding it as in state. The ones holding a changed copy are recorded in private.
+func (t *parameterTargets) read(ctx context.Context, data *parameterTargetsModel, targets *types.Set, clients targetClients, private privateState) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var existing []string
+	diags.Append(targets.ElementsAs(ctx, &existing, false)...)
+	known := make(map[string]int64, len(existing))
+	diags.Append(data.Versions.ElementsAs(ctx, &known, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	versions, drifted, errs := t.readParameter(ctx, data, existing, known, clients)
+	diags.Append(t.setState(ctx, data, targets, versions, errs)...)
+	diags.Append(recordDriftedTargets(ctx, private, drifted)...)
+
+	return diags
+}
+
+// update writes the parameter to the targets new to the resource, or to all of them
+// when the parameter changed, and removes it from the targets dropped from the plan,
+// reached as configured in the state.
+func (t *parameterTargets) update(ctx context.Context, plan *parameterTargetsModel, targets *types.Set, state parameterTargetsModel, stateTargets types.Set, planClients, stateClients targetClients, private privateState) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planned, existing []string
+	diags.Append(targets.ElementsAs(ctx, &planned, false)...)
+	diags.Append(stateTargets.ElementsAs(ctx, &existing, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Only targets that are new need a write, unless the parameter itself changed
+	toWrite := planned
+	if plan.Value.Equal(state.Value) && plan.Type.Equal(state.Type) && plan.Description.Equal(state.Description) {
+		toWrite = nil
+		for _, target := range planned {
+			if !slices.Contains(existing, target) {
+				toWrite = append(toWrite, target)
+			}
+		}
+	}
+
+	var toDelete []string
+	for _, target := range existing {
+		if !slices.Contains(planned, target) {
+			toDelete = append(toDelete, target)
+		}
+	}
+
+	versions := make(map[string]int64, len(planned))
+	diags.Append(state.Versions.ElementsAs(ctx, &versions, false)...)
+	deleted := make(map[string]int64, len(toDelete))
+	for _, target := range toDelete {
+		deleted[target] = versions[target]
+		delete(versions, target)
+	}
+
+	// A target that's new to the resource is written like on create, without
+	// overwriting a parameter of the same name it already held. The ones holding
+	// a copy, changed outside Terraform or not, are overwritten.
+	drifted, d := driftedTargets(ctx, private)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	overwritten := slices.Concat(existing, drifted)
+
+	name := t.client.parameterName(plan.Name.ValueString())
+	written, errs := t.putParameter(ctx, plan, toWrite, overwritten, planClients)
+	diags.Append(t.auditPuts(name, written)...)
+	for target, version := range written {
+		versions[target] = version
+	}
+
+	deleteErrs := t.deleteParameter(ctx, name, toDelete, stateClients)
+	diags.Append(t.auditDeletes(name, deleted, deleteErrs)...)
+	for target, err := range deleteErrs {
+		// Keep the target in state, the next apply will try to remove it again
+		versions[target] = 0
+		errs[target] = err
+	}
+
+	diags.Append(t.setState(ctx, plan, targets, versions, errs)...)
+	// Left in state, the targets of the failed writes are found drifted again by the next refresh
+	diags.Append(recordDriftedTargets(ctx, private, nil)...)
+
+	return diags
+}
+
+// delete removes the parameter from every target.
+func (t *parameterTargets) delete(ctx context.Context, data parameterTargetsModel, targets types.Set, clients targetClients) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var existing []string
+	diags.Append(targets.ElementsAs(ctx, &existing, false)...)
+	versions := make(map[string]int64, len(existing))
+	diags.Append(data.Versions.ElementsAs(ctx, &versions, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	name := t.client.parameterName(data.Name.ValueString())
+	errs := t.deleteParameter(ctx, name, existing, clients)
+	for _, target := range slices.Sorted(maps.Keys(errs)) {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete ssm parameter in %s %s, got error: %s", t.label, target, describeError(errs[target])))
+	}
+	diags.Append(t.auditDeletes(name, versions, errs)...)
+
+	return diags
+}
+
+// readParameter reads the parameter in every given target in parallel, known holding
+// the versions in state. It returns the version per target holding the parameter as
+// planned, and the targets holding a changed copy. A target that lost its copy is
+// neither.
+func (t *parameterTargets) readParameter(ctx context.Context, data *parameterTargetsModel, targets []string, known map[string]int64, clients targetClients) (map[string]int64, []string, map[string]error) {
+	var mu sync.Mutex
+	versions := make(map[string]int64, len(targets))
+	var drifted []string
+
+	errs := forEachTarget(targets, func(target string) error {
+		client := clients(target)
+
+		var res *ssm_types.Parameter
+		err := retry.RetryContext(ctx, t.client.timeouts.getParameter, func() *retry.RetryError {
+			var erri error
+			res, erri = findParameterByName(ctx, client, t.client.parameterName(data.Name.ValueString()), true)
+			if erri != nil {
+				if t.client.isRetryableError(ctx, erri) {
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
+
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+			}
+
+			return nil
+		})
+
+		// A target that lost its copy, or whose copy changed, falls out of the
+		// targets in state so the next plan writes it again.
+		if tfresource.NotFound(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		same := aws.ToString(res.Value) == data.Value.ValueString() && string(res.Type) == data.Type.ValueString()
+		// The description is only returned by the expensive DescribeParameters call,
+		// made when the version changed, as changing the description writes one.
+		if same && res.Version != known[target] {
+			var md *ssm_types.ParameterMetadata
+			err := retry.RetryContext(ctx, t.client.timeouts.describeParameters, func() *retry.RetryError {
+				var erri error
+				md, erri = findParameterMetadataByName(ctx, client, *res.Name)
+				if erri != nil {
+					if t.client.isRetryableError(ctx, erri) {
+						return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+					}
+
+					return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			same = aws.ToString(md.Description) == data.Description.ValueString()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if same {
+			versions[target] = res.Version
+		} else {
+			drifted = append(drifted, target)
+		}
+
+		return nil
+	})
+
+	return versions, drifted, errs
+}
+
+// putParameter writes the planned parameter to every given target in parallel and
+// returns the version written per target, along with the per-target failures. The
+// parameter is only overwritten in the overwritten targets, it fails to be created
+// in the other ones already holding it.
+func (t *parameterTargets) putParameter(ctx context.Context, data *parameterTargetsModel, targets, overwritten []string, clients targetClients) (map[string]int64, map[string]error) {
+	var mu sync.Mutex
+	versions := make(map[string]int64, len(targets))
+
+	errs := forEachTarget(targets, func(target string) error {
+		client := clients(target)
+		val := data.Value.ValueString()
+		overwrite := slices.Contains(overwritten, target)
+
+		input := &ssm.PutParameterInput{
+			Name:        aws.String(t.client.parameterName(data.Name.ValueString())),
+			Value:       &val,
+			Type:        ssm_types.ParameterType(data.Type.ValueString()),
+			Description: data.Description.ValueStringPointer(),
+			Overwrite:   &overwrite,
+		}
+
+		var result *ssm.PutParameterOutput
+		err := retry.RetryContext(ctx, t.client.timeouts.putParameter, func() *retry.RetryError {
+			var erri error
+			result, erri = client.PutParameter(ctx, input)
+			if erri != nil {
+				if t.client.isRetryableError(ctx, erri) {
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
+
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		versions[target] = result.Version
+		mu.Unlock()
+
+		return nil
+	})
+
+	// One of the targets may be the provider's own region and account
+	t.client.forgetParameter(t.client.parameterName(data.Name.ValueString()))
+
+	return versions, errs
+}
+
+// deleteParameter removes the parameter from every given target in parallel.
+// A target where the parameter is already gone is not a failure.
+func (t *parameterTargets) deleteParameter(ctx context.Context, name string, targets []string, clients targetClients) map[string]error {
+	defer t.client.forgetParameter(name)
+
+	return forEachTarget(targets, func(target string) error {
+		client := clients(target)
+
+		return retry.RetryContext(ctx, t.client.timeouts.deleteParameter, func() *retry.RetryError {
+			_, erri := client.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: &name})
+
+			var notfound *ssm_types.ParameterNotFound
+			if errors.As(erri, &notfound) {
+				return nil
+			}
+
+			if erri != nil {
+				if t.client.isRetryableError(ctx, erri) {
+					return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+				}
+
+				return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+			}
+
+			return nil
+		})
+	})
+}
+
+// auditPuts records the versions written per target in the audit log.
+func (t *parameterTargets) auditPuts(name string, versions map[string]int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, target := range slices.Sorted(maps.Keys(versions)) {
+		audit, region := t.audit(target)
+		diags.Append(audit.putParameter(region, name, versions[target])...)
+	}
+
+	return diags
+}
+
+// auditDeletes records the deletions in the audit log, of the targets of versions
+// which didn't fail.
+func (t *parameterTargets) auditDeletes(name string, versions map[string]int64, errs map[string]error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, target := range slices.Sorted(maps.Keys(versions)) {
+		if _, failed := errs[target]; !failed {
+			audit, region := t.audit(target)
+			diags.Append(audit.deleteParameter(region, name, versions[target])...)
+		}
+	}
+
+	return diags
+}
+
+// setState narrows the targets down to the ones holding a known version and reports
+// any per-target failure.
+func (t *parameterTargets) setState(ctx context.Context, data *parameterTargetsModel, targets *types.Set, versions map[string]int64, errs map[string]error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, target := range slices.Sorted(maps.Keys(errs)) {
+		diags.AddError("SSM parameter write error", fmt.Sprintf("writing SSM Parameter (%s) to %s %s: %s", data.Name.String(), t.label, target, describeError(errs[target])))
+	}
+
+	targetSet, d := types.SetValueFrom(ctx, types.StringType, slices.Sorted(maps.Keys(versions)))
+	diags.Append(d...)
+	versionMap, d := types.MapValueFrom(ctx, types.Int64Type, versions)
+	diags.Append(d...)
+
+	*targets = targetSet
+	data.Versions = versionMap
+
+	return diags
+}
+
+const driftedTargetsKey = "drifted_targets"
+
+// driftedTargets returns the targets found holding a changed copy of the parameter
+// by the last refresh, recorded by recordDriftedTargets.
+func driftedTargets(ctx context.Context, private privateState) ([]string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, driftedTargetsKey)
+	if diags.HasError() || value == nil {
+		return nil, diags
+	}
+
+	var targets []string
+	if err := json.Unmarshal(value, &targets); err != nil {
+		diags.AddError("Private state error", fmt.Sprintf("decoding the drifted targets: %s", err))
+	}
+
+	return targets, diags
+}
+
+// recordDriftedTargets stores the targets holding a changed copy of the parameter.
+// They're dropped from the state so the next plan writes them again, overwriting
+// the copy, unlike in the targets new to the resource.
+func recordDriftedTargets(ctx context.Context, private privateState, targets []string) diag.Diagnostics {
+	if len(targets) == 0 {
+		// An empty value removes the key
+		return private.SetKey(ctx, driftedTargetsKey, nil)
+	}
+	sort.Strings(targets)
+
+	// Private state values must be JSON
+	value, err := json.Marshal(targets)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Private state error", fmt.Sprintf("encoding the drifted targets: %s", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, driftedTargetsKey, value)
+}
+
+// forEachTarget runs fn for every target, region or role, concurrently and returns the
+// failures keyed by target.
+func forEachTarget(targets []string, fn func(target string) error) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+
+			if err := fn(target); err != nil {
+				mu.Lock()
+				errs[target] = err
+				mu.Unlock()
+			}
+		}(target)
+	}
+
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newTestReplicationResource is a fastssm_parameter_replication of a fake backend,
+// with a put helper writing a parameter to a region of it.
+func newTestReplicationResource(t *testing.T) (*ParameterReplicationResource, func(region, value, description string)) {
+	t.Helper()
+
+	server := fakessm.NewServer()
+	t.Cleanup(server.Close)
+
+	client := newFastSSMClient(ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	}), defaultParameterBatchOptions)
+
+	put := func(region, value, description string) {
+		if _, err := regionalClient(client.Client, region).PutParameter(context.Background(), &ssm.PutParameterInput{
+			Name:        aws.String("/app/config"),
+			Value:       aws.String(value),
+			Type:        ssm_types.ParameterTypeString,
+			Description: aws.String(description),
+			Overwrite:   aws.Bool(true),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &ParameterReplicationResource{}
+	var resp resource.ConfigureResponse
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	return r, put
+}
+
+func TestParameterTargetsReadParameter(t *testing.T) {
+	t.Parallel()
+
+	r, put := newTestReplicationResource(t)
+
+	// Version 1 everywhere, as in state
+	regions := []string{"eu-west-1", "eu-central-1", "us-east-1", "us-west-2"}
+	for _, region := range regions[:3] {
+		put(region, "one", "config")
+	}
+	// Changed outside Terraform: the value, and the description only
+	put("eu-central-1", "two", "config")
+	put("us-east-1", "one", "changed")
+
+	data := &parameterTargetsModel{
+		Name:        types.StringValue("/app/config"),
+		Type:        types.StringValue("String"),
+		Value:       types.StringValue("one"),
+		Description: types.StringValue("config"),
+	}
+	known := map[string]int64{"eu-west-1": 1, "eu-central-1": 1, "us-east-1": 1, "us-west-2": 1}
+
+	versions, drifted, errs := r.targets.readParameter(context.Background(), data, regions, known, r.regionClient)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if diff := cmp.Diff(versions, map[string]int64{"eu-west-1": 1}); diff != "" {
+		t.Errorf("unexpected difference in the versions: %s", diff)
+	}
+	// us-west-2 lost its copy, it isn't drifted
+	slices.Sort(drifted)
+	if diff := cmp.Diff(drifted, []string{"eu-central-1", "us-east-1"}); diff != "" {
+		t.Errorf("unexpected difference in the drifted regions: %s", diff)
+	}
+}
+
+func TestParameterTargetsPutParameter(t *testing.T) {
+	t.Parallel()
+
+	r, put := newTestReplicationResource(t)
+
+	// A parameter of the same name, not managed by the resource
+	put("eu-central-1", "unmanaged", "")
+	put("eu-west-1", "one", "config")
+
+	data := &parameterTargetsModel{
+		Name:        types.StringValue("/app/config"),
+		Type:        types.StringValue("String"),
+		Value:       types.StringValue("two"),
+		Description: types.StringValue("config"),
+	}
+
+	versions, errs := r.targets.putParameter(context.Background(), data, []string{"eu-west-1", "eu-central-1"}, []string{"eu-west-1"}, r.regionClient)
+	if diff := cmp.Diff(versions, map[string]int64{"eu-west-1": 2}); diff != "" {
+		t.Errorf("unexpected difference in the versions: %s", diff)
+	}
+	var alreadyExists *ssm_types.ParameterAlreadyExists
+	if !errors.As(errs["eu-central-1"], &alreadyExists) {
+		t.Errorf("expected ParameterAlreadyExists in eu-central-1, got %v", errs)
+	}
+
+	res, err := findParameterByName(context.Background(), regionalClient(r.client.Client, "eu-central-1"), "/app/config", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(res.Value) != "unmanaged" {
+		t.Errorf("expected the unmanaged parameter to be kept, got %s", aws.ToString(res.Value))
+	}
+
+	// Once drifted, the copy of a region is overwritten
+	versions, errs = r.targets.putParameter(context.Background(), data, []string{"eu-central-1"}, []string{"eu-central-1"}, r.regionClient)
+	if len(errs) != 0 || versions["eu-central-1"] != 2 {
+		t.Errorf("expected version 2 in eu-central-1, got %v, %v", versions, errs)
+	}
+}
+
+func TestDriftedTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	if diags := recordDriftedTargets(ctx, private, []string{"us-east-1", "eu-central-1"}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	targets, diags := driftedTargets(ctx, private)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diff := cmp.Diff(targets, []string{"eu-central-1", "us-east-1"}); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	if diags := recordDriftedTargets(ctx, private, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if targets, _ := driftedTargets(ctx, private); len(targets) != 0 {
+		t.Errorf("expected no drifted targets, got %v", targets)
+	}
+}
+
+func TestParameterTargetsUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, put := newTestReplicationResource(t)
+
+	put("eu-west-1", "one", "config")
+	// Not managed by the resource, and changed outside Terraform
+	put("eu-central-1", "unmanaged", "")
+	put("us-east-1", "changed", "config")
+
+	private := testPrivateState{}
+	if diags := recordDriftedTargets(ctx, private, []string{"us-east-1"}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	state := parameterTargetsModel{
+		Name:        types.StringValue("/app/config"),
+		Type:        types.StringValue("String"),
+		Value:       types.StringValue("one"),
+		Description: types.StringValue("config"),
+		Versions:    types.MapValueMust(types.Int64Type, map[string]attr.Value{"eu-west-1": types.Int64Value(1)}),
+	}
+	stateRegions := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("eu-west-1")})
+	plan := state
+	planRegions := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("eu-west-1"),
+		types.StringValue("eu-central-1"),
+		types.StringValue("us-east-1"),
+	})
+
+	diags := r.targets.update(ctx, &plan, &planRegions, state, stateRegions, r.regionClient, r.regionClient, private)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected the error of eu-central-1, got %v", diags)
+	}
+
+	var versions map[string]int64
+	plan.Versions.ElementsAs(ctx, &versions, false)
+	if diff := cmp.Diff(versions, map[string]int64{"eu-west-1": 1, "us-east-1": 2}); diff != "" {
+		t.Errorf("unexpected difference in the versions: %s", diff)
+	}
+	if targets, _ := driftedTargets(ctx, private); len(targets) != 0 {
+		t.Errorf("expected no drifted targets, got %v", targets)
+	}
+
+	res, err := findParameterByName(ctx, r.regionClient("eu-central-1"), "/app/config", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToString(res.Value) != "unmanaged" {
+		t.Errorf("expected the unmanaged parameter to be kept, got %s", aws.ToString(res.Value))
+	}
+}
+
+func TestParameterReplicationRegionClient(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestReplicationResource(t)
+
+	if r.regionClient("eu-west-1") != r.client.Client {
+		t.Error("expected the provider's client for its own region")
+	}
+	// Created once, not on every call
+	if client := r.regionClient("eu-central-1"); client != r.regionClient("eu-central-1") || client.Options().Region != "eu-central-1" {
+		t.Error("expected the cached client of eu-central-1")
+	}
+}
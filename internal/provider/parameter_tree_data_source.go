@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-fastssm/internal/retry"
+	"time"
+
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ParameterTreeDataSource{}
+
+func NewParameterTreeDataSource() datasource.DataSource {
+	return &ParameterTreeDataSource{}
+}
+
+// ParameterTreeDataSource defines the data source implementation.
+type ParameterTreeDataSource struct {
+	client *FastSSMClient
+}
+
+// ParameterTreeDataSourceModel describes the data source data model.
+type ParameterTreeDataSourceModel struct {
+	MaxDepth       types.Int64   `tfsdk:"max_depth"`
+	MaxResults     types.Int64   `tfsdk:"max_results"`
+	Path           types.String  `tfsdk:"path"`
+	Tree           types.Dynamic `tfsdk:"tree"`
+	WithDecryption types.Bool    `tfsdk:"with_decryption"`
+}
+
+func (d *ParameterTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter_tree"
+}
+
+func (d *ParameterTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads every SSM parameter under a path recursively and returns them as a nested object mirroring the hierarchy, `/app/db/host` under the path `/app` becoming `tree.db.host`.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_depth": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of levels of the hierarchy read below `path`, 1 for its direct children. " +
+					"The parameters of deeper levels are still listed by `GetParametersByPath`, but dropped as they come instead of being held. All levels are read when unset.",
+			},
+			"max_results": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of parameters read, the read fails as soon as more are found under `path` " +
+					"instead of fetching and holding a whole unexpectedly large hierarchy. Unlimited when unset.",
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "The hierarchy to read, e.g. `/app/prod`. Hierarchies start with a forward slash (`/`).",
+			},
+			"tree": schema.DynamicAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Object of the parameters under `path`, one nested object per level of the hierarchy and the values as strings. A parameter can't be both a value and a level of the hierarchy, e.g. `/app/db` and `/app/db/host`, such a tree fails the read.",
+			},
+			"with_decryption": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return decrypted `SecureString` values. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (d *ParameterTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ParameterTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParameterTreeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	const (
+		timeout = 5 * time.Minute
+	)
+
+	decryption := true
+	if !data.WithDecryption.IsNull() {
+		decryption = data.WithDecryption.ValueBool()
+	}
+
+	options := byPathOptions{
+		maxResults: int(data.MaxResults.ValueInt64()),
+		maxDepth:   int(data.MaxDepth.ValueInt64()),
+	}
+
+	var res []ssm_types.Parameter
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		res, erri = findParametersByPath(ctx, d.client.Client, data.Path.ValueString(), true, decryption, options)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if d.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameters by path %s, got error: %s", data.Path.String(), describeError(err)))
+		return
+	}
+
+	tree, err := parameterTree(data.Path.ValueString(), res)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Conflicting parameter hierarchy", err.Error())
+		return
+	}
+
+	// The tree only holds objects and strings, which always convert
+	value, _ := jsonAttrValue(ctx, tree)
+	data.Tree = types.DynamicValue(value)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parameterTree nests the parameters by the levels of their name below root,
+// maps for the levels and strings for the values.
+func parameterTree(root string, parameters []ssm_types.Parameter) (map[string]any, error) {
+	root = strings.TrimSuffix(root, "/")
+
+	tree := map[string]any{}
+	for _, p := range parameters {
+		relative := strings.TrimPrefix(strings.TrimPrefix(*p.Name, root), "/")
+		levels := strings.Split(relative, "/")
+
+		node := tree
+		for i, level := range levels[:len(levels)-1] {
+			switch child := node[level].(type) {
+			case nil:
+				next := map[string]any{}
+				node[level] = next
+				node = next
+			case map[string]any:
+				node = child
+			default:
+				return nil, fmt.Errorf("parameter %s is both a value and the parent of %s", root+"/"+strings.Join(levels[:i+1], "/"), *p.Name)
+			}
+		}
+
+		leaf := levels[len(levels)-1]
+		if _, ok := node[leaf]; ok {
+			return nil, fmt.Errorf("parameter %s is both a value and the parent of other parameters", *p.Name)
+		}
+		node[leaf] = *p.Value
+	}
+
+	return tree, nil
+}
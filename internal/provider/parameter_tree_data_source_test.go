@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccParameterTreeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterTreeDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fastssm_parameter_tree.test", "tree.db.host", "db.example.com"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter_tree.test", "tree.db.password", "secret"),
+					resource.TestCheckResourceAttr("data.fastssm_parameter_tree.test", "tree.replicas", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestParameterTree(t *testing.T) {
+	t.Parallel()
+
+	parameter := func(name, value string) ssm_types.Parameter {
+		return ssm_types.Parameter{Name: aws.String(name), Value: aws.String(value)}
+	}
+
+	testCases := []struct {
+		Name       string
+		Root       string
+		Parameters []ssm_types.Parameter
+		Expected   map[string]any
+		ExpectErr  bool
+	}{
+		{
+			Name: "nested",
+			Root: "/app",
+			Parameters: []ssm_types.Parameter{
+				parameter("/app/db/host", "db.example.com"),
+				parameter("/app/db/port", "5432"),
+				parameter("/app/replicas", "2"),
+			},
+			Expected: map[string]any{
+				"db":       map[string]any{"host": "db.example.com", "port": "5432"},
+				"replicas": "2",
+			},
+		},
+		{
+			Name:       "trailing slash",
+			Root:       "/app/",
+			Parameters: []ssm_types.Parameter{parameter("/app/db/host", "db.example.com")},
+			Expected:   map[string]any{"db": map[string]any{"host": "db.example.com"}},
+		},
+		{
+			Name:       "root",
+			Root:       "/",
+			Parameters: []ssm_types.Parameter{parameter("/app/db/host", "db.example.com")},
+			Expected:   map[string]any{"app": map[string]any{"db": map[string]any{"host": "db.example.com"}}},
+		},
+		{Name: "empty", Root: "/app", Expected: map[string]any{}},
+		{
+			Name: "value then children",
+			Root: "/app",
+			Parameters: []ssm_types.Parameter{
+				parameter("/app/db", "db.example.com"),
+				parameter("/app/db/host", "db.example.com"),
+			},
+			ExpectErr: true,
+		},
+		{
+			Name: "children then value",
+			Root: "/app",
+			Parameters: []ssm_types.Parameter{
+				parameter("/app/db/host", "db.example.com"),
+				parameter("/app/db", "db.example.com"),
+			},
+			ExpectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parameterTree(testCase.Root, testCase.Parameters)
+			if testCase.ExpectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+const testAccParameterTreeDataSourceConfig = `
+resource "fastssm_parameter" "host" {
+  name  = "/fastssm/acctest/tree/db/host"
+  type  = "String"
+  value = "db.example.com"
+}
+
+resource "fastssm_parameter" "password" {
+  name  = "/fastssm/acctest/tree/db/password"
+  type  = "SecureString"
+  value = "secret"
+}
+
+resource "fastssm_parameter" "replicas" {
+  name  = "/fastssm/acctest/tree/replicas"
+  type  = "String"
+  value = "2"
+}
+
+data "fastssm_parameter_tree" "test" {
+  path = "/fastssm/acctest/tree"
+
+  depends_on = [
+    fastssm_parameter.host,
+    fastssm_parameter.password,
+    fastssm_parameter.replicas,
+  ]
+}
+`
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// getParametersByPathMaxResults is the maximum number of parameters of a single GetParametersByPath page.
+const getParametersByPathMaxResults = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ParametersByPathEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ParametersByPathEphemeralResource{}
+
+func NewParametersByPathEphemeralResource() ephemeral.EphemeralResource {
+	return &ParametersByPathEphemeralResource{}
+}
+
+// ParametersByPathEphemeralResource defines the ephemeral resource implementation.
+type ParametersByPathEphemeralResource struct {
+	client *FastSSMClient
+}
+
+// ParametersByPathEphemeralResourceModel describes the ephemeral resource data model.
+type ParametersByPathEphemeralResourceModel struct {
+	MaxDepth       types.Int64  `tfsdk:"max_depth"`
+	MaxResults     types.Int64  `tfsdk:"max_results"`
+	Path           types.String `tfsdk:"path"`
+	Recursive      types.Bool   `tfsdk:"recursive"`
+	Values         types.Map    `tfsdk:"values"`
+	WithDecryption types.Bool   `tfsdk:"with_decryption"`
+}
+
+func (e *ParametersByPathEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameters_by_path"
+}
+
+func (e *ParametersByPathEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Opens every SSM parameter under a path with `GetParametersByPath`. The values never touch the Terraform state or plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"max_depth": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of levels read below `path` when `recursive` is set, 1 for its direct children. " +
+					"The parameters of deeper levels are still listed by `GetParametersByPath`, but dropped as they come instead of being held. All levels are read when unset.",
+			},
+			"max_results": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of parameters read, the read fails as soon as more are found under `path` " +
+					"instead of fetching and holding a whole unexpectedly large hierarchy. Unlimited when unset.",
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "The hierarchy for the parameters, e.g. `/app/prod`. Hierarchies start with a forward slash (`/`).",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to retrieve all parameters within the hierarchy, not just the ones directly under `path`. Defaults to `false`.",
+			},
+			names.AttrValues: schema.MapAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Map of the fully qualified parameter name to its value.",
+			},
+			"with_decryption": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return decrypted `SecureString` values. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (e *ParametersByPathEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *ParametersByPathEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParametersByPathEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	const (
+		timeout = 5 * time.Minute
+	)
+
+	decryption := true
+	if !data.WithDecryption.IsNull() {
+		decryption = data.WithDecryption.ValueBool()
+	}
+
+	options := byPathOptions{
+		maxResults: int(data.MaxResults.ValueInt64()),
+		maxDepth:   int(data.MaxDepth.ValueInt64()),
+	}
+
+	var res []ssm_types.Parameter
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		res, erri = findParametersByPath(ctx, e.client.Client, data.Path.ValueString(), data.Recursive.ValueBool(), decryption, options)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if e.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameters by path %s, got error: %s", data.Path.String(), describeError(err)))
+		return
+	}
+
+	values := make(map[string]string, len(res))
+	for _, p := range res {
+		values[*p.Name] = *p.Value
+	}
+
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Values = mapValue
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// byPathOptions bound the reads of GetParametersByPath, the zero value reads everything.
+type byPathOptions struct {
+	// maxResults fails the read once more parameters are found, 0 for no limit
+	maxResults int
+	// maxDepth is the number of levels read below the path, 0 for no limit
+	maxDepth int
+}
+
+// findParametersByPath walks all pages of GetParametersByPath. The whole hierarchy
+// is fetched on each call, so a retry starts from the first page again. Every page
+// needs the token of the previous one, so the pages of a path are read one by one.
+func findParametersByPath(ctx context.Context, conn *ssm.Client, path string, recursive, withDecryption bool, options byPathOptions) ([]ssm_types.Parameter, error) {
+	// The direct children are the only level of a non-recursive read
+	if options.maxDepth == 1 {
+		recursive = false
+	}
+
+	input := &ssm.GetParametersByPathInput{
+		Path:           &path,
+		Recursive:      &recursive,
+		WithDecryption: &withDecryption,
+		MaxResults:     aws.Int32(getParametersByPathMaxResults),
+	}
+
+	var parameters []ssm_types.Parameter
+	pages := ssm.NewGetParametersByPathPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, parameter := range page.Parameters {
+			// Deeper parameters are dropped as they come, they are never held
+			if options.maxDepth > 0 && parameterDepth(path, aws.ToString(parameter.Name)) > options.maxDepth {
+				continue
+			}
+
+			parameters = append(parameters, parameter)
+		}
+
+		if options.maxResults > 0 && len(parameters) > options.maxResults {
+			return nil, fmt.Errorf("more than %d parameters under %s, narrow the path or raise max_results", options.maxResults, path)
+		}
+	}
+
+	return parameters, nil
+}
+
+// parameterDepth is the number of levels of name below path, 1 for its direct children.
+func parameterDepth(path, name string) int {
+	relative := strings.TrimPrefix(name, strings.TrimSuffix(path, "/")+"/")
+
+	return strings.Count(relative, "/") + 1
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParametersByPathEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParametersByPathEphemeralResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.values.%", "2"),
+					resource.TestCheckResourceAttr("echo.test", "data.values./fastssm/acctest/ephemeral/by-path/db/host", "db.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestFindParametersByPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	})
+
+	// Enough parameters for several pages
+	var names []string
+	for _, name := range []string{"a", "b/c", "b/d/e"} {
+		for _, suffix := range []string{"1", "2", "3", "4", "5"} {
+			names = append(names, "/app/"+name+suffix)
+		}
+	}
+	for _, name := range names {
+		_, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("value"), Type: ssm_types.ParameterTypeString})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		Name        string
+		Recursive   bool
+		Options     byPathOptions
+		Expected    int
+		ExpectError string
+	}{
+		{
+			Name:     "direct children",
+			Expected: 5,
+		},
+		{
+			Name:      "recursive",
+			Recursive: true,
+			Expected:  15,
+		},
+		{
+			Name:      "depth 1",
+			Recursive: true,
+			Options:   byPathOptions{maxDepth: 1},
+			Expected:  5,
+		},
+		{
+			Name:      "depth 2",
+			Recursive: true,
+			Options:   byPathOptions{maxDepth: 2},
+			Expected:  10,
+		},
+		{
+			Name:      "max results reached",
+			Recursive: true,
+			Options:   byPathOptions{maxResults: 15},
+			Expected:  15,
+		},
+		{
+			Name:        "max results exceeded",
+			Recursive:   true,
+			Options:     byPathOptions{maxResults: 14},
+			ExpectError: "more than 14 parameters under /app",
+		},
+		{
+			Name:      "max results of the levels read",
+			Recursive: true,
+			Options:   byPathOptions{maxResults: 10, maxDepth: 2},
+			Expected:  10,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := findParametersByPath(ctx, conn, "/app", testCase.Recursive, true, testCase.Options)
+
+			if testCase.ExpectError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.ExpectError) {
+					t.Errorf("expected an error containing %q, got: %v", testCase.ExpectError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(got) != testCase.Expected {
+				t.Errorf("expected %d parameters, got %d", testCase.Expected, len(got))
+			}
+			for _, parameter := range got {
+				if !slices.Contains(names, aws.ToString(parameter.Name)) {
+					t.Errorf("unexpected parameter %s", aws.ToString(parameter.Name))
+				}
+			}
+		})
+	}
+}
+
+func TestParameterDepth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Path     string
+		Name     string
+		Expected int
+	}{
+		{Path: "/app", Name: "/app/a", Expected: 1},
+		{Path: "/app/", Name: "/app/a", Expected: 1},
+		{Path: "/app", Name: "/app/b/c", Expected: 2},
+		{Path: "/", Name: "/app/b/c", Expected: 3},
+	}
+
+	for _, testCase := range testCases {
+		if got := parameterDepth(testCase.Path, testCase.Name); got != testCase.Expected {
+			t.Errorf("parameterDepth(%q, %q): expected %d, got %d", testCase.Path, testCase.Name, testCase.Expected, got)
+		}
+	}
+}
+
+const testAccParametersByPathEphemeralResourceConfig = `
+resource "fastssm_parameter" "host" {
+  name  = "/fastssm/acctest/ephemeral/by-path/db/host"
+  type  = "String"
+  value = "db.example.com"
+}
+
+resource "fastssm_parameter" "password" {
+  name  = "/fastssm/acctest/ephemeral/by-path/db/password"
+  type  = "SecureString"
+  value = "secret"
+}
+
+ephemeral "fastssm_parameters_by_path" "test" {
+  path      = "/fastssm/acctest/ephemeral/by-path"
+  recursive = true
+
+  depends_on = [
+    fastssm_parameter.host,
+    fastssm_parameter.password,
+  ]
+}
+
+provider "echo" {
+  data = ephemeral.fastssm_parameters_by_path.test
+}
+
+resource "echo" "test" {}
+`
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// getParametersMaxNames is the maximum number of names accepted by a single GetParameters call.
+const getParametersMaxNames = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ParametersEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ParametersEphemeralResource{}
+
+func NewParametersEphemeralResource() ephemeral.EphemeralResource {
+	return &ParametersEphemeralResource{}
+}
+
+// ParametersEphemeralResource defines the ephemeral resource implementation.
+type ParametersEphemeralResource struct {
+	client *FastSSMClient
+}
+
+// ParametersEphemeralResourceModel describes the ephemeral resource data model.
+type ParametersEphemeralResourceModel struct {
+	Names          types.List `tfsdk:"names"`
+	Values         types.Map  `tfsdk:"values"`
+	WithDecryption types.Bool `tfsdk:"with_decryption"`
+}
+
+func (e *ParametersEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameters"
+}
+
+func (e *ParametersEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Opens several SSM parameters by name with `GetParameters`, fetching up to 10 names per API call. The values never touch the Terraform state or plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				Description: "Names of the parameters to open.",
+			},
+			names.AttrValues: schema.MapAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Map of the requested parameter name to its value.",
+			},
+			"with_decryption": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to return decrypted `SecureString` values. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (e *ParametersEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *ParametersEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ParametersEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	const (
+		timeout = 5 * time.Minute
+	)
+
+	decryption := true
+	if !data.WithDecryption.IsNull() {
+		decryption = data.WithDecryption.ValueBool()
+	}
+
+	var parameterNames []string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &parameterNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res []ssm_types.Parameter
+	var invalid []string
+	var erri error
+	// Define retry logic
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		res, invalid, erri = findParametersByNames(ctx, e.client.Client, parameterNames, decryption)
+		if erri != nil {
+			// Check if the error is retryable (e.g., rate limiting, network issues)
+			if e.client.isRetryableError(ctx, erri) {
+				// Return with retryable error, specifying how long to wait before the next retry
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			// If it's a permanent error, stop retrying
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		// If success, return nil (no retry)
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read parameters, got error: %s", describeError(err)))
+		return
+	}
+
+	if len(invalid) > 0 {
+		resp.Diagnostics.AddError("parameters not found", fmt.Sprintf("SSM Parameters not found: %s", strings.Join(invalid, ", ")))
+		return
+	}
+
+	values := make(map[string]string, len(res))
+	for _, p := range res {
+		values[*p.Name] = *p.Value
+	}
+
+	mapValue, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Values = mapValue
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// findParametersByNames fetches the named parameters with GetParameters, in chunks
+// of getParametersMaxNames. Names unknown to SSM are returned separately.
+func findParametersByNames(ctx context.Context, conn *ssm.Client, parameterNames []string, withDecryption bool) ([]ssm_types.Parameter, []string, error) {
+	var parameters []ssm_types.Parameter
+	var invalid []string
+
+	for start := 0; start < len(parameterNames); start += getParametersMaxNames {
+		end := min(start+getParametersMaxNames, len(parameterNames))
+
+		output, err := conn.GetParameters(ctx, &ssm.GetParametersInput{
+			Names:          parameterNames[start:end],
+			WithDecryption: &withDecryption,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parameters = append(parameters, output.Parameters...)
+		invalid = append(invalid, output.InvalidParameters...)
+	}
+
+	return parameters, invalid, nil
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParametersEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParametersEphemeralResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.values.%", "2"),
+					resource.TestCheckResourceAttr("echo.test", "data.values./fastssm/acctest/ephemeral/parameters/username", "admin"),
+					resource.TestCheckResourceAttr("echo.test", "data.values./fastssm/acctest/ephemeral/parameters/password", "secret"),
+				),
+			},
+		},
+	})
+}
+
+const testAccParametersEphemeralResourceConfig = `
+resource "fastssm_parameter" "username" {
+  name  = "/fastssm/acctest/ephemeral/parameters/username"
+  type  = "String"
+  value = "admin"
+}
+
+resource "fastssm_parameter" "password" {
+  name  = "/fastssm/acctest/ephemeral/parameters/password"
+  type  = "SecureString"
+  value = "secret"
+}
+
+ephemeral "fastssm_parameters" "test" {
+  names = [
+    fastssm_parameter.username.name,
+    fastssm_parameter.password.name,
+  ]
+}
+
+provider "echo" {
+  data = ephemeral.fastssm_parameters.test
+}
+
+resource "echo" "test" {}
+`
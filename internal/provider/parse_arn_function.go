@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseARNFunction{}
+
+func NewParseARNFunction() function.Function {
+	return &ParseARNFunction{}
+}
+
+// ParseARNFunction splits an SSM parameter ARN into its components.
+type ParseARNFunction struct{}
+
+// parsedARN is the object returned by parse_arn.
+type parsedARN struct {
+	AccountId types.String `tfsdk:"account_id"`
+	Name      types.String `tfsdk:"name"`
+	Partition types.String `tfsdk:"partition"`
+	Region    types.String `tfsdk:"region"`
+}
+
+func (f *ParseARNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_arn"
+}
+
+func (f *ParseARNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an SSM parameter ARN into its components",
+		Description: "Given the ARN of an SSM parameter in any partition, returns an object with its `partition`, `region`, `account_id` " +
+			"and parameter `name`, the reverse of `name_to_arn`. Hierarchical names are returned with their leading forward slash (`/`).",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "arn",
+				Description: "ARN of the SSM parameter.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"account_id": types.StringType,
+				"name":       types.StringType,
+				"partition":  types.StringType,
+				"region":     types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParseARNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	// Validates the ARN as a parameter ARN
+	name, err := parameterNameFromARN(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsedARN{
+		AccountId: types.StringValue(parsed.AccountID),
+		Name:      types.StringValue(name),
+		Partition: types.StringValue(parsed.Partition),
+		Region:    types.StringValue(parsed.Region),
+	}))
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseARNFunction(t *testing.T) {
+	t.Parallel()
+
+	attributeTypes := map[string]attr.Type{
+		"account_id": types.StringType,
+		"name":       types.StringType,
+		"partition":  types.StringType,
+		"region":     types.StringType,
+	}
+
+	testCases := []struct {
+		Name        string
+		ARN         string
+		Expected    map[string]attr.Value
+		ExpectError bool
+	}{
+		{
+			Name: "hierarchical name",
+			ARN:  "arn:aws:ssm:eu-west-1:123456789012:parameter/app/prod/db",
+			Expected: map[string]attr.Value{
+				"account_id": types.StringValue("123456789012"),
+				"name":       types.StringValue("/app/prod/db"),
+				"partition":  types.StringValue("aws"),
+				"region":     types.StringValue("eu-west-1"),
+			},
+		},
+		{
+			Name: "flat name in another partition",
+			ARN:  "arn:aws-cn:ssm:cn-north-1:123456789012:parameter/db",
+			Expected: map[string]attr.Value{
+				"account_id": types.StringValue("123456789012"),
+				"name":       types.StringValue("db"),
+				"partition":  types.StringValue("aws-cn"),
+				"region":     types.StringValue("cn-north-1"),
+			},
+		},
+		{
+			Name:        "not an ARN",
+			ARN:         "/app/db",
+			ExpectError: true,
+		},
+		{
+			Name:        "other service",
+			ARN:         "arn:aws:s3:::bucket/app/db",
+			ExpectError: true,
+		},
+		{
+			Name:        "other SSM resource",
+			ARN:         "arn:aws:ssm:eu-west-1:123456789012:document/app",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.ARN)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(attributeTypes)),
+			}
+
+			NewParseARNFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.ObjectValueMust(attributeTypes, testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
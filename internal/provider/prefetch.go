@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
+)
+
+// parameterPrefetch serves reads of the parameters under the `prefetch_paths` of the
+// provider. The paths are fetched recursively with GetParametersByPath at the first
+// read falling under one of them, every later read is answered from memory.
+type parameterPrefetch struct {
+	conn  *ssm.Client
+	paths []string
+	// parallelism is the number of paths fetched concurrently
+	parallelism int
+	// maxResults abandons the prefetch when a path holds more parameters, 0 for no limit
+	maxResults int
+
+	once sync.Once
+	// err is set when the prefetch failed, reads go to the API then
+	err error
+
+	mu         sync.Mutex
+	parameters map[string]*ssm_types.Parameter
+	// stale holds the names written since the prefetch, they are read from the API
+	stale map[string]bool
+}
+
+func newParameterPrefetch(conn *ssm.Client, paths []string, parallelism, maxResults int) *parameterPrefetch {
+	normalized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		normalized = append(normalized, strings.TrimSuffix(p, "/"))
+	}
+
+	return &parameterPrefetch{
+		conn:        conn,
+		paths:       normalized,
+		parallelism: max(parallelism, 1),
+		maxResults:  maxResults,
+		stale:       make(map[string]bool),
+	}
+}
+
+// get answers a read from the prefetched parameters. ok is false when the read isn't
+// covered, it must go to the API then. A covered parameter missing from the paths
+// is reported as not found.
+func (p *parameterPrefetch) get(ctx context.Context, name string, withDecryption bool) (parameter *ssm_types.Parameter, ok bool, err error) {
+	if p == nil || !p.covers(name) {
+		return nil, false, nil
+	}
+
+	p.once.Do(func() {
+		p.err = p.fetch(context.WithoutCancel(ctx))
+		if p.err != nil {
+			tflog.Warn(ctx, "prefetching SSM parameters failed, reading them one by one", map[string]any{"error": p.err.Error()})
+		}
+	})
+	if p.err != nil {
+		return nil, false, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stale[name] {
+		return nil, false, nil
+	}
+
+	parameter, found := p.parameters[name]
+	if !found {
+		return nil, true, &retry.NotFoundError{
+			Message: fmt.Sprintf("SSM Parameter %s not found under the prefetched paths", name),
+		}
+	}
+
+	// The paths are fetched decrypted, the ciphertext has to come from the API
+	if !withDecryption && parameter.Type == ssm_types.ParameterTypeSecureString {
+		return nil, false, nil
+	}
+
+	return parameter, true, nil
+}
+
+// covers reports whether the name lives under one of the paths. Version and label
+// selectors aren't served, the prefetch only holds the latest versions.
+func (p *parameterPrefetch) covers(name string) bool {
+	if strings.Contains(name, ":") {
+		return false
+	}
+
+	for _, path := range p.paths {
+		if strings.HasPrefix(name, path+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fetch reads the paths, up to parallelism of them at once. The pages of a single
+// path can't be read concurrently, each needs the token of the previous one.
+func (p *parameterPrefetch) fetch(ctx context.Context) error {
+	var mu sync.Mutex
+	parameters := make(map[string]*ssm_types.Parameter)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(p.parallelism)
+
+	for _, path := range p.paths {
+		g.Go(func() error {
+			tflog.Debug(ctx, "prefetching SSM parameters", map[string]any{"path": path})
+
+			res, err := findParametersByPath(ctx, p.conn, path, true, true, byPathOptions{maxResults: p.maxResults})
+			if err != nil {
+				return fmt.Errorf("prefetching %s: %w", path, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for i := range res {
+				parameters[*res[i].Name] = &res[i]
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.parameters = parameters
+	p.mu.Unlock()
+
+	return nil
+}
+
+// forget stops serving a parameter after it has been written or deleted.
+func (p *parameterPrefetch) forget(name string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stale[name] = true
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// preflightCheck is a call exercising one permission of the provider on the probe
+// parameter, without changing it. It returns the errors proving the call was
+// authorized as nil.
+type preflightCheck struct {
+	action string
+	call   func(ctx context.Context, conn *ssm.Client, name string) error
+}
+
+// preflightChecks run after GetParameter, which tells whether the probe exists.
+var preflightChecks = []preflightCheck{
+	{
+		action: "ssm:DescribeParameters",
+		call: func(ctx context.Context, conn *ssm.Client, name string) error {
+			_, err := conn.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+				ParameterFilters: []ssm_types.ParameterStringFilter{{Key: aws.String("Name"), Option: aws.String("Equals"), Values: []string{name}}},
+				MaxResults:       aws.Int32(1),
+			})
+			return err
+		},
+	},
+	{
+		// A value rejected by its own allowed pattern is never stored, authorization comes first
+		action: "ssm:PutParameter",
+		call: func(ctx context.Context, conn *ssm.Client, name string) error {
+			_, err := conn.PutParameter(ctx, &ssm.PutParameterInput{
+				Name:           aws.String(name),
+				Value:          aws.String("fastssm-preflight"),
+				Type:           ssm_types.ParameterTypeString,
+				AllowedPattern: aws.String("^$"),
+			})
+			return ignoreErrorCodes(err, "ParameterPatternMismatchException", "ParameterAlreadyExists")
+		},
+	},
+	{
+		// Run only when the probe doesn't exist, it would be deleted otherwise
+		action: "ssm:DeleteParameter",
+		call: func(ctx context.Context, conn *ssm.Client, name string) error {
+			_, err := conn.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String(name)})
+			return ignoreErrorCodes(err, "ParameterNotFound")
+		},
+	},
+}
+
+// ignoreErrorCodes returns err unless it's an API error with one of codes.
+func ignoreErrorCodes(err error, codes ...string) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && slices.Contains(codes, apiErr.ErrorCode()) {
+		return nil
+	}
+
+	return err
+}
+
+// preflight checks the permissions of the provider on the probe parameter, before
+// the apply starts changing resources, reporting every missing one at once instead
+// of failing on the first resource that needs it.
+func preflight(ctx context.Context, conn *ssm.Client, probe string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var denied, failed []string
+	report := func(action string, err error) {
+		var apiErr smithy.APIError
+		switch {
+		case err == nil:
+		case errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDeniedException":
+			denied = append(denied, fmt.Sprintf("- %s: %s", action, apiErr.ErrorMessage()))
+		default:
+			failed = append(failed, fmt.Sprintf("- %s: %s", action, err))
+		}
+	}
+
+	_, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(probe)})
+	probeExists := err == nil
+	report("ssm:GetParameter", ignoreErrorCodes(err, "ParameterNotFound"))
+
+	for _, check := range preflightChecks {
+		// The probe is never deleted, whatever the policy
+		if check.action == "ssm:DeleteParameter" && probeExists {
+			continue
+		}
+		report(check.action, check.call(ctx, conn, probe))
+	}
+
+	if len(denied) > 0 {
+		diags.AddAttributeError(
+			path.Root("preflight_probe"),
+			"missing IAM permissions",
+			fmt.Sprintf("The provider isn't allowed to call, on %s:\n%s\n\n"+
+				"Grant these actions to the identity of the provider on the parameters it manages.", probe, strings.Join(denied, "\n")),
+		)
+	}
+	if len(failed) > 0 {
+		diags.AddAttributeError(
+			path.Root("preflight_probe"),
+			"preflight checks failed",
+			fmt.Sprintf("These checks on %s failed for another reason than permissions:\n%s", probe, strings.Join(failed, "\n")),
+		)
+	}
+
+	return diags
+}
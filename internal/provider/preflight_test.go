@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+func TestPreflight(t *testing.T) {
+	t.Parallel()
+
+	const probe = "/app/fastssm-preflight"
+
+	testCases := []struct {
+		Name            string
+		Denied          []string
+		ProbeExists     bool
+		ExpectedDenied  []string
+		ExpectedAllowed []string
+		ExpectedCalls   map[string]int64
+	}{
+		{
+			Name:          "allowed",
+			ExpectedCalls: map[string]int64{"GetParameter": 1, "DescribeParameters": 1, "PutParameter": 1, "DeleteParameter": 1},
+		},
+		{
+			Name:            "denied",
+			Denied:          []string{"PutParameter", "DeleteParameter"},
+			ExpectedDenied:  []string{"ssm:PutParameter", "ssm:DeleteParameter"},
+			ExpectedAllowed: []string{"ssm:GetParameter", "ssm:DescribeParameters"},
+		},
+		{
+			Name:            "read only",
+			Denied:          []string{"GetParameter", "DescribeParameters"},
+			ExpectedDenied:  []string{"ssm:GetParameter", "ssm:DescribeParameters"},
+			ExpectedAllowed: []string{"ssm:PutParameter", "ssm:DeleteParameter"},
+		},
+		{
+			Name:          "existing probe",
+			ProbeExists:   true,
+			ExpectedCalls: map[string]int64{"GetParameter": 1, "DescribeParameters": 1, "PutParameter": 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			server := fakessm.NewServer()
+			defer server.Close()
+
+			conn := ssm.NewFromConfig(aws.Config{
+				Region:       "eu-west-1",
+				BaseEndpoint: aws.String(server.URL),
+				Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+			})
+			if testCase.ProbeExists {
+				if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(probe), Value: aws.String("keep"), Type: ssm_types.ParameterTypeString}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			server.Deny(testCase.Denied...)
+
+			diags := preflight(ctx, conn, probe)
+
+			if diags.HasError() != (len(testCase.ExpectedDenied) > 0) {
+				t.Fatalf("unexpected diagnostics %v", diags)
+			}
+			if diags.HasError() {
+				detail := diags[0].Detail()
+				for _, action := range testCase.ExpectedDenied {
+					if !strings.Contains(detail, action) {
+						t.Errorf("expected %s reported as denied in:\n%s", action, detail)
+					}
+				}
+				for _, action := range testCase.ExpectedAllowed {
+					if strings.Contains(detail, action) {
+						t.Errorf("unexpected %s reported as denied in:\n%s", action, detail)
+					}
+				}
+			}
+
+			for operation, expected := range testCase.ExpectedCalls {
+				if got := server.Calls()[operation]; got != expected {
+					t.Errorf("expected %d %s calls, got %d", expected, operation, got)
+				}
+			}
+
+			// The probe is left as it was
+			got, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(probe)})
+			if testCase.ProbeExists && (err != nil || aws.ToString(got.Parameter.Value) != "keep") {
+				t.Errorf("expected the probe to be kept, got %v", err)
+			}
+		})
+	}
+}
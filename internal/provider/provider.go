@@ -2,28 +2,36 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/YakDriver/regexache"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure FastSSMProvider satisfies various provider interfaces.
 var _ provider.Provider = &FastSSMProvider{}
-
-// var _ provider.ProviderWithFunctions = &FastSSMProvider{}
+var _ provider.ProviderWithEphemeralResources = &FastSSMProvider{}
+var _ provider.ProviderWithActions = &FastSSMProvider{}
+var _ provider.ProviderWithFunctions = &FastSSMProvider{}
 
 // FastSSMProvider defines the provider implementation.
 type FastSSMProvider struct {
@@ -36,28 +44,52 @@ type FastSSMProvider struct {
 // FastSSMProviderModel describes the provider data model.
 // TODO pending nested objects, most likely need structs
 type FastSSMProviderModel struct {
-	AccessKey                 types.String `tfsdk:"access_key"`
-	AllowedAccountIds         types.Set    `tfsdk:"allowed_account_ids"`
-	AssumeRole                types.List   `tfsdk:"assume_role"`                   // nested
-	AssumeRoleWithWebIdentity types.List   `tfsdk:"assume_role_with_web_identity"` // nested
-	CustomCABundle            types.String `tfsdk:"custom_ca_bundle"`
-	DefaultTags               types.Map    `tfsdk:"default_tags"`
-	Endpoints                 types.Set    `tfsdk:"endpoints"` // nested
-	ForbiddenAccountsIds      types.Set    `tfsdk:"forbidden_account_ids"`
-	HTTPProxy                 types.String `tfsdk:"http_proxy"`
-	HTTPSProxy                types.String `tfsdk:"https_proxy"`
-	Insecure                  types.Bool   `tfsdk:"insecure"`
-	IgnoreTags                types.List   `tfsdk:"ignore_tags"`
-	MaxRetries                types.Int32  `tfsdk:"max_retries"`
-	NoProxy                   types.String `tfsdk:"no_proxy"`
-	Profile                   types.String `tfsdk:"profile"`
-	Region                    types.String `tfsdk:"region"`
-	RetryMode                 types.String `tfsdk:"retry_mode"`
-	S3UserPathStyle           types.Bool   `tfsdk:"s3_use_path_style"`
+	AccessKey                 types.String  `tfsdk:"access_key"`
+	AllowedAccountIds         types.Set     `tfsdk:"allowed_account_ids"`
+	APICallEstimateWarning    types.Int64   `tfsdk:"api_call_estimate_warning_threshold"`
+	AssumeRole                types.List    `tfsdk:"assume_role"`                   // nested
+	AssumeRoleWithWebIdentity types.List    `tfsdk:"assume_role_with_web_identity"` // nested
+	AuditLog                  types.String  `tfsdk:"audit_log"`
+	CustomCABundle            types.String  `tfsdk:"custom_ca_bundle"`
+	DebugConnectivity         types.Bool    `tfsdk:"debug_connectivity"`
+	DebugCredentials          types.Bool    `tfsdk:"debug_credentials"`
+	DefaultTags               types.Map     `tfsdk:"default_tags"`
+	DisableRetries            types.Bool    `tfsdk:"disable_retries"`
+	EC2MetadataEndpoint       types.String  `tfsdk:"ec2_metadata_service_endpoint"`
+	EC2MetadataEndpointMode   types.String  `tfsdk:"ec2_metadata_service_endpoint_mode"`
+	EC2MetadataV1Disabled     types.Bool    `tfsdk:"ec2_metadata_v1_disabled"`
+	Endpoints                 types.Dynamic `tfsdk:"endpoints"`
+	ForbiddenAccountsIds      types.Set     `tfsdk:"forbidden_account_ids"`
+	HighThroughputWarning     types.Int64   `tfsdk:"high_throughput_warning_threshold"`
+	HTTPProxy                 types.String  `tfsdk:"http_proxy"`
+	HTTPSProxy                types.String  `tfsdk:"https_proxy"`
+	Insecure                  types.Bool    `tfsdk:"insecure"`
+	IgnoreTags                types.List    `tfsdk:"ignore_tags"`
+	MaxAPICalls               types.Int64   `tfsdk:"max_api_calls"`
+	MaxRetries                types.Int32   `tfsdk:"max_retries"`
+	MetricsListenAddress      types.String  `tfsdk:"metrics_listen_address"`
+	MetricsTextfile           types.String  `tfsdk:"metrics_textfile"`
+	NoProxy                   types.String  `tfsdk:"no_proxy"`
+	NormalizeNames            types.Bool    `tfsdk:"normalize_names"`
+	OperationTimeouts         types.Object  `tfsdk:"operation_timeouts"`
+	PrefetchMaxResults        types.Int64   `tfsdk:"prefetch_max_results"`
+	PrefetchParallelism       types.Int64   `tfsdk:"prefetch_parallelism"`
+	PrefetchPaths             types.List    `tfsdk:"prefetch_paths"`
+	PreflightProbe            types.String  `tfsdk:"preflight_probe"`
+	ReadBatchSize             types.Int64   `tfsdk:"read_batch_size"`
+	ReadBatchWindowMs         types.Int64   `tfsdk:"read_batch_window_ms"`
+	ReadCache                 types.Object  `tfsdk:"read_cache"`
+	Profile                   types.String  `tfsdk:"profile"`
+	Region                    types.String  `tfsdk:"region"`
+	RetryMode                 types.String  `tfsdk:"retry_mode"`
+	RetryableErrorCodes       types.Set     `tfsdk:"retryable_error_codes"`
+	S3UserPathStyle           types.Bool    `tfsdk:"s3_use_path_style"`
 	// S3USEast1RegionalEndpoint      types.String `tfsdk:"s3_us_east_1_regional_endpoint"`
 	SecretKey                      types.String `tfsdk:"secret_key"`
 	SharedConfigFiles              types.List   `tfsdk:"shared_config_files"`
 	SharedCredentialsFiles         types.List   `tfsdk:"shared_credentials_files"`
+	SharedRateLimitFile            types.String `tfsdk:"shared_rate_limit_file"`
+	SharedRateLimitTPS             types.Int64  `tfsdk:"shared_rate_limit_tps"`
 	SkipCredentialsValidation      types.Bool   `tfsdk:"skip_credentials_validation"`
 	SkipMetadataAPICheck           types.Bool   `tfsdk:"skip_metadata_api_check"`
 	SkipRegionValidation           types.Bool   `tfsdk:"skip_region_validation"`
@@ -67,6 +99,8 @@ type FastSSMProviderModel struct {
 	TokenBucketRateLimiterCapacity types.Int32  `tfsdk:"token_bucket_rate_limiter_capacity"`
 	UseDualstackEndpoint           types.Bool   `tfsdk:"use_dualstack_endpoint"`
 	UseFipsEndpoint                types.Bool   `tfsdk:"use_fips_endpoint"`
+	UseLocalStack                  types.Bool   `tfsdk:"use_localstack"`
+	VersionLimitWarning            types.Int64  `tfsdk:"version_limit_warning_threshold"`
 }
 
 func (p *FastSSMProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -94,14 +128,45 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				},
 				DeprecationMessage: "This is not supported in this provider intentionally.",
 			},
+			"api_call_estimate_warning_threshold": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of SSM API calls, estimated from the changes planned for the `fastssm_parameter` resources, from which the plan warns, once, " +
+					"with the calls per operation and how long they take at `shared_rate_limit_tps`, or at the 40 calls per second of the standard throughput. " +
+					"The estimate so far is logged at the INFO level with every planned change, the last log of a plan having the estimate of the whole plan.",
+			},
 			"assume_role":                   assumeRoleSchema(),
 			"assume_role_with_web_identity": assumeRoleWithWebIdentitySchema(),
+			"audit_log": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Path of a local file the provider appends a JSON line to for every parameter it writes or deletes, " +
+					"with the `timestamp`, the `operation`, the parameter `name` and `region`, the `old_version` and `new_version`, the `caller_arn`, and the `role_arn` the change was made with in other accounts. " +
+					"Evidence of the changes for auditors without access to CloudTrail. The version of a deleted parameter is the one last known in state.",
+			},
 			"custom_ca_bundle": schema.StringAttribute{
 				Optional: true,
 				Description: "File containing custom root and intermediate certificates. " +
 					"Can also be configured using the `AWS_CA_BUNDLE` environment variable. " +
 					"(Setting `ca_bundle` in the shared config file is not supported.)",
 			},
+			"debug_connectivity": schema.BoolAttribute{
+				Optional: true,
+				Description: "Checks the STS and SSM endpoints at configure time, step by step, and reports in a warning whether " +
+					"their name resolves, to private addresses of an interface VPC endpoint or public ones, whether they accept " +
+					"connections, and whether a call is authorized, telling apart denials of the VPC endpoint policy from IAM ones. " +
+					"Explains failures otherwise showing up as timeouts.",
+			},
+			"debug_credentials": schema.BoolAttribute{
+				Optional: true,
+				Description: "Reports in a warning which credential provider was used (static, profile, SSO, IRSA, IMDS...), " +
+					"when the credentials expire and the caller identity, to debug environments resolving different credentials. " +
+					"The access key ID is masked.",
+			},
 			"default_tags": schema.MapAttribute{
 				Optional:           true,
 				Description:        "Configuration block with settings to default resource tags across all resources.",
@@ -119,6 +184,33 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				// 	},
 				// },
 			},
+			"disable_retries": schema.BoolAttribute{
+				Optional: true,
+				Description: "Fail on the first error instead of retrying it, for CI pipelines preferring an immediate failure over retry loops that can last up to 10 minutes. " +
+					"Neither the SDK nor the provider retry throttling, transient server errors and network failures anymore. " +
+					"The waits for SSM to reflect a write are kept.",
+			},
+			"ec2_metadata_service_endpoint": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^https?://`), "must be an http or https URL"),
+				},
+				Description: "Address of the EC2 instance metadata service (IMDS) the instance profile credentials and the region are read from, " +
+					"e.g. `http://[fd00:ec2::254]`. Takes precedence over the `AWS_EC2_METADATA_SERVICE_ENDPOINT` environment variable and the profile.",
+			},
+			"ec2_metadata_service_endpoint_mode": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPv4", "IPv6"),
+				},
+				Description: "Address family of the default endpoint of the EC2 instance metadata service, `IPv4` or `IPv6` for IPv6-only instances. " +
+					"Ignored when `ec2_metadata_service_endpoint` is set. Takes precedence over the `AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE` environment variable and the profile.",
+			},
+			"ec2_metadata_v1_disabled": schema.BoolAttribute{
+				Optional: true,
+				Description: "Don't fall back to IMDSv1 when the IMDSv2 token can't be obtained, failing with the cause instead, e.g. a hop limit of 1 " +
+					"on an instance running Terraform in a container. Failures of the instance metadata service are explained either way.",
+			},
 			"endpoints": endpointsSchema(),
 			"forbidden_account_ids": schema.SetAttribute{
 				ElementType: types.StringType,
@@ -130,6 +222,15 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					}...),
 				},
 			},
+			"high_throughput_warning_threshold": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of parameters planned to be written by the `fastssm_parameter` resources from which the provider checks, once per run, " +
+					"the `/ssm/parameter-store/high-throughput-enabled` service setting of the account and region, and warns when it's disabled, " +
+					"with the expected impact on the throughput. Requires `ssm:GetServiceSetting`.",
+			},
 			"http_proxy": schema.StringAttribute{
 				Optional: true,
 				Description: "URL of a proxy to use for HTTP requests when accessing the AWS API. " +
@@ -172,18 +273,89 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description: "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted, " +
 					"default value is `false`",
 			},
+			"max_api_calls": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of AWS API calls, retries included, the provider configuration may make during a Terraform command's plan or apply. " +
+					"Terraform runs each of them, and each alias, in its own plugin process, counting its own calls: a `terraform apply` planning first may make up to twice the budget. " +
+					"Once exhausted every further call fails, aborting the command. Protects shared accounts from runaway refreshes.",
+			},
 			"max_retries": schema.Int32Attribute{
 				Optional: true,
 				Description: "The maximum number of times an AWS API request is\n" +
 					"being executed. If the API request still fails, an error is\n" +
 					"thrown.",
 			},
+			"metrics_listen_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Address, e.g. `127.0.0.1:9464`, serving Prometheus metrics on `/metrics` for the duration of the run: " +
+					"the AWS API calls, retries, throttles and time spent per operation. " +
+					"An address already in use, e.g. by another provider process of the same run, is reported as a warning.",
+			},
+			"metrics_textfile": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Path of a file the Prometheus metrics, the same as served on `metrics_listen_address`, are written to every 10 seconds and at the end of the run, " +
+					"for the textfile collector of the node exporter.",
+			},
 			"no_proxy": schema.StringAttribute{
 				Optional: true,
 				Description: "Comma-separated list of hosts that should not use HTTP or HTTPS proxies. " +
 					"Can also be set using the `NO_PROXY` or `no_proxy` environment variables.",
 				DeprecationMessage: "This is not supported in this provider intentionally.",
 			},
+			"normalize_names": schema.BoolAttribute{
+				Optional: true,
+				Description: "Prefix hierarchical parameter names with `/` when missing and collapse repeated slashes, " +
+					"e.g. `app//db/password` is managed as `/app/db/password`, avoiding the fully qualified name errors of SSM " +
+					"and duplicates differing only by their leading slash. Names without any `/` are left alone. " +
+					"Applies to the names of resources, data sources and actions, the state keeps them as configured.",
+			},
+			"operation_timeouts": operationTimeoutsSchema(),
+			"prefetch_max_results": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of parameters prefetched per path of `prefetch_paths`. The prefetch is abandoned as soon as a path " +
+					"holds more, and the reads under every path go to the API one by one, instead of holding a whole unexpectedly large hierarchy in memory. Unlimited when unset.",
+			},
+			"prefetch_parallelism": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of `prefetch_paths` fetched concurrently, 1 by default. The pages of a single path are always read one after the other, " +
+					"each needing the token of the previous one, so split very large trees into several paths to fetch them in parallel.",
+			},
+			"prefetch_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexache.MustCompile(`^/.+`), "must be an absolute path, e.g. /app/prod"),
+					),
+				},
+				Description: "Paths fetched recursively with `GetParametersByPath` at the first read under them. " +
+					"All later reads of parameters under these paths, from resources and data sources, are served from memory.",
+			},
+			"preflight_probe": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^/.+`), "must be a fully qualified parameter name, e.g. /app/prod/fastssm-preflight"),
+				},
+				Description: "Name of a parameter, which needn't exist, under the path managed by the provider. When set, the provider " +
+					"checks its permissions on it at configure time, with `GetParameter`, `DescribeParameters`, a `PutParameter` rejected " +
+					"by its allowed pattern and a `DeleteParameter` when it doesn't exist, and reports every missing IAM permission before " +
+					"the apply starts changing resources. The probe is never written nor deleted.",
+			},
 			"profile": schema.StringAttribute{
 				Optional: true,
 				Description: "The profile for API operations. If not set, the default profile\n" +
@@ -192,13 +364,38 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 			"region": schema.StringAttribute{
 				Optional: true,
 				Description: "The region where AWS operations will take place. Examples\n" +
-					"are us-east-1, us-west-2, etc.", // lintignore:AWSAT003,
+					"are us-east-1, us-west-2, etc. If not set, the `AWS_REGION` and\n" +
+					"`AWS_DEFAULT_REGION` environment variables, the profile and the\n" +
+					"EC2 instance metadata are checked in this order.", // lintignore:AWSAT003,
+			},
+			"read_batch_size": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, getParametersMaxNames),
+				},
+				Description: "Maximum number of concurrent reads coalesced into a single `GetParameters` call, from 1, disabling the batching, to 10, the default.",
+			},
+			"read_batch_window_ms": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+				Description: "How long, in milliseconds, a batch of reads waits for more reads before it's sent, unless it's full. Defaults to `10`. " +
+					"A longer window trades a little latency per read for fewer API calls when many reads run in parallel, a shorter one suits low `-parallelism`.",
 			},
+			"read_cache": readCacheSchema(),
 			"retry_mode": schema.StringAttribute{
 				Optional: true,
 				Description: "Specifies how retries are attempted. Valid values are `standard` and `adaptive`. " +
 					"Can also be configured using the `AWS_RETRY_MODE` environment variable.",
 			},
+			"retryable_error_codes": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "AWS error codes retried by resources, data sources and actions once the SDK gave up on them, " +
+					"in addition to the throttling errors and the transient `InternalFailure`, `InternalServerError`, " +
+					"`RequestTimeout`, `RequestTimeoutException`, `ServiceUnavailable` and connection resets.",
+			},
 			"s3_use_path_style": schema.BoolAttribute{
 				Optional: true,
 				Description: "Set this to true to enable the request to use path-style addressing,\n" +
@@ -219,8 +416,9 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					"from the 'Security & Credentials' section of the AWS console.",
 			},
 			"shared_config_files": schema.ListAttribute{
-				Optional:    true,
-				Description: "List of paths to shared config files. If not set, defaults to [~/.aws/config].",
+				Optional: true,
+				Description: "List of paths to shared config files. If not set, defaults to [~/.aws/config]. " +
+					"On HCP Terraform, `[var.tfc_aws_dynamic_credentials.aliases[\"ALIAS\"].shared_config_file]` gives an alias its own dynamic credentials.",
 				ElementType: types.StringType,
 			},
 			"shared_credentials_files": schema.ListAttribute{
@@ -228,6 +426,25 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description: "List of paths to shared credentials files. If not set, defaults to [~/.aws/credentials].",
 				ElementType: types.StringType,
 			},
+			"shared_rate_limit_file": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Path of a file, created when missing, the Terraform runs of a host coordinate their SSM API calls through, " +
+					"so concurrent pipelines against an account share `shared_rate_limit_tps` instead of throttling each other. " +
+					"Every attempt takes a slot, spent in bursts of up to a second. " +
+					"Provider aliases each run in their own plugin process, those against the same account coordinate through the same file.",
+			},
+			"shared_rate_limit_tps": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10000),
+					int64validator.AlsoRequires(path.MatchRoot("shared_rate_limit_file")),
+				},
+				Description: "SSM API calls per second shared by the runs using `shared_rate_limit_file`. Defaults to `40`, " +
+					"the throughput of `GetParameter` without the higher throughput setting. The runs sharing a file should set the same rate.",
+			},
 			"skip_credentials_validation": schema.BoolAttribute{
 				Optional: true,
 				Description: "Skip the credentials validation via STS API. " +
@@ -277,27 +494,146 @@ func (p *FastSSMProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Description:        "Resolve an endpoint with FIPS capability",
 				DeprecationMessage: "This is not supported in this provider intentionally.",
 			},
+			"use_localstack": schema.BoolAttribute{
+				Optional: true,
+				Description: "Run against [LocalStack](https://www.localstack.cloud/), at `LOCALSTACK_ENDPOINT` or `http://localhost:4566` by default: " +
+					"the SSM and STS endpoints left unset in `endpoints` point at it, dummy static credentials are used unless `access_key` and `secret_key` are set, " +
+					"the region defaults to `us-east-1` and the credentials aren't validated with STS.",
+			},
+			"version_limit_warning_threshold": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Number of writes left from which the `fastssm_parameter` resources warn, when planning a write, that a parameter nears " +
+					"SSM's limit of 100 versions with a label on one of its oldest versions, which SSM can't drop, failing the writes past it. " +
+					"The version in state tells which parameters are close to the limit, only their history is read. Requires `ssm:GetParameterHistory`.",
+			},
 		},
 	}
 }
 
-func endpointsSchema() *schema.SetNestedAttribute {
-	return &schema.SetNestedAttribute{
+func endpointsSchema() *schema.DynamicAttribute {
+	return &schema.DynamicAttribute{
 		Optional: true,
-		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"ssm": schema.StringAttribute{
-					Optional:    true,
-					Description: "Use this to override the default service endpoint URL",
+		Description: "Endpoint URLs overriding those resolved by the SDK for the region, " +
+			"from the `AWS_ENDPOINT_URL_SSM`, `AWS_ENDPOINT_URL_STS` and `AWS_ENDPOINT_URL` environment variables when set. " +
+			"An object with the optional `ssm` endpoint, and `sts` endpoint used to validate the credentials and assume roles, " +
+			"e.g. `endpoints = { ssm = \"http://localhost:4566\" }`. A list holding a single such object is accepted as well.",
+		Validators: []validator.Dynamic{
+			endpointsValidator{},
+		},
+	}
+}
+
+// readCacheModel describes the `read_cache` object.
+type readCacheModel struct {
+	EncryptionKey types.String `tfsdk:"encryption_key"`
+	Path          types.String `tfsdk:"path"`
+	TTL           types.String `tfsdk:"ttl"`
+}
+
+func readCacheSchema() *schema.SingleNestedAttribute {
+	return &schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "Persistent cache of parameter reads, so repeated runs in quick succession don't fetch unchanged parameters again. " +
+			"Changes made outside Terraform go unnoticed until the entries expire. " +
+			"Set the `" + forceRefreshEnvVar + "` environment variable to `true` to bypass the cached entries. " +
+			"Provider aliases each run in their own plugin process, the aliases of an account and region share their reads through the same `path`.",
+		Attributes: map[string]schema.Attribute{
+			"encryption_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "Key used to encrypt the cache entries with AES-GCM. " +
+					"Without it, `SecureString` parameters are never written to the cache.",
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Directory holding the cache entries. Created when missing.",
+			},
+			"ttl": schema.StringAttribute{
+				Required:    true,
+				Description: "How long an entry is served, e.g. `10m`. Valid time units are ns, us (or µs), ms, s, h, or m.",
+				Validators: []validator.String{
+					durationValidator{},
 				},
 			},
 		},
 	}
 }
 
+// operationTimeoutsModel describes the `operation_timeouts` object.
+type operationTimeoutsModel struct {
+	DeleteParameter    types.String `tfsdk:"delete_parameter"`
+	DescribeParameters types.String `tfsdk:"describe_parameters"`
+	GetParameter       types.String `tfsdk:"get_parameter"`
+	PutParameter       types.String `tfsdk:"put_parameter"`
+}
+
+// apply overrides the timeouts set in the model, validated by the schema.
+func (m operationTimeoutsModel) apply(timeouts operationTimeouts) operationTimeouts {
+	for _, timeout := range []struct {
+		value  types.String
+		target *time.Duration
+	}{
+		{m.DeleteParameter, &timeouts.deleteParameter},
+		{m.DescribeParameters, &timeouts.describeParameters},
+		{m.GetParameter, &timeouts.getParameter},
+		{m.PutParameter, &timeouts.putParameter},
+	} {
+		if !timeout.value.IsNull() {
+			*timeout.target, _ = time.ParseDuration(timeout.value.ValueString())
+		}
+	}
+
+	return timeouts
+}
+
+func operationTimeoutsSchema() *schema.SingleNestedAttribute {
+	timeout := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:    true,
+			Description: description + " Valid time units are ns, us (or µs), ms, s, h, or m.",
+			Validators: []validator.String{
+				timeoutValidator{},
+			},
+		}
+	}
+
+	return &schema.SingleNestedAttribute{
+		Optional: true,
+		Description: "How long the calls of each SSM operation are retried before failing, e.g. a short `get_parameter` for fast refreshes " +
+			"and a long `put_parameter` for large applies. Every attempt counts, throttled ones included.",
+		Attributes: map[string]schema.Attribute{
+			"delete_parameter":    timeout("Timeout of the deletions of parameters, `10m` by default."),
+			"describe_parameters": timeout("Timeout of the `DescribeParameters` calls reading the metadata of parameters and counting them, `5m` by default."),
+			"get_parameter":       timeout("Timeout of the reads of parameters, `2m` by default, waiting for a write to be readable included."),
+			"put_parameter":       timeout("Timeout of the writes of parameters, `10m` by default."),
+		},
+	}
+}
+
+// assumeRoleModel is a block of assume_role.
+type assumeRoleModel struct {
+	Duration          types.String `tfsdk:"duration"`
+	ExternalID        types.String `tfsdk:"external_id"`
+	Policy            types.String `tfsdk:"policy"`
+	PolicyARNs        types.Set    `tfsdk:"policy_arns"`
+	RoleARN           types.String `tfsdk:"role_arn"`
+	SerialNumber      types.String `tfsdk:"serial_number"`
+	SessionName       types.String `tfsdk:"session_name"`
+	SourceIdentity    types.String `tfsdk:"source_identity"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TokenCode         types.String `tfsdk:"token_code"`
+	TransitiveTagKeys types.Set    `tfsdk:"transitive_tag_keys"`
+}
+
 func assumeRoleSchema() *schema.ListNestedAttribute {
 	return &schema.ListNestedAttribute{
 		Optional: true,
+		Description: "Roles assumed in order before making API calls, each with the credentials of the previous one " +
+			"(role chaining), the first with the credentials of the provider. " +
+			"AWS limits the session of a chained role to 1 hour.",
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
 				"duration": schema.StringAttribute{
@@ -337,6 +673,20 @@ func assumeRoleSchema() *schema.ListNestedAttribute {
 						arnValidator{kind: "string"},
 					},
 				},
+				"serial_number": schema.StringAttribute{
+					Optional: true,
+					Description: "Serial number, or ARN for a virtual device, of the MFA device of the role's trust policy. " +
+						"The code is taken from `token_code`, or the `" + mfaTokenCodeEnvVar + "` environment variable, e.g. " +
+						"`" + mfaTokenCodeEnvVar + "=123456 terraform apply`: Terraform doesn't connect providers to the terminal to prompt for it. " +
+						"A code is valid for a single `AssumeRole` call: the session is stored in the cache directory of the user and shared by the plan, the apply " +
+						"and the aliases configured with the same code until it expires, set a `duration` covering them.",
+					Validators: []validator.String{
+						stringvalidator.All(
+							stringvalidator.LengthBetween(9, 256),
+							stringvalidator.RegexMatches(regexache.MustCompile(`^[\w+=/:,.@-]+$`), "must be the serial number or ARN of an MFA device"),
+						),
+					},
+				},
 				"session_name": schema.StringAttribute{
 					Optional:    true,
 					Description: "An identifier for the assumed role session.",
@@ -362,6 +712,17 @@ func assumeRoleSchema() *schema.ListNestedAttribute {
 					Description: "Assume role session tags.",
 					ElementType: types.StringType,
 				},
+				"token_code": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Code of the MFA device of `serial_number`, 6 digits.",
+					Validators: []validator.String{
+						stringvalidator.All(
+							stringvalidator.RegexMatches(mfaTokenCodeRegexp, "must be 6 digits"),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("serial_number")),
+						),
+					},
+				},
 				"transitive_tag_keys": schema.SetAttribute{
 					Optional:    true,
 					Description: "Assume role session tag keys to pass to any subsequent sessions.",
@@ -372,9 +733,25 @@ func assumeRoleSchema() *schema.ListNestedAttribute {
 	}
 }
 
+// assumeRoleWithWebIdentityModel is the assume_role_with_web_identity block.
+type assumeRoleWithWebIdentityModel struct {
+	Duration             types.String `tfsdk:"duration"`
+	Policy               types.String `tfsdk:"policy"`
+	PolicyARNs           types.Set    `tfsdk:"policy_arns"`
+	RoleARN              types.String `tfsdk:"role_arn"`
+	SessionName          types.String `tfsdk:"session_name"`
+	WebIdentityToken     types.String `tfsdk:"web_identity_token"`
+	WebIdentityTokenFile types.String `tfsdk:"web_identity_token_file"`
+}
+
 func assumeRoleWithWebIdentitySchema() *schema.ListNestedAttribute {
 	return &schema.ListNestedAttribute{
 		Optional: true,
+		Description: "Role assumed with an OpenID Connect token before the roles of `assume_role`. " +
+			"`role_arn`, `session_name` and `web_identity_token_file` default to the `AWS_ROLE_ARN`, " +
+			"`AWS_ROLE_SESSION_NAME` and `AWS_WEB_IDENTITY_TOKEN_FILE` environment variables. Without the block, these variables, " +
+			"as set by EKS IAM roles for service accounts (IRSA), are assumed the same way unless static credentials or a profile are configured. " +
+			"EKS Pod Identity needs no configuration either.",
 		Validators: []validator.List{
 			listvalidator.SizeAtMost(1),
 		},
@@ -420,24 +797,26 @@ func assumeRoleWithWebIdentitySchema() *schema.ListNestedAttribute {
 					},
 				},
 				"web_identity_token": schema.StringAttribute{
-					Optional: true,
-					// ExactlyOneOf: []string{"assume_role_with_web_identity.0.web_identity_token", "assume_role_with_web_identity.0.web_identity_token_file"},
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The OpenID Connect token, used for the whole run.",
 					Validators: []validator.String{
 						stringvalidator.All(
 							stringvalidator.LengthBetween(4, 20000),
 							stringvalidator.ConflictsWith(path.Expressions{
-								path.MatchRoot("web_identity_token_file"),
+								path.MatchRelative().AtParent().AtName("web_identity_token_file"),
 							}...),
 						),
 					},
 				},
 				"web_identity_token_file": schema.StringAttribute{
 					Optional: true,
-					// ExactlyOneOf: []string{"assume_role_with_web_identity.0.web_identity_token", "assume_role_with_web_identity.0.web_identity_token_file"},
+					Description: "File containing the OpenID Connect token. It's read again whenever the credentials are refreshed, " +
+						"so tokens rotated by the platform, e.g. projected service account tokens, keep working during long applies.",
 					Validators: []validator.String{
 						stringvalidator.All(
 							stringvalidator.ConflictsWith(path.Expressions{
-								path.MatchRoot("web_identity_token"),
+								path.MatchRelative().AtParent().AtName("web_identity_token"),
 							}...),
 						),
 					},
@@ -448,6 +827,9 @@ func assumeRoleWithWebIdentitySchema() *schema.ListNestedAttribute {
 }
 
 func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
 	// Retrieve provider data from configuration
 	var data FastSSMProviderModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -455,6 +837,12 @@ func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	var localstackEndpoint string
+	if data.UseLocalStack.ValueBool() {
+		localstackEndpoint = applyLocalStack(&data)
+		tflog.Info(ctx, "running against LocalStack", map[string]any{"endpoint": localstackEndpoint})
+	}
+
 	var options = []func(*config.LoadOptions) error{}
 
 	if !data.RetryMode.IsNull() {
@@ -469,14 +857,33 @@ func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureR
 		options = append(options, config.WithRetryMode(mode), config.WithRetryMaxAttempts(25))
 	}
 
+	// A single attempt, overriding the attempts of retry_mode
+	if data.DisableRetries.ValueBool() {
+		options = append(options, config.WithRetryMaxAttempts(1))
+	}
+
 	// AWS Profile
 	if !data.Profile.IsNull() {
 		options = append(options, config.WithSharedConfigProfile(data.Profile.ValueString()))
 	}
 
-	// Region
-	if !data.Region.IsNull() {
-		options = append(options, config.WithRegion(data.Region.ValueString()))
+	// The aliases of HCP Terraform dynamic credentials each get their own file
+	if !data.SharedConfigFiles.IsNull() {
+		var files []string
+		resp.Diagnostics.Append(data.SharedConfigFiles.ElementsAs(ctx, &files, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		options = append(options, config.WithSharedConfigFiles(files))
+	}
+
+	// Instance profile credentials and region
+	metadata := newEC2MetadataSettings(data)
+	options = append(options, metadata.loadOptions()...)
+
+	// Region, the profile and the instance metadata are checked once loaded
+	if region, _ := explicitRegion(data.Region); region != "" {
+		options = append(options, config.WithRegion(region))
 	}
 
 	// Static credentials
@@ -492,14 +899,7 @@ func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureR
 		options = append(options, config.WithCredentialsProvider(creds))
 	}
 
-	// TODO add assumerole support
-	// config.WithAssumeRoleCredentialOptions()
-	// config.WithSharedCredentialsFiles()
-
-	// TODO add web-identity-role support
-	// if !data.AssumeRoleWithWebIdentity.IsNull() {
-	// 	options = append(options, config.WithWebIdentityRoleCredentialOptions(func(*stscreds.WebIdentityRoleOptions)))
-	// }
+	// TODO config.WithSharedCredentialsFiles()
 
 	// Client configuration for data sources and resources
 	cfg, err := config.LoadDefaultConfig(context.TODO(), options...)
@@ -511,15 +911,132 @@ func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	stsclient := sts.NewFromConfig(cfg)
-	res, err := stsclient.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
-	if err != nil || res == nil {
-		resp.Diagnostics.AddError(
-			"provider configuration failed at STS GetCallerIdentity phase",
-			err.Error(),
-		)
+	// Before any client is derived from cfg
+	cfg.HTTPClient = sharedHTTPClientFor(cfg.HTTPClient)
+
+	resp.Diagnostics.Append(resolveRegion(ctx, &cfg, data.Region, data.Profile.ValueString(), metadata.clientOptions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every client derived from cfg is counted and logged
+	cfg.APIOptions = append(cfg.APIOptions, apiCalls.addMiddleware, addAPILoggingMiddleware)
+	if !data.MaxAPICalls.IsNull() {
+		cfg.APIOptions = append(cfg.APIOptions, newAPICallBudget(data.MaxAPICalls.ValueInt64()).addMiddleware)
+	}
+
+	if !data.MetricsListenAddress.IsNull() {
+		if err := metrics.listen(data.MetricsListenAddress.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeWarning(path.Root("metrics_listen_address"), "Metrics endpoint not started", err.Error())
+		}
+	}
+	if !data.MetricsTextfile.IsNull() {
+		if err := metrics.writeTextfile(data.MetricsTextfile.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("metrics_textfile"), "metrics configuration failed", err.Error())
+			return
+		}
+	}
+
+	// The calls are expected at the shared rate, when set
+	var estimateTPS int64
+	if !data.SharedRateLimitFile.IsNull() {
+		tps := int64(defaultSharedRateLimitTPS)
+		if !data.SharedRateLimitTPS.IsNull() {
+			tps = data.SharedRateLimitTPS.ValueInt64()
+		}
+		estimateTPS = tps
+
+		limiter, err := sharedRateLimiterFor(data.SharedRateLimitFile.ValueString(), tps)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("shared_rate_limit_file"), "shared rate limit configuration failed", err.Error())
+			return
+		}
+		cfg.APIOptions = append(cfg.APIOptions, limiter.addMiddleware)
+	}
+
+	serviceEndpoints, diags := newEndpoints(ctx, data.Endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	if localstackEndpoint != "" {
+		serviceEndpoints = serviceEndpoints.withLocalStack(localstackEndpoint)
+	}
+
+	// Assumed once the region is known, the AssumeRole calls are counted as well
+	if !data.AssumeRoleWithWebIdentity.IsNull() {
+		var roles []assumeRoleWithWebIdentityModel
+		resp.Diagnostics.Append(data.AssumeRoleWithWebIdentity.ElementsAs(ctx, &roles, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// At most one block
+		for _, role := range roles {
+			resp.Diagnostics.Append(assumeRoleWithWebIdentity(ctx, &cfg, role, serviceEndpoints)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if role, enabled, diags := hcpTerraformWebIdentity(data); enabled {
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "assuming TFC_AWS_RUN_ROLE_ARN with the workload identity token of HCP Terraform", map[string]any{"role_arn": role.RoleARN.ValueString()})
+		resp.Diagnostics.Append(assumeRoleWithWebIdentity(ctx, &cfg, role, serviceEndpoints)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if defaultsToWebIdentity(data) {
+		// Assumed by the provider rather than the SDK, so the STS endpoint and the
+		// middlewares of the provider apply to AssumeRoleWithWebIdentity too
+		tflog.Info(ctx, "assuming AWS_ROLE_ARN with the web identity token of AWS_WEB_IDENTITY_TOKEN_FILE (EKS IRSA)")
+		resp.Diagnostics.Append(assumeRoleWithWebIdentity(ctx, &cfg, emptyAssumeRoleWithWebIdentity, serviceEndpoints)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !data.AssumeRole.IsNull() {
+		var roles []assumeRoleModel
+		resp.Diagnostics.Append(data.AssumeRole.ElementsAs(ctx, &roles, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(assumeRoles(ctx, &cfg, roles, serviceEndpoints)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Added last, the clients assuming the roles refresh their own credentials
+	if credentials, ok := cfg.Credentials.(*aws.CredentialsCache); ok {
+		cfg.APIOptions = append(cfg.APIOptions, newCredentialRefresh(credentials).addMiddleware)
+	}
+
+	// Before GetCallerIdentity, which fails the configuration with the first error
+	if data.DebugConnectivity.ValueBool() {
+		checks, err := connectivityChecks(ctx, cfg, serviceEndpoints)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("debug_connectivity"), "connectivity diagnostics failed", err.Error())
+			return
+		}
+		resp.Diagnostics.AddWarning("AWS connectivity", diagnoseConnectivity(ctx, checks))
+	}
+
+	var res *sts.GetCallerIdentityOutput
+	if localstackEndpoint != "" {
+		res = localstackCallerIdentity()
+	} else {
+		stsclient := sts.NewFromConfig(cfg, serviceEndpoints.stsOptions)
+		res, err = stsclient.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+		if err != nil || res == nil {
+			resp.Diagnostics.AddError(
+				"provider configuration failed at STS GetCallerIdentity phase",
+				describeCredentialsError(err),
+			)
+			return
+		}
+	}
 
 	if res.UserId == nil {
 		resp.Diagnostics.AddError(
@@ -532,9 +1049,96 @@ func (p *FastSSMProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	client := ssm.NewFromConfig(cfg)
+	if data.DebugCredentials.ValueBool() {
+		// Retrieved for GetCallerIdentity already, served by the cache of cfg
+		creds, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("retrieving the AWS credentials failed", err.Error())
+			return
+		}
+		resp.Diagnostics.AddWarning("AWS credentials", describeCredentials(creds, sharedConfigProfile(data.Profile.ValueString()), aws.ToString(res.Arn), time.Now()))
+	}
+
+	batching := defaultParameterBatchOptions
+	if !data.ReadBatchSize.IsNull() {
+		batching.maxNames = int(data.ReadBatchSize.ValueInt64())
+	}
+	if !data.ReadBatchWindowMs.IsNull() {
+		batching.window = time.Duration(data.ReadBatchWindowMs.ValueInt64()) * time.Millisecond
+	}
+
+	client := newFastSSMClient(ssm.NewFromConfig(cfg, serviceEndpoints.ssmOptions), batching)
+	client.normalizeNames = data.NormalizeNames.ValueBool()
+	client.disableRetries = data.DisableRetries.ValueBool()
+	if !data.OperationTimeouts.IsNull() {
+		var timeouts operationTimeoutsModel
+		resp.Diagnostics.Append(data.OperationTimeouts.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.timeouts = timeouts.apply(client.timeouts)
+	}
+	if !data.RetryableErrorCodes.IsNull() {
+		resp.Diagnostics.Append(data.RetryableErrorCodes.ElementsAs(ctx, &client.retryableErrorCodes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.HighThroughputWarning.IsNull() {
+		client.highThroughput = newHighThroughputCheck(data.HighThroughputWarning.ValueInt64())
+	}
+	client.versionLimitWarning = data.VersionLimitWarning.ValueInt64()
+	client.plannedCalls = newAPICallEstimate(cfg.Region, estimateTPS, batching.maxNames, data.APICallEstimateWarning.ValueInt64())
+	client.assumedRoles = newAssumedRoles(sts.NewFromConfig(cfg, serviceEndpoints.stsOptions))
+
+	if !data.AuditLog.IsNull() {
+		client.audit, err = newAuditLog(data.AuditLog.ValueString(), aws.ToString(res.Arn))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("audit_log"), "audit log configuration failed", err.Error())
+			return
+		}
+	}
+
+	if !data.PreflightProbe.IsNull() {
+		resp.Diagnostics.Append(preflight(ctx, client.Client, data.PreflightProbe.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.PrefetchPaths.IsNull() {
+		var paths []string
+		resp.Diagnostics.Append(data.PrefetchPaths.ElementsAs(ctx, &paths, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.prefetch = newParameterPrefetch(client.Client, paths, int(data.PrefetchParallelism.ValueInt64()), int(data.PrefetchMaxResults.ValueInt64()))
+	}
+
+	if !data.ReadCache.IsNull() {
+		var readCache readCacheModel
+		resp.Diagnostics.Append(data.ReadCache.As(ctx, &readCache, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Validated by the schema
+		ttl, _ := time.ParseDuration(readCache.TTL.ValueString())
+		client.diskCache, err = newParameterDiskCache(readCache.Path.ValueString(), ttl, readCache.EncryptionKey.ValueString(), aws.ToString(res.Account)+"/"+cfg.Region)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"read cache configuration failed",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
 }
 
 type staticCredentials struct {
@@ -548,26 +1152,58 @@ func (s staticCredentials) Retrieve(context.Context) (aws.Credentials, error) {
 		AccessKeyID:     s.accessKey,
 		SecretAccessKey: s.secretKey,
 		SessionToken:    s.token,
+		Source:          providerCredentialsSource,
 	}, nil
 }
 
 func (p *FastSSMProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewParameterResource,
+		NewParameterFanoutResource,
+		NewParameterReplicationResource,
+		NewServiceSettingResource,
 	}
 }
 
 func (p *FastSSMProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewParameterCountDataSource,
 		NewParameterDataSource,
+		NewParameterExistsDataSource,
+		NewParameterTreeDataSource,
 	}
 }
 
-// func (p *FastSSMProvider) Functions(ctx context.Context) []func() function.Function {
-// 	return []func() function.Function{
-// 		NewExampleFunction,
-// 	}
-// }
+func (p *FastSSMProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewParameterEphemeralResource,
+		NewParametersByPathEphemeralResource,
+		NewParametersEphemeralResource,
+	}
+}
+
+func (p *FastSSMProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewParameterCopyAction,
+		NewParameterLabelAction,
+		NewParameterRollbackAction,
+	}
+}
+
+func (p *FastSSMProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewARNToNameFunction,
+		NewExpandPathFunction,
+		NewHashFunction,
+		NewJoinStringListFunction,
+		NewNameToARNFunction,
+		NewNormalizeJSONFunction,
+		NewParseARNFunction,
+		NewSplitStringListFunction,
+		NewToDotenvFunction,
+		NewValidateNameFunction,
+	}
+}
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
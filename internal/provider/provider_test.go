@@ -1,11 +1,23 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,6 +28,94 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"fastssm": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho adds the echo provider, which stores the
+// ephemeral values it's configured with in the state of its echo resource so
+// the tests can check what an ephemeral resource returned.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"fastssm": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":    echoprovider.NewProviderServer(),
+}
+
+const (
+	// fakeBackendEnvVar runs the acceptance tests offline, against the in-memory fake SSM, when set
+	fakeBackendEnvVar = "FASTSSM_ACC_FAKE"
+	// localstackEnvVar starts a LocalStack container for the acceptance tests when set
+	localstackEnvVar = "FASTSSM_ACC_LOCALSTACK"
+)
+
+// testAccFakeBackend is the fake the acceptance tests run against, nil with another backend.
+var testAccFakeBackend *fakessm.Server
+
+func TestMain(m *testing.M) {
+	// The MFA sessions of the tests stay out of the cache of the user
+	cacheDir, err := os.MkdirTemp("", "fastssm-cache")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "creating the cache directory: %s\n", err)
+		os.Exit(1)
+	}
+	userCacheDir = func() (string, error) { return cacheDir, nil }
+
+	stop, err := startAccBackend(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "starting the acceptance test backend: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Runs the sweepers instead of the tests with -sweep
+	resource.TestMain(stoppingRunner{m: m, stop: func() {
+		stop()
+		_ = os.RemoveAll(cacheDir)
+	}})
+}
+
+// stoppingRunner stops the acceptance test backend once the tests ran.
+type stoppingRunner struct {
+	m    *testing.M
+	stop func()
+}
+
+func (r stoppingRunner) Run() int {
+	defer r.stop()
+
+	return r.m.Run()
+}
+
+// startAccBackend points the acceptance tests at the backend chosen by the
+// environment, the AWS account of the credentials by default.
+func startAccBackend(ctx context.Context) (stop func(), err error) {
+	stop = func() {}
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		return stop, nil
+	}
+
+	var endpoint string
+	switch {
+	case os.Getenv(fakeBackendEnvVar) != "":
+		testAccFakeBackend = fakessm.NewServer()
+		endpoint, stop = testAccFakeBackend.URL, testAccFakeBackend.Close
+	case os.Getenv(localstackEnvVar) != "":
+		endpoint, stop, err = startLocalStack(ctx)
+		if err != nil {
+			return nil, err
+		}
+	case os.Getenv(localstackEndpointEnvVar) != "":
+		endpoint = os.Getenv(localstackEndpointEnvVar)
+	default:
+		return stop, nil
+	}
+
+	// Picked up by the SDK for every client of the provider
+	os.Setenv("AWS_ENDPOINT_URL", endpoint)
+	os.Setenv("AWS_ACCESS_KEY_ID", "test")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	os.Setenv("AWS_SESSION_TOKEN", "test")
+	if os.Getenv("AWS_REGION") == "" {
+		os.Setenv("AWS_REGION", "eu-west-1")
+	}
+
+	return stop, nil
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
@@ -27,3 +127,66 @@ func testAccPreCheck(t *testing.T) {
 		}
 	}
 }
+
+// testAccSSMClient is a client of the backend the acceptance tests run against, for
+// inspecting what the provider actually stored.
+func testAccSSMClient(ctx context.Context) (*ssm.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssm.NewFromConfig(cfg), nil
+}
+
+// testAccCheckParameterStored asserts the latest version of a parameter holds value.
+func testAccCheckParameterStored(name, value string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ctx := context.Background()
+		conn, err := testAccSSMClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		output, err := conn.GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(name),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("reading stored parameter %s: %w", name, err)
+		}
+
+		if got := aws.ToString(output.Parameter.Value); got != value {
+			return fmt.Errorf("parameter %s: expected %q to be stored, got %q", name, value, got)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckParameterDestroyed asserts none of the parameters exist anymore.
+func testAccCheckParameterDestroyed(names ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ctx := context.Background()
+		conn, err := testAccSSMClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			_, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(name)})
+
+			var notFound *ssm_types.ParameterNotFound
+			if errors.As(err, &notFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("reading stored parameter %s: %w", name, err)
+			}
+
+			return fmt.Errorf("parameter %s still exists", name)
+		}
+
+		return nil
+	}
+}
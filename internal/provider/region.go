@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// imdsRegionTimeout bounds the region lookup in the instance metadata, which hangs off EC2.
+const imdsRegionTimeout = 2 * time.Second
+
+// explicitRegion returns the region set in the provider block or the environment,
+// and where it comes from. The SDK ignores AWS_DEFAULT_REGION, the AWS CLI doesn't.
+func explicitRegion(attribute types.String) (region, source string) {
+	if !attribute.IsNull() && attribute.ValueString() != "" {
+		return attribute.ValueString(), "the `region` attribute"
+	}
+
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := os.Getenv(env); v != "" {
+			return v, "the " + env + " environment variable"
+		}
+	}
+
+	return "", ""
+}
+
+// resolveRegion logs where the region of cfg comes from, completing it from the
+// instance metadata when neither the provider block, the environment nor the
+// profile sets one. Without any region, the error names every source checked
+// and how to set one, instead of the SDK failing on the first call.
+func resolveRegion(ctx context.Context, cfg *aws.Config, attribute types.String, profile string, imdsOptions ...func(*imds.Options)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	profile = sharedConfigProfile(profile)
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = "~/.aws/config"
+	}
+
+	if region, source := explicitRegion(attribute); region != "" {
+		tflog.Debug(ctx, "AWS region resolved", map[string]any{"region": region, "source": source})
+		return diags
+	}
+
+	if cfg.Region != "" {
+		tflog.Debug(ctx, "AWS region resolved", map[string]any{"region": cfg.Region, "source": "profile " + profile})
+		return diags
+	}
+
+	region, imdsResult := imdsRegion(ctx, *cfg, imdsOptions...)
+	if region != "" {
+		cfg.Region = region
+		tflog.Debug(ctx, "AWS region resolved", map[string]any{"region": region, "source": "the EC2 instance metadata"})
+		return diags
+	}
+
+	checked := []string{
+		"- the `region` attribute of the provider block: not set",
+		"- the AWS_REGION environment variable: not set",
+		"- the AWS_DEFAULT_REGION environment variable: not set",
+		fmt.Sprintf("- the `region` of the profile %q in %s: not set", profile, configFile),
+		"- the EC2 instance metadata: " + imdsResult,
+	}
+
+	diags.AddError(
+		"no AWS region configured",
+		"The provider needs a region, none was found in:\n"+strings.Join(checked, "\n")+"\n\n"+
+			"Set `region` in the provider block, export AWS_REGION, or add `region` to the profile.",
+	)
+
+	return diags
+}
+
+// sharedConfigProfile returns the profile the SDK loads, the one of the provider block
+// or AWS_PROFILE, default otherwise.
+func sharedConfigProfile(attribute string) string {
+	if attribute != "" {
+		return attribute
+	}
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+
+	return "default"
+}
+
+// imdsRegion returns the region of the instance from its metadata, or why it's unknown.
+func imdsRegion(ctx context.Context, cfg aws.Config, optFns ...func(*imds.Options)) (region, outcome string) {
+	if disabled, _ := strconv.ParseBool(os.Getenv("AWS_EC2_METADATA_DISABLED")); disabled {
+		return "", "disabled by AWS_EC2_METADATA_DISABLED"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, imdsRegionTimeout)
+	defer cancel()
+
+	output, err := imds.NewFromConfig(cfg, optFns...).GetRegion(ctx, &imds.GetRegionInput{})
+	if err != nil {
+		if guidance := ec2MetadataGuidance(err.Error()); guidance != "" {
+			return "", "failed (" + err.Error() + "). " + guidance
+		}
+		return "", "not reachable, not running on EC2? (" + err.Error() + ")"
+	}
+
+	return output.Region, ""
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveRegion(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Attribute        types.String
+		Env              map[string]string
+		ProfileRegion    string
+		ExpectedRegion   string
+		ExpectedError    bool
+		ExpectedInDetail []string
+	}{
+		{
+			Name:           "attribute",
+			Attribute:      types.StringValue("eu-west-1"),
+			Env:            map[string]string{"AWS_REGION": "us-east-1"},
+			ExpectedRegion: "eu-west-1",
+		},
+		{
+			Name:           "AWS_REGION",
+			Attribute:      types.StringNull(),
+			Env:            map[string]string{"AWS_REGION": "us-east-1", "AWS_DEFAULT_REGION": "us-west-2"},
+			ExpectedRegion: "us-east-1",
+		},
+		{
+			Name:           "AWS_DEFAULT_REGION",
+			Attribute:      types.StringNull(),
+			Env:            map[string]string{"AWS_DEFAULT_REGION": "us-west-2"},
+			ExpectedRegion: "us-west-2",
+		},
+		{
+			Name:           "profile",
+			Attribute:      types.StringNull(),
+			ProfileRegion:  "ap-southeast-2",
+			ExpectedRegion: "ap-southeast-2",
+		},
+		{
+			Name:          "none",
+			Attribute:     types.StringNull(),
+			Env:           map[string]string{"AWS_PROFILE": "ci", "AWS_CONFIG_FILE": "/etc/aws/config"},
+			ExpectedError: true,
+			ExpectedInDetail: []string{
+				"`region` attribute",
+				"AWS_REGION",
+				"AWS_DEFAULT_REGION",
+				`profile "ci" in /etc/aws/config`,
+				"disabled by AWS_EC2_METADATA_DISABLED",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION", "AWS_PROFILE", "AWS_CONFIG_FILE"} {
+				t.Setenv(env, testCase.Env[env])
+			}
+			t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+			// LoadDefaultConfig sets the explicit region, or the one of the profile
+			cfg := aws.Config{Region: testCase.ProfileRegion}
+			if region, _ := explicitRegion(testCase.Attribute); region != "" {
+				cfg.Region = region
+			}
+
+			diags := resolveRegion(context.Background(), &cfg, testCase.Attribute, "")
+
+			if diags.HasError() != testCase.ExpectedError {
+				t.Fatalf("expected error %t, got %v", testCase.ExpectedError, diags)
+			}
+			if cfg.Region != testCase.ExpectedRegion {
+				t.Errorf("expected region %q, got %q", testCase.ExpectedRegion, cfg.Region)
+			}
+			for _, expected := range testCase.ExpectedInDetail {
+				if detail := diags[0].Detail(); !strings.Contains(detail, expected) {
+					t.Errorf("expected %q in the diagnostic, got:\n%s", expected, detail)
+				}
+			}
+		})
+	}
+}
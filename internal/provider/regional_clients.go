@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// regionalClients caches the SSM clients of the regions read by the data sources
+// configuring their own `region`, and written by fastssm_parameter_replication, so
+// reading a few parameters of another region doesn't need a provider alias. Each is created from the provider's client on first
+// use, sharing its credentials, endpoint, retryer and rate limiter.
+type regionalClients struct {
+	mu      sync.Mutex
+	clients map[string]*ssm.Client
+}
+
+func newRegionalClients() *regionalClients {
+	return &regionalClients{
+		clients: make(map[string]*ssm.Client),
+	}
+}
+
+// client returns the client of region, conn itself when region is empty or the
+// region of conn.
+func (r *regionalClients) client(conn *ssm.Client, region string) *ssm.Client {
+	if region == "" || region == conn.Options().Region {
+		return conn
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, ok := r.clients[region]
+	if !ok {
+		client = regionalClient(conn, region)
+		r.clients[region] = client
+	}
+
+	return client
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+func TestRegionalClients(t *testing.T) {
+	t.Parallel()
+
+	clients := newRegionalClients()
+	conn := ssm.New(ssm.Options{Region: "eu-west-1"})
+
+	if clients.client(conn, "") != conn {
+		t.Errorf("expected the provider's client without a region")
+	}
+	if clients.client(conn, "eu-west-1") != conn {
+		t.Errorf("expected the provider's client for its own region")
+	}
+
+	first := clients.client(conn, "eu-central-1")
+	if region := first.Options().Region; region != "eu-central-1" {
+		t.Errorf("expected a client of eu-central-1, got %s", region)
+	}
+	if clients.client(conn, "eu-central-1") != first {
+		t.Errorf("expected the client of a region to be created once")
+	}
+	if conn.Options().Region != "eu-west-1" {
+		t.Errorf("expected the provider's client to keep its region")
+	}
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-fastssm/internal/names"
+	"terraform-provider-fastssm/internal/retry"
+
+	"github.com/YakDriver/regexache"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServiceSettingResource{}
+var _ resource.ResourceWithImportState = &ServiceSettingResource{}
+var _ resource.ResourceWithValidateConfig = &ServiceSettingResource{}
+
+// parameterStoreSettings are the valid values of the Parameter Store service settings.
+var parameterStoreSettings = map[string][]string{
+	"/ssm/parameter-store/default-parameter-tier":  {"Standard", "Advanced", "Intelligent-Tiering"},
+	"/ssm/parameter-store/high-throughput-enabled": {"true", "false"},
+}
+
+// serviceSettingARNRegexp extracts the setting ID of a service setting ARN, e.g.
+// /ssm/parameter-store/high-throughput-enabled of
+// arn:aws:ssm:eu-west-1:123456789012:servicesetting/ssm/parameter-store/high-throughput-enabled.
+var serviceSettingARNRegexp = regexache.MustCompile(`^arn:[^:]+:ssm:[^:]*:\d*:servicesetting(/.+)$`)
+
+func NewServiceSettingResource() resource.Resource {
+	return &ServiceSettingResource{}
+}
+
+// ServiceSettingResource manages an account-level SSM service setting of the region.
+type ServiceSettingResource struct {
+	client *FastSSMClient
+}
+
+// ServiceSettingResourceModel describes the resource data model.
+type ServiceSettingResourceModel struct {
+	Arn          types.String `tfsdk:"arn"`
+	SettingID    types.String `tfsdk:"setting_id"`
+	SettingValue types.String `tfsdk:"setting_value"`
+	Status       types.String `tfsdk:"status"`
+}
+
+func (r *ServiceSettingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_setting"
+}
+
+func (r *ServiceSettingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SSM service setting of the account and region.",
+		MarkdownDescription: "Manages an SSM service setting of the account and region, like the Parameter Store settings " +
+			"`/ssm/parameter-store/default-parameter-tier` and `/ssm/parameter-store/high-throughput-enabled`, " +
+			"so account-level toggles are codified alongside the parameters. Destroying the resource resets the setting to its default.",
+
+		Attributes: map[string]schema.Attribute{
+			names.AttrARN: schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ARN of the service setting.",
+			},
+			"setting_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexache.MustCompile(`^(/ssm/|arn:)`), "must be a service setting ID, e.g. /ssm/parameter-store/high-throughput-enabled, or its ARN"),
+				},
+				Description: "ID of the service setting, e.g. `/ssm/parameter-store/default-parameter-tier` or `/ssm/parameter-store/high-throughput-enabled`, or its ARN.",
+			},
+			"setting_value": schema.StringAttribute{
+				Required: true,
+				Description: "Value of the service setting. `Standard`, `Advanced` or `Intelligent-Tiering` for " +
+					"`/ssm/parameter-store/default-parameter-tier`, `true` or `false` for `/ssm/parameter-store/high-throughput-enabled`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Status of the service setting: `Default`, `Customized` or `PendingUpdate`.",
+			},
+		},
+	}
+}
+
+func (r *ServiceSettingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FastSSMClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FastSSMClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the value of the Parameter Store settings, SSM only rejects
+// invalid ones at apply time.
+func (r *ServiceSettingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ServiceSettingResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.SettingID.IsUnknown() || data.SettingValue.IsUnknown() {
+		return
+	}
+
+	values, ok := parameterStoreSettings[serviceSettingID(data.SettingID.ValueString())]
+	if ok && !slices.Contains(values, data.SettingValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("setting_value"),
+			"Invalid service setting value",
+			fmt.Sprintf("The value of %s must be one of %s, got %q.", data.SettingID.ValueString(), strings.Join(values, ", "), data.SettingValue.ValueString()),
+		)
+	}
+}
+
+func (r *ServiceSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ServiceSettingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateServiceSetting(ctx, data); err != nil {
+		resp.Diagnostics.AddError("SSM service setting update error", fmt.Sprintf("updating SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+		return
+	}
+
+	setting, err := r.waitServiceSetting(ctx, data.SettingID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("SSM service setting read error", fmt.Sprintf("reading SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+		return
+	}
+	data.Arn = types.StringValue(aws.ToString(setting.ARN))
+	data.Status = types.StringValue(aws.ToString(setting.Status))
+
+	tflog.Trace(ctx, "created a service setting")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ServiceSettingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var setting *ssm_types.ServiceSetting
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		var erri error
+		setting, erri = r.getServiceSetting(ctx, data.SettingID.ValueString())
+		if erri != nil {
+			if r.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM service setting read error", fmt.Sprintf("reading SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+		return
+	}
+
+	data.Arn = types.StringValue(aws.ToString(setting.ARN))
+	data.SettingValue = types.StringValue(aws.ToString(setting.SettingValue))
+	data.Status = types.StringValue(aws.ToString(setting.Status))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ServiceSettingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateServiceSetting(ctx, data); err != nil {
+		resp.Diagnostics.AddError("SSM service setting update error", fmt.Sprintf("updating SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+		return
+	}
+
+	setting, err := r.waitServiceSetting(ctx, data.SettingID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("SSM service setting read error", fmt.Sprintf("reading SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+		return
+	}
+	data.Arn = types.StringValue(aws.ToString(setting.ARN))
+	data.Status = types.StringValue(aws.ToString(setting.Status))
+
+	tflog.Trace(ctx, "updated a service setting")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportAPICalls := trackAPICalls(ctx)
+	defer reportAPICalls(&resp.Diagnostics)
+
+	var data ServiceSettingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A setting can't be removed, it's reset to its default
+	err := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		_, erri := r.client.ResetServiceSetting(ctx, &ssm.ResetServiceSettingInput{SettingId: aws.String(data.SettingID.ValueString())})
+		if erri != nil {
+			if r.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("SSM service setting reset error", fmt.Sprintf("resetting SSM service setting (%s): %s", data.SettingID.ValueString(), describeError(err)))
+	}
+}
+
+func (r *ServiceSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("setting_id"), req, resp)
+}
+
+func (r *ServiceSettingResource) updateServiceSetting(ctx context.Context, data ServiceSettingResourceModel) error {
+	input := &ssm.UpdateServiceSettingInput{
+		SettingId:    aws.String(data.SettingID.ValueString()),
+		SettingValue: aws.String(data.SettingValue.ValueString()),
+	}
+
+	return retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
+		_, erri := r.client.UpdateServiceSetting(ctx, input)
+		if erri != nil {
+			if r.client.isRetryableError(ctx, erri) {
+				return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+			}
+
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		}
+
+		return nil
+	})
+}
+
+// waitServiceSetting reads the setting once its update is applied, the status of
+// the setting stays PendingUpdate meanwhile.
+func (r *ServiceSettingResource) waitServiceSetting(ctx context.Context, id string) (*ssm_types.ServiceSetting, error) {
+	var setting *ssm_types.ServiceSetting
+	err := retry.RetryContext(ctx, 5*time.Minute, func() *retry.RetryError {
+		var erri error
+		setting, erri = r.getServiceSetting(ctx, id)
+		switch {
+		case erri != nil && r.client.isRetryableError(ctx, erri):
+			return retry.RetryableError(fmt.Errorf("temporary failure: %w, retrying...", erri))
+		case erri != nil:
+			return retry.NonRetryableError(fmt.Errorf("permanent failure: %w", erri))
+		case aws.ToString(setting.Status) == "PendingUpdate":
+			return retry.RetryableError(fmt.Errorf("service setting %s is still being updated", id))
+		}
+
+		return nil
+	})
+
+	return setting, err
+}
+
+func (r *ServiceSettingResource) getServiceSetting(ctx context.Context, id string) (*ssm_types.ServiceSetting, error) {
+	output, err := r.client.GetServiceSetting(ctx, &ssm.GetServiceSettingInput{SettingId: aws.String(id)})
+	if err != nil {
+		return nil, err
+	}
+	if output == nil || output.ServiceSetting == nil {
+		return nil, fmt.Errorf("empty result for service setting %s", id)
+	}
+
+	return output.ServiceSetting, nil
+}
+
+// serviceSettingID is the ID of a service setting given by its ID or its ARN.
+func serviceSettingID(id string) string {
+	if match := serviceSettingARNRegexp.FindStringSubmatch(id); match != nil {
+		return match[1]
+	}
+
+	return id
+}
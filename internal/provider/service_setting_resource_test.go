@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServiceSettingResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid values are rejected at plan time
+			{
+				Config:      testAccServiceSettingResourceConfig("Premium"),
+				ExpectError: regexp.MustCompile(`Invalid service setting value`),
+				PlanOnly:    true,
+			},
+			// Create and Read testing
+			{
+				Config: testAccServiceSettingResourceConfig("Advanced"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_service_setting.test", "setting_value", "Advanced"),
+					resource.TestCheckResourceAttr("fastssm_service_setting.test", "status", "Customized"),
+					resource.TestCheckResourceAttrSet("fastssm_service_setting.test", "arn"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "fastssm_service_setting.test",
+				ImportState:                          true,
+				ImportStateId:                        "/ssm/parameter-store/default-parameter-tier",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "setting_id",
+			},
+			// Update and Read testing
+			{
+				Config: testAccServiceSettingResourceConfig("Intelligent-Tiering"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("fastssm_service_setting.test", "setting_value", "Intelligent-Tiering"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase, resetting the setting
+		},
+	})
+}
+
+func testAccServiceSettingResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "fastssm_service_setting" "test" {
+  setting_id    = "/ssm/parameter-store/default-parameter-tier"
+  setting_value = %[1]q
+}
+`, value)
+}
+
+func TestServiceSettingID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		ID       string
+		Expected string
+	}{
+		{
+			Name:     "id",
+			ID:       "/ssm/parameter-store/high-throughput-enabled",
+			Expected: "/ssm/parameter-store/high-throughput-enabled",
+		},
+		{
+			Name:     "arn",
+			ID:       "arn:aws:ssm:eu-west-1:123456789012:servicesetting/ssm/parameter-store/default-parameter-tier",
+			Expected: "/ssm/parameter-store/default-parameter-tier",
+		},
+		{
+			Name:     "partition",
+			ID:       "arn:aws-cn:ssm:cn-north-1:123456789012:servicesetting/ssm/parameter-store/high-throughput-enabled",
+			Expected: "/ssm/parameter-store/high-throughput-enabled",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := serviceSettingID(testCase.ID); got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
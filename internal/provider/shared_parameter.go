@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// checkParameterARNRegion rejects the ARN of a parameter of another region than the
+// provider's. SSM reads the parameters shared through AWS RAM in their own region only,
+// and would answer with a confusing validation error. Plain names pass.
+func checkParameterARNRegion(name, region string) error {
+	if !strings.HasPrefix(name, "arn:") {
+		return nil
+	}
+
+	parsed, err := arn.Parse(name)
+	if err != nil {
+		return fmt.Errorf("%q is an invalid ARN: %s", name, err)
+	}
+	if parsed.Region != region {
+		return fmt.Errorf("the parameter %s is in region %s, but the provider reads region %s. "+
+			"Read it through a provider alias configured for %s", name, parsed.Region, region, parsed.Region)
+	}
+
+	return nil
+}
+
+// isSharedParameter reports whether the parameter is owned by another account and
+// shared through AWS RAM, SSM naming those by their full ARN.
+func isSharedParameter(parameter *ssm_types.Parameter) bool {
+	return strings.HasPrefix(aws.ToString(parameter.Name), "arn:")
+}
+
+// findSharedParameterMetadataByARN is findParameterMetadataByName for a parameter shared
+// with the account, which DescribeParameters only lists with `Shared`.
+func findSharedParameterMetadataByARN(ctx context.Context, conn *ssm.Client, parameterARN string) (*ssm_types.ParameterMetadata, error) {
+	input := &ssm.DescribeParametersInput{
+		ParameterFilters: []ssm_types.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("Equals"),
+				Values: []string{parameterARN},
+			},
+		},
+		Shared: aws.Bool(true),
+	}
+
+	output, err := conn.DescribeParameters(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.Parameters) != 1 {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	return &output.Parameters[0], nil
+}
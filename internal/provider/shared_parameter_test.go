@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestCheckParameterARNRegion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Input       string
+		ExpectError bool
+	}{
+		{Name: "name", Input: "/app/endpoint"},
+		{Name: "same region", Input: "arn:aws:ssm:eu-west-1:210987654321:parameter/app/endpoint"},
+		{Name: "other region", Input: "arn:aws:ssm:us-east-1:210987654321:parameter/app/endpoint", ExpectError: true},
+		{Name: "invalid", Input: "arn:aws:ssm", ExpectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkParameterARNRegion(testCase.Input, "eu-west-1")
+			if (err != nil) != testCase.ExpectError {
+				t.Errorf("expected error %t, got %v", testCase.ExpectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSharedRateLimitTPS is the throughput of GetParameter, the busiest call, with the standard throughput of SSM.
+const defaultSharedRateLimitTPS = 40
+
+// sharedRateLimiters are the limiters of the process by file, shared by the aliases
+// coordinating through the same file. The first alias sets the rate.
+var (
+	sharedRateLimitersMu sync.Mutex
+	sharedRateLimiters   = make(map[string]*sharedRateLimiter)
+)
+
+// sharedRateLimiter spaces the SSM API calls of every Terraform run on the host using
+// the same `shared_rate_limit_file`, so concurrent pipelines against an account share
+// its throughput instead of throttling each other.
+//
+// The file holds the time of the next free slot, taken under an exclusive lock by each
+// call. Up to a second of unused slots can be spent in a burst.
+type sharedRateLimiter struct {
+	interval time.Duration
+	burst    time.Duration
+
+	now func() time.Time
+
+	// mu serializes the goroutines of the process, the file lock only excludes the other processes
+	mu   sync.Mutex
+	file *os.File
+}
+
+// sharedRateLimiterFor returns the limiter of the process coordinating through path.
+func sharedRateLimiterFor(path string, tps int64) (*sharedRateLimiter, error) {
+	sharedRateLimitersMu.Lock()
+	defer sharedRateLimitersMu.Unlock()
+
+	if limiter, ok := sharedRateLimiters[path]; ok {
+		return limiter, nil
+	}
+
+	limiter, err := newSharedRateLimiter(path, tps)
+	if err != nil {
+		return nil, err
+	}
+	sharedRateLimiters[path] = limiter
+
+	return limiter, nil
+}
+
+func newSharedRateLimiter(path string, tps int64) (*sharedRateLimiter, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the shared rate limit file: %w", err)
+	}
+
+	return &sharedRateLimiter{
+		interval: time.Second / time.Duration(tps),
+		burst:    time.Second,
+		now:      time.Now,
+		file:     file,
+	}, nil
+}
+
+// addMiddleware registers the limiter on a client stack, for use in aws.Config.APIOptions.
+// Every attempt of the SSM API calls takes a slot, retries included.
+func (l *sharedRateLimiter) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("FastSSMSharedRateLimit", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if awsmiddleware.GetServiceID(ctx) == "SSM" {
+			if err := l.wait(ctx); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+		}
+
+		return next.HandleFinalize(ctx, in)
+	}), "Retry", middleware.After)
+}
+
+// wait takes the next slot and sleeps until it's due.
+func (l *sharedRateLimiter) wait(ctx context.Context) error {
+	slot, err := l.take()
+	if err != nil {
+		return err
+	}
+
+	if delay := slot.Sub(l.now()); delay > 0 {
+		tflog.Debug(ctx, "waiting for the shared SSM rate limit", map[string]any{"delay": delay.String()})
+		if !sleepContext(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// take reserves the next free slot in the file.
+func (l *sharedRateLimiter) take() (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := lockFile(l.file); err != nil {
+		return time.Time{}, fmt.Errorf("locking the shared rate limit file: %w", err)
+	}
+	defer func() { _ = unlockFile(l.file) }()
+
+	next, err := l.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	slot := l.now().Add(-l.burst)
+	if next.After(slot) {
+		slot = next
+	}
+
+	if err := l.write(slot.Add(l.interval)); err != nil {
+		return time.Time{}, err
+	}
+
+	return slot, nil
+}
+
+func (l *sharedRateLimiter) read() (time.Time, error) {
+	buf := make([]byte, 32)
+	n, err := l.file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return time.Time{}, fmt.Errorf("reading the shared rate limit file: %w", err)
+	}
+
+	nanos, err := strconv.ParseInt(strings.TrimSpace(string(buf[:n])), 10, 64)
+	if err != nil {
+		// A new file, or one left over by a crashed write, every slot is free
+		return time.Time{}, nil
+	}
+
+	return time.Unix(0, nanos), nil
+}
+
+func (l *sharedRateLimiter) write(next time.Time) error {
+	content := strconv.FormatInt(next.UnixNano(), 10) + "\n"
+
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("writing the shared rate limit file: %w", err)
+	}
+	if _, err := l.file.WriteAt([]byte(content), 0); err != nil {
+		return fmt.Errorf("writing the shared rate limit file: %w", err)
+	}
+
+	return nil
+}
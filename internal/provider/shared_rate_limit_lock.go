@@ -0,0 +1,17 @@
+//go:build !windows
+
+package provider
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on f, blocking until the other processes release it.
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
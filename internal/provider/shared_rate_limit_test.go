@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ssm.ratelimit")
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Two limiters on the same file stand for two Terraform runs
+	var limiters []*sharedRateLimiter
+	for range 2 {
+		limiter, err := newSharedRateLimiter(path, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		t.Cleanup(func() { limiter.file.Close() })
+		limiter.now = func() time.Time { return now }
+		limiters = append(limiters, limiter)
+	}
+
+	// A second of unused slots is spent in a burst, the next ones are spaced by 100ms
+	// whichever run takes them
+	for i := range 15 {
+		slot, err := limiters[i%2].take()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := now.Add(-time.Second).Add(time.Duration(i) * 100 * time.Millisecond)
+		if !slot.Equal(expected) {
+			t.Errorf("slot %d: expected %s, got %s", i, expected, slot)
+		}
+	}
+
+	// A corrupted file frees every slot
+	if err := os.WriteFile(path, []byte("garbage"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	slot, err := limiters[0].take()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := now.Add(-time.Second); !slot.Equal(expected) {
+		t.Errorf("expected %s after a corrupted file, got %s", expected, slot)
+	}
+}
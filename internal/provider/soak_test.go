@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-fastssm/internal/fakessm"
+	"terraform-provider-fastssm/internal/retry"
+	"terraform-provider-fastssm/internal/tfresource"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+const (
+	// soakEnvVar is the number of parameters read by TestSoak, it's skipped when unset
+	soakEnvVar = "FASTSSM_SOAK"
+	// soakThrottleEnvVar throttles every nth SSM request of the soak test, 10 by default
+	soakThrottleEnvVar = "FASTSSM_SOAK_THROTTLE_EVERY"
+
+	// soakReaders is the number of concurrent reads of every parameter, with and without decryption
+	soakReaders = 3
+	// soakConcurrency bounds the goroutines reading at once
+	soakConcurrency = 256
+)
+
+// TestSoak reads thousands of parameters concurrently, several times each, through
+// the client of the provider against the fake backend throttling a share of the
+// requests. Every read must return the right value, throttled calls must be retried,
+// and identical reads must be coalesced. Run it with the race detector, `make soak`.
+func TestSoak(t *testing.T) {
+	parameters, _ := strconv.Atoi(os.Getenv(soakEnvVar))
+	if parameters <= 0 {
+		t.Skipf("set %s to the number of parameters to run the soak test", soakEnvVar)
+	}
+	throttleEvery := int64(10)
+	if v, err := strconv.ParseInt(os.Getenv(soakThrottleEnvVar), 10, 64); err == nil {
+		throttleEvery = v
+	}
+
+	previousBackoff := throttleBackoff
+	throttleBackoff = 10 * time.Millisecond
+	t.Cleanup(func() { throttleBackoff = previousBackoff })
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion("eu-west-1"),
+		config.WithBaseEndpoint(server.URL),
+		config.WithCredentialsProvider(staticCredentials{accessKey: "soak", secretKey: "soak"}),
+		// As configured by the provider with retry_mode = "standard"
+		config.WithRetryMode(aws.RetryModeStandard),
+		config.WithRetryMaxAttempts(25),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newFastSSMClient(ssm.NewFromConfig(cfg), defaultParameterBatchOptions)
+
+	for i := range parameters {
+		typ := ssm_types.ParameterTypeString
+		if i%2 == 1 {
+			typ = ssm_types.ParameterTypeSecureString
+		}
+		if _, err := client.PutParameter(ctx, &ssm.PutParameterInput{
+			Name:  aws.String(soakParameterName(i)),
+			Value: aws.String(soakParameterValue(i)),
+			Type:  typ,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server.SetThrottling(throttleEvery)
+	before := server.Calls()
+	start := time.Now()
+
+	// A tenth of the reads look up missing parameters, as optional lookups do
+	missing := parameters / 10
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, soakConcurrency)
+	errs := make(chan error, (parameters+missing)*soakReaders*2)
+
+	for i := range parameters + missing {
+		for r := range soakReaders * 2 {
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if err := soakRead(ctx, client, i, parameters, r%2 == 0); err != nil {
+					errs <- err
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	after := server.Calls()
+	reads := (after["GetParameter"] - before["GetParameter"]) + (after["GetParameters"] - before["GetParameters"])
+	distinct := int64((parameters + missing) * 2)
+
+	t.Logf("parameters=%d reads=%d distinct=%d api_calls=%d throttled=%d time=%s",
+		parameters, (parameters+missing)*soakReaders*2, distinct, reads, server.Throttled(), time.Since(start).Round(time.Millisecond))
+
+	if throttleEvery > 0 && server.Throttled() == 0 {
+		t.Error("expected throttled requests")
+	}
+	// Every throttled request is retried, the others are one per batch at most
+	if successful := reads - server.Throttled(); successful > distinct {
+		t.Errorf("expected at most %d successful read calls, one per distinct read, got %d", distinct, successful)
+	}
+}
+
+// soakRead reads a parameter as the data source does, i >= parameters being a missing one.
+func soakRead(ctx context.Context, client *FastSSMClient, i, parameters int, withDecryption bool) error {
+	name := soakParameterName(i)
+
+	var res *ssm_types.Parameter
+	err := retry.RetryContext(ctx, 5*time.Minute, func() *retry.RetryError {
+		var erri error
+		res, erri = client.readParameter(ctx, name, withDecryption)
+		if erri != nil {
+			if isRetryableError(ctx, erri) {
+				return retry.RetryableError(erri)
+			}
+			return retry.NonRetryableError(erri)
+		}
+		return nil
+	})
+
+	if i >= parameters {
+		if !tfresource.NotFound(err) {
+			return fmt.Errorf("%s: expected not found, got %v", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	// A SecureString read without decryption doesn't return the value
+	if expected := soakParameterValue(i); (withDecryption || i%2 == 0) && aws.ToString(res.Value) != expected {
+		return fmt.Errorf("%s: expected %q, got %q", name, expected, aws.ToString(res.Value))
+	}
+
+	return nil
+}
+
+func soakParameterName(i int) string {
+	return fmt.Sprintf("/soak/%d/parameter-%d", i%100, i)
+}
+
+func soakParameterValue(i int) string {
+	return fmt.Sprintf("value-%d", i)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SplitStringListFunction{}
+
+func NewSplitStringListFunction() function.Function {
+	return &SplitStringListFunction{}
+}
+
+// SplitStringListFunction turns the value of a StringList parameter into a list of strings.
+type SplitStringListFunction struct{}
+
+func (f *SplitStringListFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_stringlist"
+}
+
+func (f *SplitStringListFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a StringList parameter value into a list",
+		Description: "Given the value of a `StringList` SSM parameter, returns its items as a list of strings. An empty value returns an empty list.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "Value of a `StringList` parameter, items separated by commas.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *SplitStringListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, splitStringList(value)))
+}
+
+// splitStringList splits on the StringList separator. SSM doesn't allow commas
+// within the items, so no escaping needs to be handled.
+func splitStringList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitStringListFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Value    string
+		Expected []attr.Value
+	}{
+		{
+			Name:     "empty value",
+			Value:    "",
+			Expected: []attr.Value{},
+		},
+		{
+			Name:     "single item",
+			Value:    "one",
+			Expected: []attr.Value{types.StringValue("one")},
+		},
+		{
+			Name:     "several items",
+			Value:    "one,two,three",
+			Expected: []attr.Value{types.StringValue("one"), types.StringValue("two"), types.StringValue("three")},
+		},
+		{
+			Name:     "empty items are kept",
+			Value:    "one,,three",
+			Expected: []attr.Value{types.StringValue("one"), types.StringValue(""), types.StringValue("three")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.Value)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewSplitStringListFunction().Run(context.Background(), req, &resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.ListValueMust(types.StringType, testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	// sweepPrefixEnvVar overrides the name prefix of the parameters removed by the sweeper
+	sweepPrefixEnvVar = "FASTSSM_SWEEP_PREFIX"
+	// defaultSweepPrefix is the prefix of the parameters created by the acceptance tests
+	defaultSweepPrefix = "/fastssm/acctest/"
+)
+
+func init() {
+	resource.AddTestSweepers("fastssm_parameter", &resource.Sweeper{
+		Name: "fastssm_parameter",
+		F:    sweepParameters,
+	})
+}
+
+// sweepParameters deletes the parameters left behind by failed acceptance test runs in
+// a region, the ones whose name starts with the sweep prefix.
+func sweepParameters(region string) error {
+	prefix := os.Getenv(sweepPrefixEnvVar)
+	if prefix == "" {
+		prefix = defaultSweepPrefix
+	}
+	// A prefix this short would match every parameter of the account
+	if len(prefix) < 2 {
+		return fmt.Errorf("refusing to sweep parameters with the prefix %q", prefix)
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return err
+	}
+	conn := ssm.NewFromConfig(cfg)
+
+	var names []string
+	pages := ssm.NewDescribeParametersPaginator(conn, &ssm.DescribeParametersInput{
+		ParameterFilters: []ssm_types.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("BeginsWith"),
+				Values: []string{prefix},
+			},
+		},
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing parameters with the prefix %s: %w", prefix, err)
+		}
+		for _, parameter := range page.Parameters {
+			names = append(names, aws.ToString(parameter.Name))
+		}
+	}
+
+	// DeleteParameters takes up to 10 names
+	for i := 0; i < len(names); i += 10 {
+		batch := names[i:min(i+10, len(names))]
+
+		output, err := conn.DeleteParameters(ctx, &ssm.DeleteParametersInput{Names: batch})
+		if err != nil {
+			return fmt.Errorf("deleting parameters %v: %w", batch, err)
+		}
+		for _, name := range output.DeletedParameters {
+			log.Printf("[INFO] swept parameter %s in %s", name, region)
+		}
+	}
+
+	log.Printf("[INFO] swept %d parameters with the prefix %s in %s", len(names), prefix, region)
+
+	return nil
+}
+
+func TestSweepParameters(t *testing.T) {
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv(sweepPrefixEnvVar, "")
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion("eu-west-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := ssm.NewFromConfig(cfg)
+
+	// More than a DeleteParameters batch
+	var swept []string
+	for i := range 12 {
+		swept = append(swept, fmt.Sprintf("%sleftover-%d", defaultSweepPrefix, i))
+	}
+	kept := []string{"/fastssm/other", "/app/config"}
+
+	for _, name := range append(slices.Clone(swept), kept...) {
+		if _, err := conn.PutParameter(ctx, &ssm.PutParameterInput{Name: aws.String(name), Value: aws.String("v"), Type: ssm_types.ParameterTypeString}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := sweepParameters("eu-west-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testAccCheckParameterDestroyed(swept...)(nil); err != nil {
+		t.Errorf("expected the leftovers to be swept: %s", err)
+	}
+	for _, name := range kept {
+		if _, err := conn.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(name)}); err != nil {
+			t.Errorf("expected %s to be kept: %s", name, err)
+		}
+	}
+}
+
+func TestSweepParametersRefusesShortPrefix(t *testing.T) {
+	t.Setenv(sweepPrefixEnvVar, "/")
+
+	if err := sweepParameters("eu-west-1"); err == nil {
+		t.Error("expected an error for the prefix /")
+	}
+}
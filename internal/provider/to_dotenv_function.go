@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/YakDriver/regexache"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// dotenvBareValueRegexp matches the values written without quotes, read the same by
+// every dotenv parser and by `docker run --env-file`, which doesn't unquote.
+var dotenvBareValueRegexp = regexache.MustCompile(`^[0-9A-Za-z_./:@%+,=-]+$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ToDotenvFunction{}
+
+func NewToDotenvFunction() function.Function {
+	return &ToDotenvFunction{}
+}
+
+// ToDotenvFunction renders parameters as the lines of a dotenv file.
+type ToDotenvFunction struct{}
+
+func (f *ToDotenvFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_dotenv"
+}
+
+func (f *ToDotenvFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render parameters as a dotenv file",
+		Description: "Given a map of parameter names to values, like the `values` of the `fastssm_parameters_by_path` ephemeral resource, " +
+			"returns a `KEY=value` line per parameter, sorted by key, e.g. for container env files or user data. The key is the name without `path`, " +
+			"upper-cased, with the characters other than letters, digits and `_` replaced by `_`: `/app/prod/db/host` under `/app/prod` becomes `DB_HOST`. " +
+			"Values made of letters, digits and `_./:@%+,=-` are written as they are, the others in double quotes, escaping `\\`, `\"`, `$` and newlines. " +
+			"Fails when a name isn't under `path`, or when two names result in the same key. Sensitive values keep the result sensitive.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:        "parameters",
+				ElementType: types.StringType,
+				Description: "Values of the parameters, keyed by name.",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path removed from the names to form the keys, `\"\"` to keep the whole names.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ToDotenvFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parameters map[string]string
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &parameters, &path))
+	if resp.Error != nil {
+		return
+	}
+
+	dotenv, err := toDotenv(parameters, path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, dotenv))
+}
+
+// toDotenv renders a line per parameter, sorted by key, each ending with a newline.
+func toDotenv(parameters map[string]string, path string) (string, error) {
+	path = "/" + strings.Trim(path, "/")
+
+	keys := make(map[string]string, len(parameters))
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		key, err := dotenvKey(name, path)
+		if err != nil {
+			return "", err
+		}
+
+		if other, ok := keys[key]; ok {
+			return "", fmt.Errorf("%s and %s both result in the key %s", other, name, key)
+		}
+		keys[key] = name
+	}
+
+	var b strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		fmt.Fprintf(&b, "%s=%s\n", key, dotenvValue(parameters[keys[key]]))
+	}
+
+	return b.String(), nil
+}
+
+// dotenvKey turns the name of a parameter under path into an environment variable name.
+func dotenvKey(name, path string) (string, error) {
+	relative := "/" + strings.Trim(name, "/")
+	if path != "/" {
+		if !strings.HasPrefix(relative, path+"/") {
+			return "", fmt.Errorf("%s is not under %s", name, path)
+		}
+		relative = strings.TrimPrefix(relative, path)
+	}
+
+	key := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimPrefix(relative, "/"))
+
+	if key == "" {
+		return "", fmt.Errorf("%q has no name to form a key", name)
+	}
+
+	// Variable names can't start with a digit
+	if key[0] >= '0' && key[0] <= '9' {
+		key = "_" + key
+	}
+
+	return key, nil
+}
+
+// dotenvValue quotes the values that aren't read the same by every parser otherwise.
+func dotenvValue(value string) string {
+	if dotenvBareValueRegexp.MatchString(value) {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestToDotenvFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Parameters  map[string]attr.Value
+		Path        string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name: "keys relative to the path",
+			Parameters: map[string]attr.Value{
+				"/app/prod/db/host":   types.StringValue("db.example.com"),
+				"/app/prod/db/port":   types.StringValue("5432"),
+				"/app/prod/log-level": types.StringValue("info"),
+			},
+			Path:     "/app/prod",
+			Expected: "DB_HOST=db.example.com\nDB_PORT=5432\nLOG_LEVEL=info\n",
+		},
+		{
+			Name:       "trailing slash in the path",
+			Parameters: map[string]attr.Value{"/app/prod/db/host": types.StringValue("db.example.com")},
+			Path:       "/app/prod/",
+			Expected:   "DB_HOST=db.example.com\n",
+		},
+		{
+			Name:       "whole names",
+			Parameters: map[string]attr.Value{"/app/prod/db.host": types.StringValue("db.example.com")},
+			Path:       "",
+			Expected:   "APP_PROD_DB_HOST=db.example.com\n",
+		},
+		{
+			Name:       "leading digit",
+			Parameters: map[string]attr.Value{"/app/2fa": types.StringValue("on")},
+			Path:       "/app",
+			Expected:   "_2FA=on\n",
+		},
+		{
+			Name: "quoted values",
+			Parameters: map[string]attr.Value{
+				"/app/empty":    types.StringValue(""),
+				"/app/greeting": types.StringValue(`say "hi" to $USER`),
+				"/app/key":      types.StringValue("-----BEGIN KEY-----\nabc\\def\n-----END KEY-----"),
+			},
+			Path:     "/app",
+			Expected: "EMPTY=\"\"\nGREETING=\"say \\\"hi\\\" to \\$USER\"\nKEY=\"-----BEGIN KEY-----\\nabc\\\\def\\n-----END KEY-----\"\n",
+		},
+		{
+			Name:       "no parameters",
+			Parameters: map[string]attr.Value{},
+			Path:       "/app",
+			Expected:   "",
+		},
+		{
+			Name:        "name outside the path",
+			Parameters:  map[string]attr.Value{"/application/db": types.StringValue("a")},
+			Path:        "/app",
+			ExpectError: true,
+		},
+		{
+			Name: "colliding keys",
+			Parameters: map[string]attr.Value{
+				"/app/db/host": types.StringValue("a"),
+				"/app/db-host": types.StringValue("b"),
+			},
+			Path:        "/app",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.MapValueMust(types.StringType, testCase.Parameters),
+					types.StringValue(testCase.Path),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewToDotenvFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			expected := function.NewResultData(types.StringValue(testCase.Expected))
+			if diff := cmp.Diff(resp.Result, expected); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/YakDriver/regexache"
@@ -12,12 +14,23 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 )
 
 var accountIDRegexp = regexache.MustCompile(`^(aws|aws-managed|third-party|\d{12}|cw.{10})$`)
 var partitionRegexp = regexache.MustCompile(`^aws(-[a-z]+)*$`)
 var regionRegexp = regexache.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+var parameterNameRegexp = regexache.MustCompile(`^[0-9A-Za-z_.\-/]+$`)
+var kmsKeyIDRegexp = regexache.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|mrk-[0-9a-f]{32})$`)
+var mfaTokenCodeRegexp = regexache.MustCompile(`^[0-9]{6}$`)
+var kmsAliasNameRegexp = regexache.MustCompile(`^alias/[0-9A-Za-z/_-]+$`)
+
+const (
+	kmsAliasNameMaxLength  = 256
+	parameterNameMaxLength = 2048
+	parameterNameMaxDepth  = 15
+	// Advanced parameters hold up to 8 KB, standard ones 4 KB
+	parameterValueMaxLength = 8192
+)
 
 // validates all listed in https://gist.github.com/shortjared/4c1e3fe52bdfa47522cfe5b41e5d6f22
 // var  = regexache.MustCompile(`^([0-9a-z-]+\.){1,4}(amazonaws|amazon)\.com$`)
@@ -58,6 +71,43 @@ func (v durationValidator) ValidateString(ctx context.Context, req validator.Str
 	}
 }
 
+// timeoutValidator validates a positive duration.
+type timeoutValidator struct{}
+
+func (v timeoutValidator) Description(ctx context.Context) string {
+	return "Validates that the duration is positive with valid time units (ns, us, µs, ms, s, m, h)."
+}
+
+func (v timeoutValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeoutValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	val := req.ConfigValue.ValueString()
+
+	duration, err := time.ParseDuration(val)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"error parsing duration",
+			fmt.Sprintf("%q cannot be parsed as a duration: %v", val, err),
+		)
+		return
+	}
+
+	if duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"invalid timeout",
+			fmt.Sprintf("timeout %q must be positive", val),
+		)
+	}
+}
+
 type jsonValidator struct{}
 
 func (v jsonValidator) Description(ctx context.Context) string {
@@ -84,7 +134,8 @@ func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringR
 		return
 	}
 
-	if _, err := structure.NormalizeJsonString(v); err != nil {
+	var j any
+	if err := json.Unmarshal([]byte(val), &j); err != nil {
 		resp.Diagnostics.AddError(
 			"invalid json input",
 			fmt.Sprintf("%q contains an invalid JSON: %s", val, err),
@@ -92,6 +143,32 @@ func (v jsonValidator) ValidateString(ctx context.Context, req validator.StringR
 	}
 }
 
+// jsonMergePatchValidator validates that the supplied string is a JSON object.
+type jsonMergePatchValidator struct{}
+
+func (v jsonMergePatchValidator) Description(ctx context.Context) string {
+	return "Validates that the supplied string is a JSON object"
+}
+
+func (v jsonMergePatchValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonMergePatchValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The patch may hold secrets, it isn't quoted
+	if _, err := decodeJSONMergePatch(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"invalid JSON merge patch",
+			err.Error(),
+		)
+	}
+}
+
 type arnValidator struct {
 	kind string
 }
@@ -189,6 +266,214 @@ func validateArn(value attr.Value) (diag diag.Diagnostics) {
 	return
 }
 
+// validateParameterName checks a parameter name against the constraints documented in
+// https://docs.aws.amazon.com/systems-manager/latest/userguide/sysman-parameter-name-constraints.html
+func validateParameterName(name string) error {
+	if name == "" {
+		return fmt.Errorf("parameter name must not be empty")
+	}
+
+	if len(name) > parameterNameMaxLength {
+		return fmt.Errorf("parameter name %q is longer than %d characters", name, parameterNameMaxLength)
+	}
+
+	if !parameterNameRegexp.MatchString(name) {
+		return fmt.Errorf("parameter name %q can only contain letters, numbers and the symbols `.`, `-`, `_` and `/`", name)
+	}
+
+	if !strings.Contains(name, "/") {
+		return validateParameterNamePrefix(name)
+	}
+
+	if !strings.HasPrefix(name, "/") {
+		return fmt.Errorf("parameter name %q contains a path, so it must be fully qualified with a leading forward slash (/)", name)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	if len(segments) > parameterNameMaxDepth {
+		return fmt.Errorf("parameter name %q has %d levels, the maximum is %d", name, len(segments), parameterNameMaxDepth)
+	}
+
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("parameter name %q contains an empty path segment", name)
+		}
+	}
+
+	return validateParameterNamePrefix(segments[0])
+}
+
+// validateParameterNamePrefix rejects the prefixes reserved by AWS.
+func validateParameterNamePrefix(segment string) error {
+	lower := strings.ToLower(segment)
+	for _, reserved := range []string{"aws", "ssm"} {
+		if strings.HasPrefix(lower, reserved) {
+			return fmt.Errorf("parameter name can't be prefixed with %q (case-insensitive), got %q", reserved, segment)
+		}
+	}
+
+	return nil
+}
+
+// stringListValidator validates the value of a parameter when its type is StringList,
+// so malformed lists fail the plan on the attribute instead of the apply with a ValidationException.
+type stringListValidator struct{}
+
+func (v stringListValidator) Description(ctx context.Context) string {
+	return "Validates that a StringList value has no empty items and fits the size of a parameter value."
+}
+
+func (v stringListValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringListValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var parameterType types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("type"), &parameterType)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || parameterType.ValueString() != "StringList" {
+		return
+	}
+
+	if err := validateStringList(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"invalid StringList value",
+			err.Error(),
+		)
+	}
+}
+
+// validateStringList checks the value of a StringList parameter. The value may be
+// sensitive, so errors only point at the offending items by position.
+func validateStringList(value string) error {
+	if value == "" {
+		return fmt.Errorf("a StringList value needs at least one item")
+	}
+
+	if len(value) > parameterValueMaxLength {
+		return fmt.Errorf("the StringList value is %d bytes long, the maximum is %d", len(value), parameterValueMaxLength)
+	}
+
+	var empty []string
+	for i, item := range strings.Split(value, ",") {
+		if item == "" {
+			empty = append(empty, fmt.Sprint(i))
+		}
+	}
+
+	if len(empty) > 0 {
+		return fmt.Errorf("the StringList value has empty items at positions %s, check for leading, trailing or repeated commas", strings.Join(empty, ", "))
+	}
+
+	return nil
+}
+
+// kmsKeyIDValidator validates a reference to the KMS key of a SecureString parameter,
+// PutParameter only reporting an invalid one with a cryptic InvalidKeyId.
+type kmsKeyIDValidator struct{}
+
+func (v kmsKeyIDValidator) Description(ctx context.Context) string {
+	return "Validates that the value is a KMS key ID, key ARN, alias name or alias ARN."
+}
+
+func (v kmsKeyIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v kmsKeyIDValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateKMSKeyID(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"invalid KMS key",
+			err.Error(),
+		)
+	}
+}
+
+// validateKMSKeyID checks the formats accepted as the KeyId of PutParameter: a key ID,
+// e.g. `1234abcd-12ab-34cd-56ef-1234567890ab` or `mrk-` for multi-Region keys, a key
+// ARN, an alias name, e.g. `alias/my-key`, or an alias ARN.
+func validateKMSKeyID(value string) error {
+	switch {
+	case value == "":
+		return fmt.Errorf("the KMS key must not be empty, leave it unset to use the AWS managed key alias/aws/ssm")
+	case strings.HasPrefix(value, "arn:"):
+		return validateKMSKeyARN(value)
+	case strings.HasPrefix(value, "alias/"):
+		return validateKMSAliasName(value)
+	case kmsKeyIDRegexp.MatchString(value):
+		return nil
+	case kmsKeyIDRegexp.MatchString(strings.ToLower(value)):
+		return fmt.Errorf("%q is a KMS key ID with upper-case letters, key IDs are lower-case", value)
+	case strings.HasPrefix(value, "key/"):
+		return fmt.Errorf("%q looks like the resource of a key ARN, use the key ID alone, without `key/`, or the whole ARN", value)
+	}
+
+	return fmt.Errorf("%q is neither a key ID, e.g. `1234abcd-12ab-34cd-56ef-1234567890ab`, a key ARN, an alias name starting with `alias/` nor an alias ARN. "+
+		"An alias needs its `alias/` prefix", value)
+}
+
+// validateKMSKeyARN checks the ARN of a KMS key or alias, the region and account
+// telling where the key is, unlike in key IDs and alias names.
+func validateKMSKeyARN(value string) error {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q is an invalid ARN: %s", value, err)
+	}
+
+	if parsed.Service != "kms" {
+		return fmt.Errorf("%q is an ARN of the %s service, expected a KMS key or alias ARN", value, parsed.Service)
+	}
+	if !partitionRegexp.MatchString(parsed.Partition) {
+		return fmt.Errorf("%q has an invalid partition %q", value, parsed.Partition)
+	}
+	if !regionRegexp.MatchString(parsed.Region) {
+		return fmt.Errorf("%q has an invalid region %q, a KMS key ARN names the region of the key", value, parsed.Region)
+	}
+	if !awsAccountIDRegexp.MatchString(parsed.AccountID) {
+		return fmt.Errorf("%q has an invalid account ID %q, expected 12 digits", value, parsed.AccountID)
+	}
+
+	switch {
+	case strings.HasPrefix(parsed.Resource, "key/"):
+		if id := strings.TrimPrefix(parsed.Resource, "key/"); !kmsKeyIDRegexp.MatchString(id) {
+			return fmt.Errorf("%q names an invalid key ID %q", value, id)
+		}
+		return nil
+	case strings.HasPrefix(parsed.Resource, "alias/"):
+		if err := validateKMSAliasName(parsed.Resource); err != nil {
+			return fmt.Errorf("%q names an invalid alias: %s", value, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%q names neither a key, `key/...`, nor an alias, `alias/...`", value)
+}
+
+// validateKMSAliasName checks the name of an alias, prefix included.
+func validateKMSAliasName(value string) error {
+	if value == "alias/" {
+		return fmt.Errorf("the alias name is missing after `alias/`")
+	}
+	if len(value) > kmsAliasNameMaxLength {
+		return fmt.Errorf("alias %q is longer than %d characters", value, kmsAliasNameMaxLength)
+	}
+	if !kmsAliasNameRegexp.MatchString(value) {
+		return fmt.Errorf("alias %q can only contain letters, numbers and the symbols `/`, `_` and `-`", value)
+	}
+
+	return nil
+}
+
 // Custom validator to ensure param_b is set only if param_a has a specific value
 type dependentParameterValidator struct {
 	dependentParamName string
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateNameFunction{}
+
+func NewValidateNameFunction() function.Function {
+	return &ValidateNameFunction{}
+}
+
+// ValidateNameFunction asserts that a parameter name can be created in SSM.
+type ValidateNameFunction struct{}
+
+func (f *ValidateNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_name"
+}
+
+func (f *ValidateNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate an SSM parameter name",
+		Description: "Returns the given name unchanged if it satisfies the [AWS parameter name constraints](https://docs.aws.amazon.com/systems-manager/latest/userguide/sysman-parameter-name-constraints.html), and fails otherwise. " +
+			"Wrap it in `can()` to use it in a variable validation block.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Candidate parameter name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ValidateNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateParameterName(name); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateNameFunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Value       string
+		ExpectError bool
+	}{
+		{
+			Name:  "flat name",
+			Value: "db-password",
+		},
+		{
+			Name:  "hierarchical name",
+			Value: "/app/prod/db_password.v2",
+		},
+		{
+			Name:        "empty",
+			Value:       "",
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid characters",
+			Value:       "/app/prod/db password",
+			ExpectError: true,
+		},
+		{
+			Name:        "path without leading slash",
+			Value:       "app/prod/db",
+			ExpectError: true,
+		},
+		{
+			Name:        "empty segment",
+			Value:       "/app//db",
+			ExpectError: true,
+		},
+		{
+			Name:        "trailing slash",
+			Value:       "/app/db/",
+			ExpectError: true,
+		},
+		{
+			Name:        "reserved aws prefix",
+			Value:       "/AWS/app",
+			ExpectError: true,
+		},
+		{
+			Name:        "reserved ssm prefix",
+			Value:       "ssm-param",
+			ExpectError: true,
+		},
+		{
+			Name:        "too deep",
+			Value:       strings.Repeat("/a", 16),
+			ExpectError: true,
+		},
+		{
+			Name:        "too long",
+			Value:       "/" + strings.Repeat("a", 2048),
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.Value)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewValidateNameFunction().Run(context.Background(), req, &resp)
+
+			if testCase.ExpectError {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(testCase.Value)) {
+				t.Errorf("got %s, expected %q", got, testCase.Value)
+			}
+		})
+	}
+}
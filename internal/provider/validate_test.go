@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateStringList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Value       string
+		ExpectError string
+	}{
+		{
+			Name:  "single item",
+			Value: "a",
+		},
+		{
+			Name:  "several items",
+			Value: "a,b,c",
+		},
+		{
+			Name:  "items with spaces",
+			Value: "a b, c",
+		},
+		{
+			Name:        "empty",
+			ExpectError: "at least one item",
+		},
+		{
+			Name:        "repeated comma",
+			Value:       "a,,b",
+			ExpectError: "positions 1,",
+		},
+		{
+			Name:        "leading and trailing commas",
+			Value:       ",a,",
+			ExpectError: "positions 0, 2,",
+		},
+		{
+			Name:        "only a comma",
+			Value:       ",",
+			ExpectError: "positions 0, 1,",
+		},
+		{
+			Name:  "maximum length",
+			Value: strings.Repeat("a,", parameterValueMaxLength/2-1) + "ab",
+		},
+		{
+			Name:        "too long",
+			Value:       strings.Repeat("a,", parameterValueMaxLength/2) + "a",
+			ExpectError: "8193 bytes long",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateStringList(testCase.Value)
+
+			if testCase.ExpectError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectError) {
+				t.Errorf("expected an error containing %q, got: %v", testCase.ExpectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateKMSKeyID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Value       string
+		ExpectError string
+	}{
+		{
+			Name:  "key ID",
+			Value: "1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			Name:  "multi-Region key ID",
+			Value: "mrk-1234abcd12ab34cd56ef1234567890ab",
+		},
+		{
+			Name:  "key ARN",
+			Value: "arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			Name:  "alias name",
+			Value: "alias/team/my-key",
+		},
+		{
+			Name:  "AWS managed alias",
+			Value: "alias/aws/ssm",
+		},
+		{
+			Name:  "alias ARN",
+			Value: "arn:aws-us-gov:kms:us-gov-west-1:111122223333:alias/my-key",
+		},
+		{
+			Name:        "empty",
+			ExpectError: "must not be empty",
+		},
+		{
+			Name:        "alias without prefix",
+			Value:       "my-key",
+			ExpectError: "needs its `alias/` prefix",
+		},
+		{
+			Name:        "upper-case key ID",
+			Value:       "1234ABCD-12AB-34CD-56EF-1234567890AB",
+			ExpectError: "key IDs are lower-case",
+		},
+		{
+			Name:        "key resource",
+			Value:       "key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectError: "without `key/`",
+		},
+		{
+			Name:        "empty alias",
+			Value:       "alias/",
+			ExpectError: "missing after `alias/`",
+		},
+		{
+			Name:        "alias with spaces",
+			Value:       "alias/my key",
+			ExpectError: "can only contain",
+		},
+		{
+			Name:        "alias too long",
+			Value:       "alias/" + strings.Repeat("a", kmsAliasNameMaxLength),
+			ExpectError: "longer than 256",
+		},
+		{
+			Name:        "ARN of another service",
+			Value:       "arn:aws:iam::111122223333:role/my-role",
+			ExpectError: "ARN of the iam service",
+		},
+		{
+			Name:        "ARN without region",
+			Value:       "arn:aws:kms::111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectError: "invalid region",
+		},
+		{
+			Name:        "ARN with an invalid account",
+			Value:       "arn:aws:kms:eu-west-1:1111:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectError: "invalid account ID",
+		},
+		{
+			Name:        "ARN with an invalid key ID",
+			Value:       "arn:aws:kms:eu-west-1:111122223333:key/my-key",
+			ExpectError: "invalid key ID",
+		},
+		{
+			Name:        "ARN of another KMS resource",
+			Value:       "arn:aws:kms:eu-west-1:111122223333:grant/abc",
+			ExpectError: "neither a key",
+		},
+		{
+			Name:        "malformed ARN",
+			Value:       "arn:aws:kms",
+			ExpectError: "invalid ARN",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateKMSKeyID(testCase.Value)
+
+			if testCase.ExpectError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectError) {
+				t.Errorf("expected an error containing %q, got: %v", testCase.ExpectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readValueFile returns the content of a `value_file` and its hash.
+func readValueFile(name string) (content string, hash string, err error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(b), valueHash(string(b)), nil
+}
+
+// valueHash is the hex SHA-256 of a value, stored in `value_file_sha256` so the
+// state holds no copy of the file and changes on either side show up in the plan.
+func valueHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// planValueFileHash plans `value_file_sha256` from the content of `value_file` as it
+// is on disk now. Read stores the hash of the value in SSM, a difference is an update.
+func planValueFileHash(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var valueFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("value_file"), &valueFile)...)
+	if resp.Diagnostics.HasError() || valueFile.IsUnknown() {
+		return
+	}
+
+	hash := types.StringNull()
+	if !valueFile.IsNull() {
+		content, sum, err := readValueFile(valueFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("value_file"), "Unable to read value_file", err.Error())
+			return
+		}
+		hash = types.StringValue(sum)
+
+		var parameterType types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &parameterType)...)
+		if parameterType.ValueString() == "StringList" {
+			if err := validateStringList(content); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("value_file"), "invalid StringList value", err.Error())
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value_file_sha256"), hash)...)
+}
+
+// valueFileContent is the content of `value_file` at apply time, which must still be
+// the one planned.
+func valueFileContent(data ParameterResourceModel) (string, error) {
+	content, hash, err := readValueFile(data.ValueFile.ValueString())
+	if err != nil {
+		return "", err
+	}
+	if hash != data.ValueFileSHA256.ValueString() {
+		return "", fmt.Errorf("%s changed after the plan, plan again", data.ValueFile.ValueString())
+	}
+
+	return content, nil
+}
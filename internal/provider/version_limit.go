@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// maxParameterVersions is the number of versions SSM keeps for a parameter. Every write
+// past it drops the oldest version, and fails when that one carries a label.
+const maxParameterVersions = 100
+
+// getParameterHistoryMaxResults is the page size limit of GetParameterHistory.
+const getParameterHistoryMaxResults = 50
+
+// writesBeforeVersionLimit is the number of writes of a parameter at version that
+// succeed before one has to drop the labeled version, and fails.
+func writesBeforeVersionLimit(version, labeled int64) int64 {
+	return labeled + maxParameterVersions - 1 - version
+}
+
+// oldestLabeledVersion returns the oldest version of the parameter carrying a label,
+// 0 when none does. The history is listed from the oldest version, the listing
+// stops at the first label.
+func oldestLabeledVersion(ctx context.Context, conn *ssm.Client, name string) (int64, error) {
+	input := &ssm.GetParameterHistoryInput{
+		Name:       aws.String(name),
+		MaxResults: aws.Int32(getParameterHistoryMaxResults),
+	}
+
+	pages := ssm.NewGetParameterHistoryPaginator(conn, input)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, version := range page.Parameters {
+			if len(version.Labels) > 0 {
+				return version.Version, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// checkVersionLimit warns when the planned write of a parameter at version leaves at
+// most threshold writes before SSM's 100-version limit meets a labeled version. The
+// version in state rules out labels old enough without any call, the history is only
+// read for the parameters close to the limit.
+func checkVersionLimit(ctx context.Context, conn *ssm.Client, name string, version, threshold int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Every version kept could carry a label, the oldest one included
+	oldest := max(1, version-maxParameterVersions+1)
+	if writesBeforeVersionLimit(version, oldest) > threshold {
+		return diags
+	}
+
+	labeled, err := oldestLabeledVersion(ctx, conn, name)
+	if err != nil {
+		diags.AddWarning(
+			"Version limit check failed",
+			fmt.Sprintf("SSM Parameter %s is at version %d, reading its history to check the labels of its oldest versions failed: %s\n\n"+
+				"Grant ssm:GetParameterHistory to the identity of the provider, or unset version_limit_warning_threshold.", name, version, describeError(err)),
+		)
+		return diags
+	}
+
+	if labeled == 0 {
+		return diags
+	}
+
+	writes := writesBeforeVersionLimit(version, labeled)
+	if writes > threshold {
+		return diags
+	}
+
+	diags.AddWarning(
+		"SSM parameter nearing its version limit",
+		versionLimitWarning(name, version, labeled, writes),
+	)
+
+	return diags
+}
+
+// versionLimitWarning explains why the writes of a parameter are about to fail.
+func versionLimitWarning(name string, version, labeled, writes int64) string {
+	outcome := fmt.Sprintf("Only %d more writes will succeed", writes)
+	if writes <= 0 {
+		outcome = "This write will fail with ParameterMaxVersionLimitExceeded"
+	}
+
+	return fmt.Sprintf("SSM Parameter %s is at version %d and version %d carries a label. SSM keeps %d versions of a parameter "+
+		"and drops the oldest one on every write, but never a labeled one: once version %d is the oldest, writes fail. %s.\n\n"+
+		"Move the label to a newer version, e.g. with the `fastssm_parameter_label` action, or remove it.",
+		name, version, labeled, maxParameterVersions, labeled, outcome)
+}
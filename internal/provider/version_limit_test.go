@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-fastssm/internal/fakessm"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssm_types "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+func TestWritesBeforeVersionLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Version  int64
+		Labeled  int64
+		Expected int64
+	}{
+		{Name: "first version labeled", Version: 1, Labeled: 1, Expected: 99},
+		{Name: "one write left", Version: 99, Labeled: 1, Expected: 1},
+		{Name: "labeled version oldest", Version: 100, Labeled: 1, Expected: 0},
+		{Name: "recent label", Version: 150, Labeled: 140, Expected: 89},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := writesBeforeVersionLimit(testCase.Version, testCase.Labeled); got != testCase.Expected {
+				t.Errorf("expected %d, got %d", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckVersionLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakessm.NewServer()
+	defer server.Close()
+
+	conn := ssm.NewFromConfig(aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  staticCredentials{accessKey: "test", secretKey: "test"},
+	})
+
+	for range 95 {
+		_, err := conn.PutParameter(ctx, &ssm.PutParameterInput{
+			Name:      aws.String("/app/config"),
+			Value:     aws.String("value"),
+			Type:      ssm_types.ParameterTypeString,
+			Overwrite: aws.Bool(true),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// Far from the limit, the history isn't read
+	if diags := checkVersionLimit(ctx, conn, "/missing", 80, 10); diags.WarningsCount() != 0 {
+		t.Errorf("expected no warnings, got %v", diags)
+	}
+
+	// Close to the limit without labels
+	if diags := checkVersionLimit(ctx, conn, "/app/config", 95, 10); diags.WarningsCount() != 0 {
+		t.Errorf("expected no warnings, got %v", diags)
+	}
+
+	_, err := conn.LabelParameterVersion(ctx, &ssm.LabelParameterVersionInput{
+		Name:             aws.String("/app/config"),
+		ParameterVersion: aws.Int64(3),
+		Labels:           []string{"stable"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Version 3 is dropped by the write of version 103, 7 writes from version 95
+	if diags := checkVersionLimit(ctx, conn, "/app/config", 95, 5); diags.WarningsCount() != 0 {
+		t.Errorf("expected no warnings, got %v", diags)
+	}
+
+	diags := checkVersionLimit(ctx, conn, "/app/config", 95, 10)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected a warning, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "version 3 carries a label") || !strings.Contains(detail, "Only 7 more writes") {
+		t.Errorf("unexpected warning: %s", detail)
+	}
+
+	// The history can't be read
+	diags = checkVersionLimit(ctx, conn, "/missing", 95, 10)
+	if diags.WarningsCount() != 1 || diags[0].Summary() != "Version limit check failed" {
+		t.Errorf("expected a failed check warning, got %v", diags)
+	}
+}
+
+func TestVersionLimitWarning(t *testing.T) {
+	t.Parallel()
+
+	if warning := versionLimitWarning("/app/config", 100, 1, 0); !strings.Contains(warning, "This write will fail with ParameterMaxVersionLimitExceeded") {
+		t.Errorf("unexpected warning: %s", warning)
+	}
+}
@@ -0,0 +1,102 @@
+// Package retry retries operations until they succeed, fail permanently or run
+// out of time. It follows the API of helper/retry of the plugin SDK, without
+// depending on it.
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// minBackoff is the wait after the first failed attempt, doubled after each one
+	minBackoff = 500 * time.Millisecond
+	// maxBackoff caps the wait between two attempts
+	maxBackoff = 10 * time.Second
+)
+
+// RetryFunc is the function retried until it succeeds.
+type RetryFunc func() *RetryError
+
+// RetryError is the required return type of RetryFunc. It forces client code
+// to choose whether or not a given error is retryable.
+type RetryError struct {
+	Err       error
+	Retryable bool
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryableError wraps an error that's worth another attempt. A nil error
+// results in a non-retryable error, as it's a bug of the caller.
+func RetryableError(err error) *RetryError {
+	if err == nil {
+		return &RetryError{
+			Err:       errors.New("empty retryable error received, this is a bug of the provider"),
+			Retryable: false,
+		}
+	}
+
+	return &RetryError{Err: err, Retryable: true}
+}
+
+// NonRetryableError wraps an error that ends the retries. A nil error results
+// in a non-retryable error as well, as it's a bug of the caller.
+func NonRetryableError(err error) *RetryError {
+	if err == nil {
+		return &RetryError{
+			Err:       errors.New("empty non-retryable error received, this is a bug of the provider"),
+			Retryable: false,
+		}
+	}
+
+	return &RetryError{Err: err, Retryable: false}
+}
+
+// RetryContext calls f until it succeeds, returns a non-retryable error, the
+// timeout elapses or ctx is done. The wait between attempts grows exponentially.
+// Once the retries are over, the last error of f is returned, or a TimeoutError
+// or the context error if f never failed.
+func RetryContext(ctx context.Context, timeout time.Duration, f RetryFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	backoff := minBackoff
+
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		rerr := f()
+		if rerr == nil {
+			return nil
+		}
+		if !rerr.Retryable {
+			return rerr.Err
+		}
+		lastErr = rerr.Err
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	if lastErr != nil {
+		return lastErr
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{Timeout: timeout, ExpectedState: []string{"success"}}
+	}
+
+	return ctx.Err()
+}
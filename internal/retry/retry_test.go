@@ -0,0 +1,119 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryContext(t *testing.T) {
+	t.Parallel()
+
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	testCases := []struct {
+		Name          string
+		Timeout       time.Duration
+		Results       []*RetryError
+		ExpectedErr   error
+		ExpectedCalls int
+	}{
+		{
+			Name:          "success",
+			Timeout:       time.Second,
+			Results:       []*RetryError{nil},
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "success after a retry",
+			Timeout:       5 * time.Second,
+			Results:       []*RetryError{RetryableError(errTemporary), nil},
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "non-retryable error",
+			Timeout:       time.Second,
+			Results:       []*RetryError{NonRetryableError(errPermanent)},
+			ExpectedErr:   errPermanent,
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "timeout returns the last error",
+			Timeout:       100 * time.Millisecond,
+			Results:       []*RetryError{RetryableError(errTemporary), nil},
+			ExpectedErr:   errTemporary,
+			ExpectedCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			err := RetryContext(context.Background(), testCase.Timeout, func() *RetryError {
+				calls++
+				return testCase.Results[calls-1]
+			})
+
+			if !errors.Is(err, testCase.ExpectedErr) {
+				t.Errorf("expected error %v, got %v", testCase.ExpectedErr, err)
+			}
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("expected %d calls, got %d", testCase.ExpectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	err := RetryContext(ctx, time.Minute, func() *RetryError {
+		calls++
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no call, got %d", calls)
+	}
+}
+
+func TestRetryContextCancelledWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errTemporary := errors.New("temporary")
+
+	start := time.Now()
+	err := RetryContext(ctx, time.Minute, func() *RetryError {
+		cancel()
+		return RetryableError(errTemporary)
+	})
+
+	if !errors.Is(err, errTemporary) {
+		t.Errorf("expected the last error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= minBackoff {
+		t.Errorf("expected to stop without waiting, took %s", elapsed)
+	}
+}
+
+func TestNilRetryErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, rerr := range []*RetryError{RetryableError(nil), NonRetryableError(nil)} {
+		if rerr.Err == nil || rerr.Retryable {
+			t.Errorf("expected a non-retryable error, got %#v", rerr)
+		}
+	}
+}
@@ -3,7 +3,7 @@ package tfresource
 import (
 	"errors"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"terraform-provider-fastssm/internal/retry"
 )
 
 // NotFound returns true if the error represents a "resource not found" condition.
@@ -4,10 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"terraform-provider-fastssm/internal/retry"
 	"terraform-provider-fastssm/internal/tfresource"
 	"testing"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 func TestNotFound(t *testing.T) {
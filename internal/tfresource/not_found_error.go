@@ -1,7 +1,7 @@
 package tfresource
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"terraform-provider-fastssm/internal/retry"
 )
 
 type EmptyResultError struct {
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"terraform-provider-fastssm/internal/retry"
 )
 
 func TestEmptyResultErrorAsNotFoundError(t *testing.T) {
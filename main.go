@@ -32,6 +32,9 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Serve returns once Terraform is done with the provider
+	provider.FlushMetrics()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}